@@ -0,0 +1,331 @@
+package vcd
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// Catalog items and media are downloaded from the transfer service of VCD. The download must be enabled on the
+// vApp template or media first, which gives the "download:default" links. Files are downloaded in pieces with HTTP
+// range requests, and their size is checked against the one reported by VCD.
+
+const (
+	catalogDownloadFormatOva = "ova"
+	catalogDownloadFormatOvf = "ovf"
+)
+
+// ovfDescriptorReferences is the part of an OVF descriptor that lists the files of the vApp template
+type ovfDescriptorReferences struct {
+	XMLName    xml.Name `xml:"Envelope"`
+	References struct {
+		File []struct {
+			Href string `xml:"href,attr"`
+			Size int64  `xml:"size,attr"`
+		} `xml:"File"`
+	} `xml:"References"`
+}
+
+// catalogDownloadFile is a file to download, with the size reported by VCD. A size of 0 means unknown
+type catalogDownloadFile struct {
+	name string
+	href string
+	size int64
+}
+
+// catalogDownloader downloads files from VCD, optionally writing the progress to the Terraform output
+type catalogDownloader struct {
+	client         *govcd.Client
+	pieceSize      int64
+	showProgress   bool
+	progressPrefix string
+}
+
+// enableDownload makes a vApp template or a media available for download, and waits for the task
+func enableDownload(client *govcd.Client, href string) error {
+	task, err := client.ExecuteTaskRequest(href+"/action/enableDownload", http.MethodPost, "",
+		"error enabling download: %s", nil)
+	if err != nil {
+		return err
+	}
+	return task.WaitTaskCompletion()
+}
+
+// getDownloadLink returns the HREF of the "download:default" link, or an empty string
+func getDownloadLink(links types.LinkList) string {
+	for _, link := range links {
+		if link.Rel == types.RelDownloadDefault {
+			return link.HREF
+		}
+	}
+	return ""
+}
+
+// parseOvfDescriptorFiles returns the files referenced by an OVF descriptor, which are next to it in the transfer
+// folder
+func parseOvfDescriptorFiles(descriptor []byte, descriptorHref string) ([]catalogDownloadFile, error) {
+	references := ovfDescriptorReferences{}
+	err := xml.Unmarshal(descriptor, &references)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing OVF descriptor: %s", err)
+	}
+	baseHref := strings.TrimSuffix(descriptorHref, path.Base(descriptorHref))
+	var files []catalogDownloadFile
+	for _, file := range references.References.File {
+		if file.Href == "" || strings.Contains(file.Href, "..") || path.IsAbs(file.Href) {
+			return nil, fmt.Errorf("OVF descriptor references an invalid file '%s'", file.Href)
+		}
+		files = append(files, catalogDownloadFile{name: file.Href, href: baseHref + file.Href, size: file.Size})
+	}
+	return files, nil
+}
+
+// getDownloadRanges splits a file of the given size into byte ranges of at most pieceSize bytes
+func getDownloadRanges(size, pieceSize int64) [][2]int64 {
+	var ranges [][2]int64
+	for start := int64(0); start < size; start += pieceSize {
+		end := start + pieceSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		ranges = append(ranges, [2]int64{start, end})
+	}
+	return ranges
+}
+
+// get runs a GET request on a transfer HREF, optionally for a byte range
+func (downloader *catalogDownloader) get(href string, byteRange *[2]int64) (*http.Response, error) {
+	downloadUrl, err := url.ParseRequestURI(href)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing download URL %s: %s", href, err)
+	}
+	request := downloader.client.NewRequest(nil, http.MethodGet, *downloadUrl, nil)
+	if byteRange != nil {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", byteRange[0], byteRange[1]))
+	}
+	response, err := downloader.client.Http.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %s", href, err)
+	}
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusPartialContent {
+		_ = response.Body.Close()
+		return nil, fmt.Errorf("error downloading %s: %s", href, response.Status)
+	}
+	return response, nil
+}
+
+// download writes a file into the target path. A file with a known size is downloaded in pieces. When the server
+// ignores the range of a request, the whole file is taken from the first response
+func (downloader *catalogDownloader) download(file catalogDownloadFile, target string) error {
+	output, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %s", target, err)
+	}
+	defer output.Close()
+
+	if file.size == 0 {
+		response, err := downloader.get(file.href, nil)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		_, err = io.Copy(output, response.Body)
+		return err
+	}
+
+	var written int64
+	for _, byteRange := range getDownloadRanges(file.size, downloader.pieceSize) {
+		currentRange := byteRange
+		response, err := downloader.get(file.href, &currentRange)
+		if err != nil {
+			return err
+		}
+		copied, err := io.Copy(output, response.Body)
+		_ = response.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error writing %s: %s", target, err)
+		}
+		written += copied
+		downloader.printProgress(file.name, written, file.size)
+		if response.StatusCode == http.StatusOK {
+			break
+		}
+	}
+	if written != file.size {
+		return fmt.Errorf("downloaded %d bytes for %s, while VCD reports %d bytes", written, file.name, file.size)
+	}
+	return nil
+}
+
+// printProgress writes the download progress of a file into the Terraform output, when requested
+func (downloader *catalogDownloader) printProgress(name string, written, size int64) {
+	log.Printf("[TRACE] download of %s: %d/%d bytes", name, written, size)
+	if !downloader.showProgress {
+		return
+	}
+	_, _ = fmt.Fprintf(getTerraformStdout(), "%s: download progress of %s %.2f%%\n", downloader.progressPrefix,
+		name, float64(written)*100/float64(size))
+}
+
+// downloadOvf downloads the OVF descriptor of a vApp template, with the name "<name>.ovf", and the files it
+// references into a folder
+func (downloader *catalogDownloader) downloadOvf(descriptorHref, name, folder string) error {
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating folder %s: %s", folder, err)
+	}
+	descriptorPath := filepath.Join(folder, name+".ovf")
+	err = downloader.download(catalogDownloadFile{name: name + ".ovf", href: descriptorHref}, descriptorPath)
+	if err != nil {
+		return err
+	}
+	descriptor, err := ioutil.ReadFile(descriptorPath)
+	if err != nil {
+		return err
+	}
+	files, err := parseOvfDescriptorFiles(descriptor, descriptorHref)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		err = downloader.download(file, filepath.Join(folder, filepath.FromSlash(file.name)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getOvfFileNames returns the name of the OVF descriptor of a folder, followed by the names of the files it references
+func getOvfFileNames(folder, descriptorName string) ([]string, error) {
+	descriptor, err := ioutil.ReadFile(filepath.Join(folder, descriptorName))
+	if err != nil {
+		return nil, err
+	}
+	files, err := parseOvfDescriptorFiles(descriptor, descriptorName)
+	if err != nil {
+		return nil, err
+	}
+	names := []string{descriptorName}
+	for _, file := range files {
+		names = append(names, file.name)
+	}
+	return names, nil
+}
+
+// packOva writes an OVA archive with the OVF descriptor first, followed by the files it references, as required by
+// the OVF specification. The archive is removed when it can't be completed
+func packOva(folder, descriptorName, target string) error {
+	names, err := getOvfFileNames(folder, descriptorName)
+	if err != nil {
+		return err
+	}
+
+	output, err := os.Create(target)
+	if err != nil {
+		return fmt.Errorf("error creating %s: %s", target, err)
+	}
+	err = writeOva(output, folder, names, target)
+	closeErr := output.Close()
+	if err == nil && closeErr != nil {
+		err = fmt.Errorf("error closing %s: %s", target, closeErr)
+	}
+	if err != nil {
+		_ = os.Remove(target)
+		return err
+	}
+	return nil
+}
+
+// writeOva writes the files of an OVF folder into a tar archive, in the given order
+func writeOva(output io.Writer, folder string, names []string, target string) error {
+	archive := tar.NewWriter(output)
+	for _, name := range names {
+		err := addFileToTar(archive, filepath.Join(folder, filepath.FromSlash(name)), name)
+		if err != nil {
+			return fmt.Errorf("error adding %s to %s: %s", name, target, err)
+		}
+	}
+	return archive.Close()
+}
+
+// addFileToTar copies a file into a tar archive with the given name
+func addFileToTar(archive *tar.Writer, source, name string) error {
+	input, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer input.Close()
+	info, err := input.Stat()
+	if err != nil {
+		return err
+	}
+	err = archive.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: info.ModTime()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(archive, input)
+	return err
+}
+
+// fileSha256 returns the SHA-256 checksum of a file, in hexadecimal
+func fileSha256(filePath string) (string, error) {
+	input, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer input.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, input)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ovfFolderSha256 returns the checksum of an OVF folder, which covers the descriptor and every file it references.
+// It is the SHA-256 checksum of the list written by "sha256sum <descriptor> <files>", in the order of the descriptor
+func ovfFolderSha256(folder, descriptorName string) (string, error) {
+	names, err := getOvfFileNames(folder, descriptorName)
+	if err != nil {
+		return "", err
+	}
+	var list strings.Builder
+	for _, name := range names {
+		checksum, err := fileSha256(filepath.Join(folder, filepath.FromSlash(name)))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&list, "%s  %s\n", checksum, name)
+	}
+	hash := sha256.Sum256([]byte(list.String()))
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// verifyChecksum computes the checksum of a download and compares it with the expected one, when set. A download
+// with a different checksum is removed
+func verifyChecksum(downloadPath, expected string, checksumFunc func(string) (string, error)) (string, error) {
+	checksum, err := checksumFunc(downloadPath)
+	if err != nil {
+		return "", fmt.Errorf("error computing checksum of %s: %s", downloadPath, err)
+	}
+	if expected != "" && !strings.EqualFold(checksum, expected) {
+		_ = os.RemoveAll(downloadPath)
+		return "", fmt.Errorf("checksum of %s is %s, expected %s. The download was removed", downloadPath, checksum, expected)
+	}
+	return checksum, nil
+}
@@ -0,0 +1,246 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+const testOvfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<ovf:Envelope xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <ovf:References>
+    <ovf:File ovf:href="disk-0.vmdk" ovf:id="file1" ovf:size="10"/>
+    <ovf:File ovf:href="disk-1.vmdk" ovf:id="file2" ovf:size="3"/>
+  </ovf:References>
+</ovf:Envelope>`
+
+func TestGetDownloadRanges(t *testing.T) {
+	tests := []struct {
+		size, pieceSize int64
+		expected        [][2]int64
+	}{
+		{0, 4, nil},
+		{4, 4, [][2]int64{{0, 3}}},
+		{10, 4, [][2]int64{{0, 3}, {4, 7}, {8, 9}}},
+		{3, 10, [][2]int64{{0, 2}}},
+	}
+	for _, test := range tests {
+		ranges := getDownloadRanges(test.size, test.pieceSize)
+		if !reflect.DeepEqual(ranges, test.expected) {
+			t.Errorf("size %d, piece %d: expected %v, got %v", test.size, test.pieceSize, test.expected, ranges)
+		}
+	}
+}
+
+func TestParseOvfDescriptorFiles(t *testing.T) {
+	files, err := parseOvfDescriptorFiles([]byte(testOvfDescriptor), "https://vcd.example.com/transfer/abc/descriptor.ovf")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := []catalogDownloadFile{
+		{name: "disk-0.vmdk", href: "https://vcd.example.com/transfer/abc/disk-0.vmdk", size: 10},
+		{name: "disk-1.vmdk", href: "https://vcd.example.com/transfer/abc/disk-1.vmdk", size: 3},
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("expected %v, got %v", expected, files)
+	}
+
+	invalid := strings.Replace(testOvfDescriptor, "disk-1.vmdk", "../disk-1.vmdk", 1)
+	_, err = parseOvfDescriptorFiles([]byte(invalid), "descriptor.ovf")
+	if err == nil {
+		t.Errorf("expected error for a file outside of the transfer folder")
+	}
+}
+
+// TestCatalogDownloaderPieces downloads a file in pieces from a server that supports ranges, and from one that
+// ignores them
+func TestCatalogDownloaderPieces(t *testing.T) {
+	content := []byte("0123456789")
+	for _, supportsRanges := range []bool{true, false} {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requests++
+			if !supportsRanges {
+				request.Header.Del("Range")
+			}
+			http.ServeContent(writer, request, "disk.vmdk", time.Time{}, bytes.NewReader(content))
+		}))
+
+		target := filepath.Join(t.TempDir(), "disk.vmdk")
+		downloader := &catalogDownloader{client: &govcd.Client{}, pieceSize: 4}
+		err := downloader.download(catalogDownloadFile{name: "disk.vmdk", href: server.URL + "/disk.vmdk", size: 10}, target)
+		server.Close()
+		if err != nil {
+			t.Fatalf("unexpected error (ranges %t): %s", supportsRanges, err)
+		}
+		written, err := ioutil.ReadFile(target)
+		if err != nil {
+			t.Fatalf("error reading %s: %s", target, err)
+		}
+		if !bytes.Equal(written, content) {
+			t.Errorf("expected '%s', got '%s' (ranges %t)", content, written, supportsRanges)
+		}
+		expectedRequests := 3
+		if !supportsRanges {
+			expectedRequests = 1
+		}
+		if requests != expectedRequests {
+			t.Errorf("expected %d requests, got %d (ranges %t)", expectedRequests, requests, supportsRanges)
+		}
+	}
+}
+
+func TestPackOva(t *testing.T) {
+	folder := t.TempDir()
+	files := map[string]string{
+		"item.ovf":    testOvfDescriptor,
+		"disk-0.vmdk": "0123456789",
+		"disk-1.vmdk": "abc",
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(folder, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("error writing %s: %s", name, err)
+		}
+	}
+
+	target := filepath.Join(t.TempDir(), "item.ova")
+	err := packOva(folder, "item.ovf", target)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	input, err := os.Open(target)
+	if err != nil {
+		t.Fatalf("error opening %s: %s", target, err)
+	}
+	defer input.Close()
+	archive := tar.NewReader(input)
+	var names []string
+	for {
+		header, err := archive.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, header.Name)
+		content, _ := ioutil.ReadAll(archive)
+		if string(content) != files[header.Name] {
+			t.Errorf("unexpected content for %s: '%s'", header.Name, content)
+		}
+	}
+	// The descriptor must be the first file of an OVA
+	expected := []string{"item.ovf", "disk-0.vmdk", "disk-1.vmdk"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected files %v, got %v", expected, names)
+	}
+}
+
+func TestPackOvaRemovesPartialArchive(t *testing.T) {
+	folder := t.TempDir()
+	// The second disk referenced by the descriptor is missing
+	for name, content := range map[string]string{"item.ovf": testOvfDescriptor, "disk-0.vmdk": "0123456789"} {
+		err := ioutil.WriteFile(filepath.Join(folder, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("error writing %s: %s", name, err)
+		}
+	}
+
+	target := filepath.Join(t.TempDir(), "item.ova")
+	err := packOva(folder, "item.ovf", target)
+	if err == nil {
+		t.Fatalf("expected error for a missing disk")
+	}
+	if _, statErr := os.Stat(target); !os.IsNotExist(statErr) {
+		t.Errorf("expected %s to be removed after a failure", target)
+	}
+}
+
+func TestOvfFolderSha256(t *testing.T) {
+	folder := t.TempDir()
+	files := map[string]string{
+		"item.ovf":    testOvfDescriptor,
+		"disk-0.vmdk": "0123456789",
+		"disk-1.vmdk": "abc",
+	}
+	for name, content := range files {
+		err := ioutil.WriteFile(filepath.Join(folder, name), []byte(content), 0644)
+		if err != nil {
+			t.Fatalf("error writing %s: %s", name, err)
+		}
+	}
+
+	// Same as "sha256sum item.ovf disk-0.vmdk disk-1.vmdk | sha256sum"
+	var list strings.Builder
+	for _, name := range []string{"item.ovf", "disk-0.vmdk", "disk-1.vmdk"} {
+		checksum, err := fileSha256(filepath.Join(folder, name))
+		if err != nil {
+			t.Fatalf("error computing checksum of %s: %s", name, err)
+		}
+		list.WriteString(checksum + "  " + name + "\n")
+	}
+	hash := sha256.Sum256([]byte(list.String()))
+	expected := hex.EncodeToString(hash[:])
+
+	checksum, err := ovfFolderSha256(folder, "item.ovf")
+	if err != nil || checksum != expected {
+		t.Fatalf("expected checksum %s, got %s (%v)", expected, checksum, err)
+	}
+
+	// A change in a disk changes the checksum of the folder
+	err = ioutil.WriteFile(filepath.Join(folder, "disk-1.vmdk"), []byte("abd"), 0644)
+	if err != nil {
+		t.Fatalf("error writing disk-1.vmdk: %s", err)
+	}
+	changed, err := ovfFolderSha256(folder, "item.ovf")
+	if err != nil || changed == checksum {
+		t.Errorf("expected a different checksum after changing a disk, got %s (%v)", changed, err)
+	}
+
+	err = os.Remove(filepath.Join(folder, "disk-0.vmdk"))
+	if err != nil {
+		t.Fatalf("error removing disk-0.vmdk: %s", err)
+	}
+	_, err = ovfFolderSha256(folder, "item.ovf")
+	if !os.IsNotExist(err) {
+		t.Errorf("expected a missing file error, got %v", err)
+	}
+}
+
+func TestVerifyChecksum(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "media.iso")
+	err := ioutil.WriteFile(target, []byte("iso"), 0644)
+	if err != nil {
+		t.Fatalf("error writing %s: %s", target, err)
+	}
+	// SHA-256 of "iso"
+	expected := "e0e4548df88a35d5854d052281c5deedad16f286f82cb2c23f2f9dea494834ac"
+	checksum, err := verifyChecksum(target, "", fileSha256)
+	if err != nil || checksum != expected {
+		t.Errorf("expected checksum %s, got %s (%v)", expected, checksum, err)
+	}
+	_, err = verifyChecksum(target, strings.ToUpper(expected), fileSha256)
+	if err != nil {
+		t.Errorf("unexpected error with the same checksum: %s", err)
+	}
+	_, err = verifyChecksum(target, strings.Repeat("0", 64), fileSha256)
+	if err == nil {
+		t.Errorf("expected error for a different checksum")
+	}
+	if _, statErr := os.Stat(target); !os.IsNotExist(statErr) {
+		t.Errorf("expected %s to be removed after a checksum mismatch", target)
+	}
+}
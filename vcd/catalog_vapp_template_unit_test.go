@@ -0,0 +1,68 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestCaptureVAppParamsXml(t *testing.T) {
+	params := &captureVAppParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		Name:        "golden",
+		Description: "golden image",
+		Source:      &types.Reference{HREF: "https://vcd.example.com/api/vApp/vapp-da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3"},
+		CustomizationSection: &captureCustomizationSection{
+			Info:                   "VApp template customization section",
+			CustomizeOnInstantiate: true,
+		},
+	}
+	out, err := xml.Marshal(params)
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+	payload := string(out)
+	for _, expected := range []string{
+		`<CaptureVAppParams xmlns="` + types.XMLNamespaceVCloud + `" xmlns:ovf="` + types.XMLNamespaceOVF + `" name="golden">`,
+		`<Description>golden image</Description>`,
+		`<Source href="https://vcd.example.com/api/vApp/vapp-da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3"`,
+		`<ovf:CustomizationSection><ovf:Info>VApp template customization section</ovf:Info>` +
+			`<CustomizeOnInstantiate>true</CustomizeOnInstantiate></ovf:CustomizationSection>`,
+	} {
+		if !strings.Contains(payload, expected) {
+			t.Errorf("expected '%s' in payload %s", expected, payload)
+		}
+	}
+}
+
+func TestCatalogEntityRenameXml(t *testing.T) {
+	out, err := xml.Marshal(&catalogEntityRename{
+		XMLName:     xml.Name{Local: "CatalogItem"},
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        "golden",
+		Description: "golden image",
+		Entity:      &types.Reference{HREF: "https://vcd.example.com/api/vAppTemplate/vappTemplate-1"},
+	})
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+	expected := `<CatalogItem xmlns="` + types.XMLNamespaceVCloud + `" name="golden"><Description>golden image</Description>` +
+		`<Entity href="https://vcd.example.com/api/vAppTemplate/vappTemplate-1"></Entity></CatalogItem>`
+	if string(out) != expected {
+		t.Errorf("expected payload %s, got %s", expected, out)
+	}
+
+	out, err = xml.Marshal(&catalogEntityRename{XMLName: xml.Name{Local: "VAppTemplate"}, Xmlns: types.XMLNamespaceVCloud, Name: "golden"})
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+	if !strings.HasPrefix(string(out), `<VAppTemplate xmlns="`+types.XMLNamespaceVCloud+`" name="golden">`) ||
+		strings.Contains(string(out), "Entity") {
+		t.Errorf("unexpected vApp template payload %s", out)
+	}
+}
@@ -57,6 +57,11 @@ func findCatalogItem(d *schema.ResourceData, vcdClient *VCDClient, origin string
 	}
 
 	catalog, err := adminOrg.GetCatalogByName(d.Get("catalog").(string), false)
+	if govcd.ContainsNotFound(err) && origin == "resource" {
+		log.Printf("[INFO] Unable to find catalog %s. Removing catalog item from tfstate", d.Get("catalog").(string))
+		d.SetId("")
+		return nil, nil
+	}
 	if err != nil {
 		log.Printf("[DEBUG] Unable to find catalog.")
 		return nil, fmt.Errorf("unable to find catalog: %s", err)
@@ -0,0 +1,176 @@
+package vcd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// cloud_init is converted into the guest properties read by the VMware datasource of cloud-init.
+// The properties are stored in the OVF product section together with the ones from "guest_properties"
+
+const (
+	cloudInitUserDataKey         = "guestinfo.userdata"
+	cloudInitUserDataEncodingKey = "guestinfo.userdata.encoding"
+	cloudInitMetaDataKey         = "guestinfo.metadata"
+	cloudInitMetaDataEncodingKey = "guestinfo.metadata.encoding"
+
+	cloudInitEncodingBase64     = "base64"
+	cloudInitEncodingGzipBase64 = "gzip+base64"
+
+	// cloudInitMaxEncodedSize is the maximum size of an encoded guest property
+	cloudInitMaxEncodedSize = 64 * 1024
+)
+
+// cloudInitSchema is the schema of the "cloud_init" block, used by vcd_vapp and vcd_vapp_vm
+func cloudInitSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"user_data": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Cloud-init user data, such as a #cloud-config document",
+				},
+				"meta_data": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Cloud-init instance metadata, in YAML or JSON",
+				},
+				"network_config": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Cloud-init network configuration. It is added to the metadata",
+				},
+				"encoding": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      cloudInitEncodingBase64,
+					ValidateFunc: validation.StringInSlice([]string{cloudInitEncodingBase64, cloudInitEncodingGzipBase64}, false),
+					Description:  "Encoding of user data and metadata. One of 'base64', 'gzip+base64'",
+				},
+			},
+		},
+	}
+}
+
+// getCloudInitProperties converts the "cloud_init" block into guest properties.
+// It returns an empty map when the block is not set, or when the resource doesn't have it
+func getCloudInitProperties(d *schema.ResourceData) (map[string]string, error) {
+	properties := make(map[string]string)
+	cloudInitList, ok := d.Get("cloud_init").([]interface{})
+	if !ok || len(cloudInitList) == 0 || cloudInitList[0] == nil {
+		return properties, nil
+	}
+	cloudInit := cloudInitList[0].(map[string]interface{})
+	encoding := cloudInit["encoding"].(string)
+
+	userData := cloudInit["user_data"].(string)
+	if userData != "" {
+		encodedUserData, err := encodeCloudInitData(userData, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding cloud-init user data: %s", err)
+		}
+		properties[cloudInitUserDataKey] = encodedUserData
+		properties[cloudInitUserDataEncodingKey] = encoding
+	}
+
+	metaData, err := buildCloudInitMetaData(cloudInit["meta_data"].(string), cloudInit["network_config"].(string))
+	if err != nil {
+		return nil, err
+	}
+	if metaData != "" {
+		encodedMetaData, err := encodeCloudInitData(metaData, encoding)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding cloud-init metadata: %s", err)
+		}
+		properties[cloudInitMetaDataKey] = encodedMetaData
+		properties[cloudInitMetaDataEncodingKey] = encoding
+	}
+	return properties, nil
+}
+
+// isCloudInitProperty returns true for the guest properties that are managed by "cloud_init"
+func isCloudInitProperty(key string) bool {
+	switch key {
+	case cloudInitUserDataKey, cloudInitUserDataEncodingKey, cloudInitMetaDataKey, cloudInitMetaDataEncodingKey:
+		return true
+	}
+	return false
+}
+
+// encodeCloudInitData encodes user data or metadata, and checks that the result fits in a guest property.
+// The gzip header has no timestamp, so that the same data always gives the same result
+func encodeCloudInitData(data, encoding string) (string, error) {
+	raw := []byte(data)
+	switch encoding {
+	case cloudInitEncodingBase64:
+	case cloudInitEncodingGzipBase64:
+		var buffer bytes.Buffer
+		writer := gzip.NewWriter(&buffer)
+		_, err := writer.Write(raw)
+		if err != nil {
+			return "", err
+		}
+		err = writer.Close()
+		if err != nil {
+			return "", err
+		}
+		raw = buffer.Bytes()
+	default:
+		return "", fmt.Errorf("unknown encoding '%s'", encoding)
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(raw)
+	if len(encoded) > cloudInitMaxEncodedSize {
+		return "", fmt.Errorf("encoded size is %d bytes, more than the maximum of %d. Using encoding '%s' may help",
+			len(encoded), cloudInitMaxEncodedSize, cloudInitEncodingGzipBase64)
+	}
+	return encoded, nil
+}
+
+// buildCloudInitMetaData adds the network configuration to the metadata, as the "network" key expected by the
+// VMware datasource of cloud-init. The network configuration is always base64 encoded.
+// JSON metadata is merged as an object. Any other metadata is considered YAML, and the keys are appended to it
+func buildCloudInitMetaData(metaData, networkConfig string) (string, error) {
+	if networkConfig == "" {
+		return metaData, nil
+	}
+	encodedNetwork := base64.StdEncoding.EncodeToString([]byte(networkConfig))
+
+	trimmed := strings.TrimSpace(metaData)
+	if trimmed != "" && !strings.HasPrefix(trimmed, "{") {
+		return strings.TrimRight(metaData, "\n") + "\nnetwork: " + encodedNetwork +
+			"\nnetwork.encoding: " + cloudInitEncodingBase64 + "\n", nil
+	}
+
+	content := make(map[string]interface{})
+	if trimmed != "" {
+		err := json.Unmarshal([]byte(trimmed), &content)
+		if err != nil {
+			return "", fmt.Errorf("cloud-init metadata is not a valid JSON object: %s", err)
+		}
+	}
+	if _, ok := content["network"]; ok {
+		return "", fmt.Errorf("cloud-init metadata already contains the 'network' key. Use either 'network_config' or the metadata")
+	}
+	content["network"] = encodedNetwork
+	content["network.encoding"] = cloudInitEncodingBase64
+
+	// Map keys are sorted by json.Marshal, which keeps the result stable
+	result, err := json.Marshal(content)
+	if err != nil {
+		return "", err
+	}
+	return string(result), nil
+}
@@ -0,0 +1,111 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestEncodeCloudInitData(t *testing.T) {
+	data := "#cloud-config\npackages:\n  - nginx\n"
+	for _, encoding := range []string{cloudInitEncodingBase64, cloudInitEncodingGzipBase64} {
+		encoded, err := encodeCloudInitData(data, encoding)
+		if err != nil {
+			t.Fatalf("[%s] unexpected error: %s", encoding, err)
+		}
+		raw, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			t.Fatalf("[%s] result is not base64: %s", encoding, err)
+		}
+		if encoding == cloudInitEncodingGzipBase64 {
+			reader, err := gzip.NewReader(bytes.NewReader(raw))
+			if err != nil {
+				t.Fatalf("[%s] result is not gzip: %s", encoding, err)
+			}
+			raw, err = ioutil.ReadAll(reader)
+			if err != nil {
+				t.Fatalf("[%s] error decompressing: %s", encoding, err)
+			}
+		}
+		if string(raw) != data {
+			t.Errorf("[%s] expected %q, got %q", encoding, data, string(raw))
+		}
+
+		again, _ := encodeCloudInitData(data, encoding)
+		if again != encoded {
+			t.Errorf("[%s] encoding is not stable", encoding)
+		}
+	}
+
+	_, err := encodeCloudInitData(data, "unknown")
+	if err == nil {
+		t.Errorf("expected error for unknown encoding")
+	}
+
+	// Repeated data compresses well: it only fits with gzip
+	large := strings.Repeat("x", cloudInitMaxEncodedSize)
+	_, err = encodeCloudInitData(large, cloudInitEncodingBase64)
+	if err == nil {
+		t.Errorf("expected error for data larger than %d bytes", cloudInitMaxEncodedSize)
+	}
+	_, err = encodeCloudInitData(large, cloudInitEncodingGzipBase64)
+	if err != nil {
+		t.Errorf("compressed data should fit: %s", err)
+	}
+}
+
+func TestBuildCloudInitMetaData(t *testing.T) {
+	network := "version: 2\n"
+	encodedNetwork := base64.StdEncoding.EncodeToString([]byte(network))
+
+	tests := []struct {
+		name          string
+		metaData      string
+		networkConfig string
+		expected      string
+		expectError   bool
+	}{
+		{"no-network", "local-hostname: vm1\n", "", "local-hostname: vm1\n", false},
+		{"empty", "", network,
+			`{"network":"` + encodedNetwork + `","network.encoding":"base64"}`, false},
+		{"json", `{"local-hostname": "vm1"}`, network,
+			`{"local-hostname":"vm1","network":"` + encodedNetwork + `","network.encoding":"base64"}`, false},
+		{"yaml", "local-hostname: vm1\n", network,
+			"local-hostname: vm1\nnetwork: " + encodedNetwork + "\nnetwork.encoding: base64\n", false},
+		{"json-duplicate", `{"network": "abc"}`, network, "", true},
+		{"json-invalid", `{"local-hostname": `, network, "", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := buildCloudInitMetaData(test.metaData, test.networkConfig)
+			if test.expectError {
+				if err == nil {
+					t.Errorf("expected error, got %q", result)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if result != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsCloudInitProperty(t *testing.T) {
+	for _, key := range []string{cloudInitUserDataKey, cloudInitUserDataEncodingKey, cloudInitMetaDataKey, cloudInitMetaDataEncodingKey} {
+		if !isCloudInitProperty(key) {
+			t.Errorf("expected %s to be a cloud-init property", key)
+		}
+	}
+	if isCloudInitProperty("guestinfo.hostname") {
+		t.Errorf("guestinfo.hostname is not a cloud-init property")
+	}
+}
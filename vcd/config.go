@@ -134,12 +134,17 @@ func debugPrintf(format string, args ...interface{}) {
 // This is a global mutexKV for all resources
 var vcdMutexKV = newMutexKV()
 
+// getVappLockKey returns the key that locks a vApp
+func getVappLockKey(orgName, vdcName, vappName string) string {
+	return fmt.Sprintf("org:%s|vdc:%s|vapp:%s", orgName, vdcName, vappName)
+}
+
 func (cli *VCDClient) lockVapp(d *schema.ResourceData) {
 	vappName := d.Get("name").(string)
 	if vappName == "" {
 		panic("vApp name not found")
 	}
-	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
+	key := getVappLockKey(cli.getOrgName(d), cli.getVdcName(d), vappName)
 	vcdMutexKV.kvLock(key)
 }
 
@@ -148,7 +153,7 @@ func (cli *VCDClient) unLockVapp(d *schema.ResourceData) {
 	if vappName == "" {
 		panic("vApp name not found")
 	}
-	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
+	key := getVappLockKey(cli.getOrgName(d), cli.getVdcName(d), vappName)
 	vcdMutexKV.kvUnlock(key)
 }
 
@@ -182,7 +187,7 @@ func (cli *VCDClient) lockParentVappWithName(d *schema.ResourceData, vappName st
 	if vappName == "" {
 		panic("vApp name not found")
 	}
-	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
+	key := getVappLockKey(cli.getOrgName(d), cli.getVdcName(d), vappName)
 	vcdMutexKV.kvLock(key)
 }
 
@@ -190,7 +195,7 @@ func (cli *VCDClient) unLockParentVappWithName(d *schema.ResourceData, vappName
 	if vappName == "" {
 		panic("vApp name not found")
 	}
-	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
+	key := getVappLockKey(cli.getOrgName(d), cli.getVdcName(d), vappName)
 	vcdMutexKV.kvUnlock(key)
 }
 
@@ -201,7 +206,7 @@ func (cli *VCDClient) lockParentVapp(d *schema.ResourceData) {
 	if vappName == "" {
 		panic("vApp name not found")
 	}
-	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
+	key := getVappLockKey(cli.getOrgName(d), cli.getVdcName(d), vappName)
 	vcdMutexKV.kvLock(key)
 }
 
@@ -210,7 +215,7 @@ func (cli *VCDClient) unLockParentVapp(d *schema.ResourceData) {
 	if vappName == "" {
 		panic("vApp name not found")
 	}
-	key := fmt.Sprintf("org:%s|vdc:%s|vapp:%s", cli.getOrgName(d), cli.getVdcName(d), vappName)
+	key := getVappLockKey(cli.getOrgName(d), cli.getVdcName(d), vappName)
 	vcdMutexKV.kvUnlock(key)
 }
 
@@ -86,6 +86,7 @@ type TestConfig struct {
 			NetworkPool     string `json:"networkPool"`
 			StorageProfile  string `json:"storageProfile"`
 			StorageProfile2 string `json:"storageProfile2"`
+			VmGroupId       string `json:"vmGroupId,omitempty"`
 		} `json:"providerVdc"`
 		NsxtProviderVdc struct {
 			Name           string `json:"name"`
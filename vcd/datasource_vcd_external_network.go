@@ -6,7 +6,7 @@ import (
 
 func datasourceVcdExternalNetwork() *schema.Resource {
 	return &schema.Resource{
-		Read: resourceVcdExternalNetworkRead,
+		Read: datasourceVcdExternalNetworkRead,
 		Schema: map[string]*schema.Schema{
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
@@ -101,3 +101,7 @@ func datasourceVcdExternalNetwork() *schema.Resource {
 		},
 	}
 }
+
+func datasourceVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdExternalNetworkRead(d, meta, "datasource")
+}
@@ -10,12 +10,15 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 )
 
 type resourceRef struct {
 	name string
 	id   string
 	href string
+	// importId is the last element of the import path, when the resource is not imported by name
+	importId string
 }
 
 func datasourceVcdResourceList() *schema.Resource {
@@ -35,9 +38,11 @@ func datasourceVcdResourceList() *schema.Resource {
 			},
 			// Parent will be needed for:
 			// * VM (parent: vApp)
+			// * vApp networks, vApp org networks and vApp network rules (vApp)
+			// * VM internal disks (vApp)
 			// * catalogItem (catalog)
 			// * mediaItem (catalog)
-			// * all edge gateway objects (NAT, firewall, lb)
+			// * all edge gateway objects (NAT, firewall, lb, VPN)
 			// When the parent is org or vdc, they are taken from the regular fields above
 			"parent": {
 				Type:        schema.TypeString,
@@ -201,16 +206,13 @@ func catalogItemList(d *schema.ResourceData, meta interface{}, wantMedia bool) (
 
 	for _, catalogItems := range catalog.Catalog.CatalogItems {
 		for _, reference := range catalogItems.CatalogItem {
-			wanted := true
 			catalogItem, err := catalog.GetCatalogItemByHref(reference.HREF)
 			if err != nil {
 				return list, err
 			}
-			if catalogItem.CatalogItem.Entity.Type == "application/vnd.vmware.vcloud.media+xml" {
-				wanted = wantMedia
-			}
+			isMedia := catalogItem.CatalogItem.Entity.Type == "application/vnd.vmware.vcloud.media+xml"
 
-			if wanted {
+			if isMedia == wantMedia {
 				items = append(items, resourceRef{
 					name: reference.Name,
 					id:   reference.ID,
@@ -220,7 +222,11 @@ func catalogItemList(d *schema.ResourceData, meta interface{}, wantMedia bool) (
 
 		}
 	}
-	return genericResourceList("vcd_catalog_item", listMode, nameIdSeparator, []string{org.AdminOrg.Name, catalogName}, items)
+	resType := "vcd_catalog_item"
+	if wantMedia {
+		resType = "vcd_catalog_media"
+	}
+	return genericResourceList(resType, listMode, nameIdSeparator, []string{org.AdminOrg.Name, catalogName}, items)
 }
 
 func vdcList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
@@ -326,7 +332,9 @@ func networkList(d *schema.ResourceData, meta interface{}) (list []string, err e
 	return list, nil
 }
 
-func edgeGatewayList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+// edgeGatewayList finds the edge gateways in a VDC. The same list is used for all the resources that are
+// imported using the edge gateway as last element (vcd_edgegateway, vcd_edgegateway_settings, vcd_nsxv_dhcp_relay)
+func edgeGatewayList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	listMode := d.Get("list_mode").(string)
@@ -347,16 +355,22 @@ func edgeGatewayList(d *schema.ResourceData, meta interface{}) (list []string, e
 		if err != nil {
 			return []string{}, err
 		}
+		// DHCP relay settings are only available for edge gateways with advanced networking
+		if resType == "vcd_nsxv_dhcp_relay" && !edgeGateway.HasAdvancedNetworking() {
+			continue
+		}
 		items = append(items, resourceRef{
 			name: edgeGateway.EdgeGateway.Name,
 			id:   edgeGateway.EdgeGateway.ID,
 			href: edgeGateway.EdgeGateway.HREF,
 		})
 	}
-	return genericResourceList("vcd_edgegateway", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(resType, listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func vappList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+// vappList finds the vApps in a VDC. The same list is used for vcd_vapp_access_control, which is imported
+// using the vApp as last element
+func vappList(d *schema.ResourceData, meta interface{}, resType string) (list []string, err error) {
 	client := meta.(*VCDClient)
 
 	listMode := d.Get("list_mode").(string)
@@ -379,41 +393,137 @@ func vappList(d *schema.ResourceData, meta interface{}) (list []string, err erro
 			}
 		}
 	}
-	return genericResourceList("vcd_vapp", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList(resType, listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
-func vappVmList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+func getVappDetails(d *schema.ResourceData, meta interface{}) (orgName string, vdcName string, listMode string, separator string, vapp *govcd.VApp, err error) {
 	client := meta.(*VCDClient)
 
-	listMode := d.Get("list_mode").(string)
-	nameIdSeparator := d.Get("name_id_separator").(string)
+	listMode = d.Get("list_mode").(string)
+	separator = d.Get("name_id_separator").(string)
 	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
 	if err != nil {
-		return list, err
+		return "", "", "", "", nil, err
 	}
 	vappName := d.Get("parent").(string)
 	if vappName == "" {
-		return list, fmt.Errorf(`vApp name (as "parent") is required for VM lists`)
+		return "", "", "", "", nil, fmt.Errorf(`vApp name (as "parent") is required for this task`)
+	}
+	vapp, err = vdc.GetVAppByName(vappName, false)
+	if err != nil {
+		return "", "", "", "", nil, fmt.Errorf("error retrieving vApp '%s': %s ", vappName, err)
+	}
+	return org.Org.Name, vdc.Vdc.Name, listMode, separator, vapp, nil
+}
+
+func vappVmList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcName, listMode, separator, vapp, err := getVappDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+
+	var items []resourceRef
+	if vapp.VApp.Children != nil {
+		for _, vm := range vapp.VApp.Children.VM {
+			items = append(items, resourceRef{
+				name: vm.Name,
+				id:   vm.ID,
+				href: vm.HREF,
+			})
+		}
 	}
-	vapp, err := vdc.GetVAppByName(vappName, false)
+	return genericResourceList("vcd_vapp_vm", listMode, separator, []string{orgName, vdcName, vapp.VApp.Name}, items)
+}
+
+// vappNetworkList finds either the vApp networks or the vApp org networks of a vApp.
+// The vApp networks list is also used for the vApp network rules (vcd_vapp_firewall_rules, vcd_vapp_nat_rules,
+// vcd_vapp_static_routing), which are imported using the vApp network as last element
+func vappNetworkList(d *schema.ResourceData, meta interface{}, resType string, wantOrgNetworks bool) (list []string, err error) {
+	orgName, vdcName, listMode, separator, vapp, err := getVappDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving vApp '%s': %s ", vappName, err)
+		return list, err
 	}
 
+	networkConfig, err := vapp.GetNetworkConfig()
+	if err != nil {
+		return list, fmt.Errorf("error retrieving vApp network configuration: %s ", err)
+	}
 	var items []resourceRef
-	for _, vm := range vapp.VApp.Children.VM {
+	for _, network := range networkConfig.NetworkConfig {
+		// "none" is a placeholder for VMs without network, not a real network
+		if network.NetworkName == types.NoneNetwork || network.Link == nil {
+			continue
+		}
+		if isVappOrgNetwork(&network) != wantOrgNetworks {
+			continue
+		}
+		networkId, err := govcd.GetUuidFromHref(network.Link.HREF, false)
+		if err != nil {
+			return list, fmt.Errorf("unable to get network ID from HREF: %s", err)
+		}
 		items = append(items, resourceRef{
-			name: vm.Name,
-			id:   vm.ID,
-			href: vm.HREF,
+			name: network.NetworkName,
+			id:   normalizeId("urn:vcloud:network:", networkId),
+			href: network.Link.HREF,
 		})
 	}
-	return genericResourceList("vcd_vapp_vm", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name, vappName}, items)
+	return genericResourceList(resType, listMode, separator, []string{orgName, vdcName, vapp.VApp.Name}, items)
+}
+
+// vmInternalDiskList finds the internal disks of all the VMs in a vApp
+func vmInternalDiskList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	orgName, vdcName, listMode, separator, vapp, err := getVappDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	if vapp.VApp.Children == nil {
+		return list, nil
+	}
+
+	for _, vmRef := range vapp.VApp.Children.VM {
+		vm, err := client.Client.GetVMByHref(vmRef.HREF)
+		if err != nil {
+			return []string{}, fmt.Errorf("error retrieving VM '%s': %s ", vmRef.Name, err)
+		}
+		if vm.VM.VmSpecSection == nil || vm.VM.VmSpecSection.DiskSection == nil {
+			continue
+		}
+		var items []resourceRef
+		for _, disk := range vm.VM.VmSpecSection.DiskSection.DiskSettings {
+			// API shows internal disk and independent disks in one list. If disk.Disk != nil then it's independent disk
+			if disk.Disk != nil {
+				continue
+			}
+			items = append(items, resourceRef{
+				name:     disk.DiskId,
+				id:       disk.DiskId,
+				href:     "",
+				importId: disk.DiskId,
+			})
+		}
+		vmList, err := genericResourceList("vcd_vm_internal_disk", listMode, separator, []string{orgName, vdcName, vapp.VApp.Name, vm.VM.Name}, items)
+		if err != nil {
+			return []string{}, err
+		}
+		list = append(list, vmList...)
+	}
+	return list, nil
 }
 
 func genericResourceList(resType, listMode, nameIdSeparator string, ancestors []string, refs []resourceRef) (list []string, err error) {
 
 	for _, ref := range refs {
+		importId := ref.name
+		if ref.importId != "" {
+			importId = ref.importId
+		}
+		// Entities without a name (such as NAT rules) use their import identifier as Terraform resource name
+		resourceName := ref.name
+		if resourceName == "" {
+			resourceName = importId
+		}
 		switch listMode {
 		case "name":
 			list = append(list, ref.name)
@@ -422,16 +532,14 @@ func genericResourceList(resType, listMode, nameIdSeparator string, ancestors []
 		case "name_id":
 			list = append(list, ref.name+nameIdSeparator+ref.id)
 		case "hierarchy":
-			list = append(list, strings.Join(ancestors, nameIdSeparator)+nameIdSeparator+ref.name)
+			list = append(list, strings.Join(append(ancestors, ref.name), nameIdSeparator))
 		case "href":
-			list = append(list, "")
+			list = append(list, ref.href)
 		case "import":
-			list = append(list, fmt.Sprintf("terraform import %s.%s %s%s%s",
+			list = append(list, fmt.Sprintf("terraform import %s.%s %s",
 				resType,
-				ref.name,
-				strings.Join(ancestors, ImportSeparator),
-				ImportSeparator,
-				ref.name))
+				resourceName,
+				strings.Join(append(ancestors, importId), ImportSeparator)))
 		}
 	}
 	return list, nil
@@ -460,7 +568,7 @@ func getEdgeGatewayDetails(d *schema.ResourceData, meta interface{}) (orgName st
 func lbServerPoolList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 	lbServerPoolList, err := edgeGateway.GetLbServerPools()
 	if err != nil {
@@ -481,7 +589,7 @@ func lbServerPoolList(d *schema.ResourceData, meta interface{}) (list []string,
 func lbServiceMonitorList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 
 	var items []resourceRef
@@ -503,7 +611,7 @@ func lbVirtualServerList(d *schema.ResourceData, meta interface{}) (list []strin
 
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 	var items []resourceRef
 	lbVirtualServerList, err := edgeGateway.GetLbVirtualServers()
@@ -523,7 +631,7 @@ func lbVirtualServerList(d *schema.ResourceData, meta interface{}) (list []strin
 func nsxvFirewallList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 
 	var items []resourceRef
@@ -533,9 +641,10 @@ func nsxvFirewallList(d *schema.ResourceData, meta interface{}) (list []string,
 	}
 	for _, fw := range fwRuleList {
 		items = append(items, resourceRef{
-			name: fw.Name,
-			id:   fw.ID,
-			href: "",
+			name:     fw.Name,
+			id:       fw.ID,
+			href:     "",
+			importId: fw.ID,
 		})
 	}
 	return genericResourceList("vcd_nsxv_firewall_rule", listMode, separator, []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
@@ -544,7 +653,7 @@ func nsxvFirewallList(d *schema.ResourceData, meta interface{}) (list []string,
 func lbAppRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 
 	var items []resourceRef
@@ -565,7 +674,7 @@ func lbAppRuleList(d *schema.ResourceData, meta interface{}) (list []string, err
 func lbAppProfileList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 
 	var items []resourceRef
@@ -609,13 +718,13 @@ func ipsetList(d *schema.ResourceData, meta interface{}) (list []string, err err
 			href: "",
 		})
 	}
-	return genericResourceList("vcd_ipset", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
+	return genericResourceList("vcd_nsxv_ip_set", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
 }
 
 func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (list []string, err error) {
 	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
 	if err != nil {
-		return list, fmt.Errorf("error retrieving edge gateway '%s': %s ", edgeGateway.EdgeGateway.Name, err)
+		return list, err
 	}
 
 	var items []resourceRef
@@ -626,13 +735,288 @@ func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (
 	for _, rule := range rules {
 		if rule.Action == natType {
 			items = append(items, resourceRef{
-				name: "",
-				id:   rule.ID,
+				name:     "",
+				id:       rule.ID,
+				href:     "",
+				importId: rule.ID,
+			})
+		}
+	}
+	return genericResourceList("vcd_nsxv_"+natType, listMode, separator, []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+func independentDiskList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return list, err
+	}
+
+	var items []resourceRef
+	for _, resourceEntities := range vdc.Vdc.ResourceEntities {
+		for _, resourceReference := range resourceEntities.ResourceEntity {
+			if resourceReference.Type == "application/vnd.vmware.vcloud.disk+xml" {
+				// Independent disk names are not unique: the import uses the ID
+				items = append(items, resourceRef{
+					name:     resourceReference.Name,
+					id:       resourceReference.ID,
+					href:     resourceReference.HREF,
+					importId: resourceReference.ID,
+				})
+			}
+		}
+	}
+	return genericResourceList("vcd_independent_disk", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
+}
+
+// standaloneVmList finds the VMs of a VDC that live in a vApp managed by VCD
+func standaloneVmList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return list, err
+	}
+
+	vmList, err := client.Client.QueryVmList(types.VmQueryFilterOnlyDeployed)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, vm := range vmList {
+		if !vm.AutoNature || !haveSameUuid(vm.VdcHREF, vdc.Vdc.HREF) {
+			continue
+		}
+		items = append(items, resourceRef{
+			name: vm.Name,
+			id:   normalizeId("urn:vcloud:vm:", extractUuid(vm.HREF)),
+			href: vm.HREF,
+		})
+	}
+	return genericResourceList("vcd_vm", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
+}
+
+func orgGroupList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+	org, err := client.GetAdminOrg(d.Get("org").(string))
+	if err != nil {
+		return list, err
+	}
+
+	var items []resourceRef
+	if org.AdminOrg.Groups != nil {
+		for _, group := range org.AdminOrg.Groups.Group {
+			items = append(items, resourceRef{
+				name: group.Name,
+				id:   group.ID,
+				href: group.HREF,
+			})
+		}
+	}
+	return genericResourceList("vcd_org_group", listMode, nameIdSeparator, []string{org.AdminOrg.Name}, items)
+}
+
+func vmAffinityRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+	org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return list, err
+	}
+
+	rules, err := vdc.GetAllVmAffinityRuleList()
+	if err != nil {
+		return list, fmt.Errorf("error retrieving VM affinity rule list: %s ", err)
+	}
+	var items []resourceRef
+	for _, rule := range rules {
+		// VM affinity rule names are not unique: the import uses the ID
+		items = append(items, resourceRef{
+			name:     rule.Name,
+			id:       rule.ID,
+			href:     rule.HREF,
+			importId: rule.ID,
+		})
+	}
+	return genericResourceList("vcd_vm_affinity_rule", listMode, nameIdSeparator, []string{org.Org.Name, vdc.Vdc.Name}, items)
+}
+
+func vmSizingPolicyList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+	org, err := client.GetAdminOrg(d.Get("org").(string))
+	if err != nil {
+		return list, err
+	}
+
+	policies, err := org.GetAllVdcComputePolicies(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving VM sizing policy list: %s ", err)
+	}
+	var items []resourceRef
+	for _, policy := range policies {
+		if isVmPlacementPolicy(policy.VdcComputePolicy) {
+			continue
+		}
+		items = append(items, resourceRef{
+			name:     policy.VdcComputePolicy.Name,
+			id:       policy.VdcComputePolicy.ID,
+			href:     "",
+			importId: policy.VdcComputePolicy.ID,
+		})
+	}
+	return genericResourceList("vcd_vm_sizing_policy", listMode, nameIdSeparator, []string{org.AdminOrg.Name}, items)
+}
+
+func vmPlacementPolicyList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+	org, err := client.GetAdminOrg(d.Get("org").(string))
+	if err != nil {
+		return list, err
+	}
+
+	policies, err := org.GetAllVdcComputePolicies(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving VM placement policy list: %s ", err)
+	}
+	var items []resourceRef
+	for _, policy := range policies {
+		if !isVmPlacementPolicy(policy.VdcComputePolicy) {
+			continue
+		}
+		items = append(items, resourceRef{
+			name:     policy.VdcComputePolicy.Name,
+			id:       policy.VdcComputePolicy.ID,
+			href:     "",
+			importId: policy.VdcComputePolicy.ID,
+		})
+	}
+	return genericResourceList("vcd_vm_placement_policy", listMode, nameIdSeparator, []string{org.AdminOrg.Name}, items)
+}
+
+func externalNetworkV2List(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	if !client.VCDClient.Client.IsSysAdmin {
+		return []string{}, fmt.Errorf("external network V2 list requires system administrator privileges")
+	}
+	listMode := d.Get("list_mode").(string)
+	nameIdSeparator := d.Get("name_id_separator").(string)
+
+	externalNetworks, err := govcd.GetAllExternalNetworksV2(client.VCDClient, nil)
+	if err != nil {
+		return list, err
+	}
+	var items []resourceRef
+	for _, extNet := range externalNetworks {
+		items = append(items, resourceRef{
+			name: extNet.ExternalNetwork.Name,
+			id:   extNet.ExternalNetwork.ID,
+			href: "",
+		})
+	}
+	// External networks don't have ancestors: they are imported using only their name
+	return genericResourceList("vcd_external_network_v2", listMode, nameIdSeparator, []string{}, items)
+}
+
+func edgeGatewayVpnList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcName, listMode, separator, edgeGateway, err := getEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+
+	var items []resourceRef
+	serviceConfiguration := edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration
+	if serviceConfiguration != nil && serviceConfiguration.GatewayIpsecVpnService != nil {
+		for _, tunnel := range serviceConfiguration.GatewayIpsecVpnService.Tunnel {
+			items = append(items, resourceRef{
+				name: tunnel.Name,
+				id:   "",
 				href: "",
 			})
 		}
 	}
-	return genericResourceList("vcd_lb_app_profile", listMode, separator, []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+	return genericResourceList("vcd_edgegateway_vpn", listMode, separator, []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+// insertedMediaList finds the VMs of a vApp that have a media inserted.
+// The inserted media is imported using the VM as last element
+func insertedMediaList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	orgName, vdcName, listMode, separator, vapp, err := getVappDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	if vapp.VApp.Children == nil {
+		return list, nil
+	}
+
+	var items []resourceRef
+	for _, vmRef := range vapp.VApp.Children.VM {
+		vm, err := client.Client.GetVMByHref(vmRef.HREF)
+		if err != nil {
+			return []string{}, fmt.Errorf("error retrieving VM '%s': %s ", vmRef.Name, err)
+		}
+		mediaImage := getVmInsertedMedia(vm)
+		if mediaImage == nil {
+			continue
+		}
+		items = append(items, resourceRef{
+			name:     vm.VM.Name,
+			id:       mediaImage.ID,
+			href:     mediaImage.HREF,
+			importId: vm.VM.Name,
+		})
+	}
+	return genericResourceList("vcd_inserted_media", listMode, separator, []string{orgName, vdcName, vapp.VApp.Name}, items)
+}
+
+// vmSnapshotList finds the VMs of a vApp that have a snapshot.
+// The snapshot is imported using the VM as last element
+func vmSnapshotList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+
+	orgName, vdcName, listMode, separator, vapp, err := getVappDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	if vapp.VApp.Children == nil {
+		return list, nil
+	}
+
+	var items []resourceRef
+	for _, vmRef := range vapp.VApp.Children.VM {
+		vm, err := client.Client.GetVMByHref(vmRef.HREF)
+		if err != nil {
+			return []string{}, fmt.Errorf("error retrieving VM '%s': %s ", vmRef.Name, err)
+		}
+		if getVmSnapshot(vm) == nil {
+			continue
+		}
+		items = append(items, resourceRef{
+			name:     vm.VM.Name,
+			id:       vm.VM.ID,
+			href:     vm.VM.HREF,
+			importId: vm.VM.Name,
+		})
+	}
+	return genericResourceList("vcd_vm_snapshot", listMode, separator, []string{orgName, vdcName, vapp.VApp.Name}, items)
 }
 
 func getResourcesList() ([]string, error) {
@@ -668,13 +1052,47 @@ func datasourceVcdResourceListRead(ctx context.Context, d *schema.ResourceData,
 	case "vcd_catalog_media", "catalog_media", "media_items", "mediaitems", "mediaitem":
 		list, err = catalogItemList(d, meta, true)
 	case "vcd_vapp", "vapp", "vapps":
-		list, err = vappList(d, meta)
+		list, err = vappList(d, meta, "vcd_vapp")
+	case "vcd_vapp_access_control", "vapp_access_control":
+		list, err = vappList(d, meta, "vcd_vapp_access_control")
 	case "vcd_vapp_vm", "vapp_vm", "vapp_vms":
 		list, err = vappVmList(d, meta)
+	case "vcd_vm", "vm", "vms":
+		list, err = standaloneVmList(d, meta)
+	case "vcd_vapp_network", "vapp_network", "vapp_networks":
+		list, err = vappNetworkList(d, meta, "vcd_vapp_network", false)
+	case "vcd_vapp_org_network", "vapp_org_network", "vapp_org_networks":
+		list, err = vappNetworkList(d, meta, "vcd_vapp_org_network", true)
+	case "vcd_vapp_firewall_rules", "vapp_firewall_rules":
+		list, err = vappNetworkList(d, meta, "vcd_vapp_firewall_rules", false)
+	case "vcd_vapp_nat_rules", "vapp_nat_rules":
+		list, err = vappNetworkList(d, meta, "vcd_vapp_nat_rules", false)
+	case "vcd_vapp_static_routing", "vapp_static_routing":
+		list, err = vappNetworkList(d, meta, "vcd_vapp_static_routing", false)
+	case "vcd_vm_internal_disk", "vm_internal_disk", "internal_disk", "internal_disks":
+		list, err = vmInternalDiskList(d, meta)
+	case "vcd_independent_disk", "independent_disk", "independent_disks", "disk", "disks":
+		list, err = independentDiskList(d, meta)
 	case "vcd_org_user", "org_user", "user", "users":
 		list, err = orgUserList(d, meta)
+	case "vcd_org_group", "org_group", "group", "groups":
+		list, err = orgGroupList(d, meta)
+	case "vcd_vm_affinity_rule", "vm_affinity_rule", "affinity_rule", "affinity_rules":
+		list, err = vmAffinityRuleList(d, meta)
+	case "vcd_vm_sizing_policy", "vm_sizing_policy", "sizing_policy", "sizing_policies":
+		list, err = vmSizingPolicyList(d, meta)
+	case "vcd_vm_placement_policy", "vm_placement_policy", "placement_policy", "placement_policies":
+		list, err = vmPlacementPolicyList(d, meta)
+	case "vcd_external_network_v2", "external_network_v2", "external_networks_v2":
+		list, err = externalNetworkV2List(d, meta)
 	case "vcd_edgegateway", "edge_gateway", "edge", "edgegateway":
-		list, err = edgeGatewayList(d, meta)
+		list, err = edgeGatewayList(d, meta, "vcd_edgegateway")
+	case "vcd_edgegateway_settings", "edgegateway_settings", "edge_gateway_settings":
+		list, err = edgeGatewayList(d, meta, "vcd_edgegateway_settings")
+	case "vcd_edgegateway_vpn", "edgegateway_vpn", "edge_gateway_vpn", "vpn":
+		list, err = edgeGatewayVpnList(d, meta)
+	case "vcd_nsxv_dhcp_relay", "nsxv_dhcp_relay", "dhcp_relay":
+		list, err = edgeGatewayList(d, meta, "vcd_nsxv_dhcp_relay")
 	case "vcd_lb_server_pool", "lb_server_pool":
 		list, err = lbServerPoolList(d, meta)
 	case "vcd_lb_service_monitor", "lb_service_monitor":
@@ -687,7 +1105,7 @@ func datasourceVcdResourceListRead(ctx context.Context, d *schema.ResourceData,
 		list, err = lbAppProfileList(d, meta)
 	case "vcd_nsxv_firewall_rule", "nsxv_firewall_rule":
 		list, err = nsxvFirewallList(d, meta)
-	case "vcd_ipset", "ipset":
+	case "vcd_nsxv_ip_set", "vcd_ipset", "nsxv_ip_set", "ipset":
 		list, err = ipsetList(d, meta)
 	case "vcd_nsxv_dnat", "nsxv_dnat":
 		list, err = nsxvNatRuleList("dnat", d, meta)
@@ -696,13 +1114,10 @@ func datasourceVcdResourceListRead(ctx context.Context, d *schema.ResourceData,
 	case "vcd_network_isolated", "vcd_network_direct", "vcd_network_routed",
 		"network", "networks", "network_direct", "network_routed", "network_isolated":
 		list, err = networkList(d, meta)
-
-		//// place holder to remind of what needs to be implemented
-		//	case "edgegateway_vpn",
-		//		"vapp_network",
-		//		"independent_disk",
-		//		"inserted_media":
-		//		list, err = []string{"not implemented yet"}, nil
+	case "vcd_inserted_media", "inserted_media":
+		list, err = insertedMediaList(d, meta)
+	case "vcd_vm_snapshot", "vm_snapshot", "snapshot", "snapshots":
+		list, err = vmSnapshotList(d, meta)
 	default:
 		return diag.FromErr(fmt.Errorf("unhandled resource type '%s'", requested))
 	}
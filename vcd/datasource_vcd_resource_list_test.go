@@ -35,11 +35,17 @@ func TestAccVcdDatasourceResourceList(t *testing.T) {
 		{"catalog-parent", "vcd_catalog", testConfig.VCD.Org, testConfig.VCD.Catalog.Name},
 		{"VDC", "vcd_org_vdc", "", testConfig.VCD.Vdc},
 		{"VDC-parent", "vcd_org_vdc", testConfig.VCD.Org, testConfig.VCD.Vdc},
+		{"org_group", "vcd_org_group", "", ""},
+		{"vm_sizing_policy", "vcd_vm_sizing_policy", "", ""},
+		{"vm_placement_policy", "vcd_vm_placement_policy", "", ""},
+		{"extnet_v2", "vcd_external_network_v2", "", ""},
 
 		// entities belonging to a VDC don't require an explicit parent, as it is given from the VDC passed in the provider
 		// For each resource, we test with and without and explicit parent
 		{"edge_gateway", "vcd_edgegateway", "", testConfig.Networking.EdgeGateway},
 		{"edge_gateway-parent", "vcd_edgegateway", testConfig.VCD.Vdc, testConfig.Networking.EdgeGateway},
+		{"edgegateway_settings", "vcd_edgegateway_settings", "", testConfig.Networking.EdgeGateway},
+		{"nsxv_dhcp_relay", "vcd_nsxv_dhcp_relay", "", testConfig.Networking.EdgeGateway},
 		{"network", "network", "", ""},
 		{"network-parent", "network", testConfig.VCD.Vdc, ""},
 		{"network_isolated", "vcd_network_isolated", "", ""},
@@ -51,9 +57,18 @@ func TestAccVcdDatasourceResourceList(t *testing.T) {
 		{"ipset", "vcd_ipset", "", ""},
 		{"vapp", "vcd_vapp", "", ""},
 		{"vapp-parent", "vcd_vapp", testConfig.VCD.Vdc, ""},
+		{"vapp_access_control", "vcd_vapp_access_control", "", ""},
+		{"independent_disk", "vcd_independent_disk", "", ""},
+		{"vm_affinity_rule", "vcd_vm_affinity_rule", "", ""},
 
 		// test for VM requires a VApp as parent, which may not be guaranteed, as there is none in the config file
+		// The same applies to all the other resources that use a vApp as parent
 		//{"vapp_vm", "vcd_vapp_vm", "TestVapp", ""},
+		//{"vapp_network", "vcd_vapp_network", "TestVapp", ""},
+		//{"vapp_org_network", "vcd_vapp_org_network", "TestVapp", ""},
+		//{"vm_internal_disk", "vcd_vm_internal_disk", "TestVapp", ""},
+		//{"inserted_media", "vcd_inserted_media", "TestVapp", ""},
+		//{"vm_snapshot", "vcd_vm_snapshot", "TestVapp", ""},
 
 		// tests in this last group always require an explicit parent
 		{"catalog_item", "vcd_catalog_item", testConfig.VCD.Catalog.Name, testConfig.VCD.Catalog.CatalogItem},
@@ -66,6 +81,7 @@ func TestAccVcdDatasourceResourceList(t *testing.T) {
 		{"lb_virtual_server", "vcd_lb_virtual_server", testConfig.Networking.EdgeGateway, ""},
 		{"lb_app_profile", "vcd_lb_app_profile", testConfig.Networking.EdgeGateway, ""},
 		{"lb_app_rule", "vcd_lb_app_rule", testConfig.Networking.EdgeGateway, ""},
+		{"edgegateway_vpn", "vcd_edgegateway_vpn", testConfig.Networking.EdgeGateway, ""},
 	}
 	for _, def := range lists {
 		t.Run(def.name+"-"+def.resourceType, func(t *testing.T) { runResourceInfoTest(def, t) })
@@ -92,7 +108,7 @@ func runResourceInfoTest(def listDef, t *testing.T) {
 		return
 	}
 
-	if !usingSysAdmin() && (def.resourceType == "vcd_external_network") {
+	if !usingSysAdmin() && (def.resourceType == "vcd_external_network" || def.resourceType == "vcd_external_network_v2") {
 		t.Skip("test with external network requires system administrator privileges")
 	}
 	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
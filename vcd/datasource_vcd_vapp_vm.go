@@ -136,9 +136,36 @@ func datasourceVcdVAppVm() *schema.Resource {
 							Computed:    true,
 							Description: "It defines if NIC is connected or not.",
 						},
+						"nic_index": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Network connection index of the NIC in VCD",
+						},
 					},
 				},
 			},
+			"disk_controller": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Disk controllers of the VM",
+				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+					"bus_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The type of disk controller. Possible values: ide, parallel( LSI Logic Parallel SCSI), sas(LSI Logic SAS (SCSI)), paravirtual(Paravirtual (SCSI)), sata",
+					},
+					"bus_number": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The number of the controller",
+					},
+					"sharing": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Bus sharing of a SCSI controller: 'none', 'virtual' or 'physical'",
+					},
+				}},
+			},
 			"disk": {
 				Type: schema.TypeSet,
 				Elem: &schema.Resource{Schema: map[string]*schema.Schema{
@@ -307,9 +334,30 @@ func datasourceVcdVAppVm() *schema.Resource {
 							Computed:    true,
 							Description: "Script to run on initial boot or with customization.force=true set",
 						},
+						"linux_hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Host name of a Linux guest",
+						},
+						"linux_domain": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "DNS domain of a Linux guest",
+						},
+						"linux_timezone": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time zone of a Linux guest",
+						},
 					},
 				},
 			},
+			"generated_admin_password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Administrator password generated by VCD when 'customization.0.auto_generate_password' is true",
+			},
 			"cpu_hot_add_enabled": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -326,10 +374,86 @@ func datasourceVcdVAppVm() *schema.Resource {
 				Computed:    true,
 				Description: "VM sizing policy ID.",
 			},
+			"placement_policy_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "VM placement policy ID.",
+			},
+			"cpu_reservation": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "CPU reservation of the VM in MHz",
+			},
+			"cpu_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "CPU limit of the VM in MHz. -1 means unlimited",
+			},
+			"cpu_shares": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of CPU shares of the VM",
+			},
+			"memory_reservation": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Memory reservation of the VM in MB",
+			},
+			"memory_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Memory limit of the VM in MB. -1 means unlimited",
+			},
+			"memory_shares": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of memory shares of the VM",
+			},
+			"latency_sensitivity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Latency sensitivity of the VM: 'normal' or 'high'",
+			},
+			"power_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Power state of the VM. One of 'on', 'off', 'suspended', or the VM status in lowercase",
+			},
+			"extra_config": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Key/value map of VM advanced settings (VMX parameters)",
+			},
+			"firmware": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Boot firmware of the VM. One of 'bios', 'efi'",
+			},
+			"secure_boot": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether EFI secure boot is enabled",
+			},
+			"boot_delay": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Milliseconds to wait before the VM starts booting",
+			},
+			"boot_retry_enabled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the boot is retried when the VM fails to find a boot device",
+			},
+			"boot_retry_delay": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Milliseconds to wait before retrying the boot",
+			},
 		},
 	}
 }
 
 func datasourceVcdVAppVmRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdVAppVmRead(d, meta, "datasource")
+	return genericVcdVAppVmRead(d, meta, "datasource", vappVmType)
 }
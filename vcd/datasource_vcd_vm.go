@@ -0,0 +1,29 @@
+package vcd
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// datasourceVcdStandaloneVm uses the same schema as the vcd_vapp_vm data source, but the VM is
+// searched in the whole VDC, and the vApp created by VCD is reported in "vapp_name"
+func datasourceVcdStandaloneVm() *schema.Resource {
+	vmSchema := datasourceVcdVAppVm().Schema
+	vmSchema["vapp_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The vApp created by VCD to hold this standalone VM",
+	}
+	vmSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "A name for the VM, unique within the VDC",
+	}
+	return &schema.Resource{
+		Read:   datasourceVcdStandaloneVmRead,
+		Schema: vmSchema,
+	}
+}
+
+func datasourceVcdStandaloneVmRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdVAppVmRead(d, meta, "datasource", standaloneVmType)
+}
@@ -0,0 +1,109 @@
+package vcd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdVmGuestOs() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVmGuestOsRead,
+		Schema: map[string]*schema.Schema{
+			"hardware_version": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Virtual hardware version (e.g. `vmx-14`) for which the guest operating systems are listed",
+			},
+			"family": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Guest operating system family (e.g. `Linux`). All the families are listed when empty",
+			},
+			"os_types": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Identifiers of the guest operating systems, which can be used as 'os_type' of a VM",
+			},
+			"guest_os": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Guest operating systems supported with the hardware version",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"os_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Identifier of the guest operating system, used as 'os_type' of a VM",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the guest operating system",
+						},
+						"family": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Guest operating system family",
+						},
+						"is_64bit": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "True if the guest operating system is 64-bit",
+						},
+						"min_hardware_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Minimum virtual hardware version needed by the guest operating system",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVmGuestOsRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	hardwareVersion := d.Get("hardware_version").(string)
+	number, err := getHardwareVersionNumber(hardwareVersion)
+	if err != nil {
+		return err
+	}
+
+	guestOsList, err := getVmGuestOsList(vcdClient)
+	if err != nil {
+		return err
+	}
+	guestOsList = filterVmGuestOs(guestOsList, number, d.Get("family").(string))
+	sort.SliceStable(guestOsList, func(i, j int) bool {
+		return guestOsList[i].internalName < guestOsList[j].internalName
+	})
+
+	osTypes := make([]interface{}, len(guestOsList))
+	guestOsBlocks := make([]interface{}, len(guestOsList))
+	for i, guestOs := range guestOsList {
+		osTypes[i] = guestOs.internalName
+		guestOsBlocks[i] = map[string]interface{}{
+			"os_type":              guestOs.internalName,
+			"name":                 guestOs.name,
+			"family":               guestOs.family,
+			"is_64bit":             guestOs.is64Bit,
+			"min_hardware_version": fmt.Sprintf("vmx-%d", guestOs.minHardwareVersion),
+		}
+	}
+	err = d.Set("os_types", osTypes)
+	if err != nil {
+		return err
+	}
+	err = d.Set("guest_os", guestOsBlocks)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(hardwareVersion + ":" + d.Get("family").(string))
+	return nil
+}
@@ -0,0 +1,51 @@
+package vcd
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdVmPlacementPolicy() *schema.Resource {
+
+	return &schema.Resource{
+		Read: datasourceVcdVmPlacementPolicyRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"provider_vdc_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the Provider VDC where the VM groups of the policy are",
+			},
+			"vm_group_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the VM groups, which pin the VMs to the hosts of a cluster",
+			},
+			"logical_vm_group_ids": &schema.Schema{
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the logical VM groups, which combine VM groups across clusters",
+			},
+		},
+	}
+}
+
+// datasourceVcdVmPlacementPolicyRead reads a data source VM placement policy
+func datasourceVcdVmPlacementPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdVmPlacementPolicyRead(d, meta)
+}
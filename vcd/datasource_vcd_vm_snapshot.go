@@ -0,0 +1,70 @@
+package vcd
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+func datasourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Read: datasourceVcdVmSnapshotRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The vApp the VM belongs to",
+			},
+			"vm_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The VM owning the snapshot",
+			},
+			"created": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation date of the snapshot",
+			},
+			"size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the snapshot in bytes",
+			},
+			"powered_on": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the VM was powered on when the snapshot was taken",
+			},
+		},
+	}
+}
+
+func datasourceVcdVmSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vm, _, err := getVm(vcdClient, d)
+	if err != nil {
+		return err
+	}
+
+	snapshot := getVmSnapshot(vm)
+	if snapshot == nil {
+		return fmt.Errorf("VM %s has no snapshot: %s", vm.VM.Name, govcd.ErrorEntityNotFound)
+	}
+
+	setVmSnapshotData(d, snapshot)
+	d.SetId(vm.VM.ID)
+	return nil
+}
@@ -6,13 +6,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-// onlyHasChange is a schema helper which accepts Terraform schema definition and checks if field
-// with `fieldName` is the only one which has change (using d.HasChange)
-func onlyHasChange(fieldName string, schema map[string]*schema.Schema, d *schema.ResourceData) bool {
-	log.Printf("[DEBUG] [VM update] checking if only field '%s' has change during update", fieldName)
+// onlyHasChanges is a schema helper which accepts Terraform schema definition and checks if fields
+// in `fieldNames` are the only ones which have changes (using d.HasChange)
+func onlyHasChanges(fieldNames []string, schema map[string]*schema.Schema, d *schema.ResourceData) bool {
+	log.Printf("[DEBUG] [VM update] checking if only fields %v have changes during update", fieldNames)
 	for schemaFieldName := range schema {
-		// Skip checking defined field
-		if schemaFieldName == fieldName {
+		// Skip checking defined fields
+		if stringInSlice(schemaFieldName, fieldNames) {
 			continue
 		}
 		if d.HasChange(schemaFieldName) {
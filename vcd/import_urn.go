@@ -0,0 +1,178 @@
+package vcd
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	types "github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// importUrnPrefix is the common prefix of all VCD entity URNs
+const importUrnPrefix = "urn:vcloud:"
+
+// maxImportParentDepth is the maximum number of "up" links we follow when
+// searching for the parents of an entity
+const maxImportParentDepth = 8
+
+// importEntity holds the identity of an entity retrieved by URN, together with
+// the names of the parents that the importers need to retrieve it again
+type importEntity struct {
+	id              string
+	name            string
+	orgName         string
+	vdcName         string
+	vappName        string
+	catalogName     string
+	edgeGatewayName string
+}
+
+// parentPath returns the names of the parents of the entity, from the Org down to the closest parent
+func (entity *importEntity) parentPath() []string {
+	var path []string
+	for _, name := range []string{entity.orgName, entity.vdcName, entity.catalogName, entity.edgeGatewayName, entity.vappName} {
+		if name != "" {
+			path = append(path, name)
+		}
+	}
+	return path
+}
+
+// isImportUrn returns true if the import identifier is a VCD URN
+// (such as "urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3")
+func isImportUrn(identifier string) bool {
+	return strings.HasPrefix(identifier, importUrnPrefix)
+}
+
+// getUrnEntityType returns the entity type contained in a URN
+// (e.g. "vm" for "urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3")
+func getUrnEntityType(urn string) (string, error) {
+	if !isImportUrn(urn) {
+		return "", fmt.Errorf("'%s' is not a VCD URN", urn)
+	}
+	elements := strings.Split(strings.TrimPrefix(urn, importUrnPrefix), ":")
+	if len(elements) != 2 || elements[0] == "" || !govcd.IsUuid(elements[1]) {
+		return "", fmt.Errorf("'%s' is not a valid VCD URN. Expected format is '%s<type>:<UUID>'", urn, importUrnPrefix)
+	}
+	return elements[0], nil
+}
+
+// getEntityHrefByUrn resolves a URN into the HREF of the entity, using the entity resolver of VCD
+func getEntityHrefByUrn(vcdClient *VCDClient, urn string) (string, error) {
+	resolver := types.Entity{}
+	entityHref := vcdClient.Client.VCDHREF
+	entityHref.Path += "/entity/" + urn
+	_, err := vcdClient.Client.ExecuteRequest(entityHref.String(), http.MethodGet, types.MimeEntity,
+		"error resolving URN: %s", nil, &resolver)
+	if err != nil {
+		return "", fmt.Errorf("could not find entity '%s': %s", urn, err)
+	}
+
+	for _, link := range resolver.Link {
+		if link.Rel == "alternate" {
+			return link.HREF, nil
+		}
+	}
+	return "", fmt.Errorf("no link to the entity found for URN '%s'", urn)
+}
+
+// getImportEntityByUrn retrieves the entity identified by a URN and collects the names of its parents
+// (Org, VDC, vApp, catalog, edge gateway), following the "up" links of the entity tree.
+func getImportEntityByUrn(vcdClient *VCDClient, urn string) (*importEntity, error) {
+	urnType, err := getUrnEntityType(urn)
+	if err != nil {
+		return nil, err
+	}
+
+	href, err := getEntityHrefByUrn(vcdClient, urn)
+	if err != nil {
+		return nil, err
+	}
+
+	var node types.Entity
+	_, err = vcdClient.Client.ExecuteRequest(href, http.MethodGet, "",
+		"error retrieving entity: %s", nil, &node)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve entity '%s': %s", urn, err)
+	}
+
+	entity := &importEntity{
+		id:   urn,
+		name: node.Name,
+	}
+	if urnType == "org" {
+		return entity, nil
+	}
+
+	for depth := 0; depth < maxImportParentDepth; depth++ {
+		up := findImportParentLink(node.Link)
+		if up == nil {
+			break
+		}
+		var parent types.Entity
+		_, err = vcdClient.Client.ExecuteRequest(up.HREF, http.MethodGet, "",
+			"error retrieving parent entity: %s", nil, &parent)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve parent of entity '%s': %s", urn, err)
+		}
+		switch up.Type {
+		case types.MimeVApp:
+			entity.vappName = parent.Name
+		case types.MimeVDC, types.MimeAdminVDC:
+			entity.vdcName = parent.Name
+		case types.MimeCatalog, types.MimeAdminCatalog:
+			entity.catalogName = parent.Name
+		case types.MimeEdgeGateway:
+			entity.edgeGatewayName = parent.Name
+		case types.MimeOrg, types.MimeAdminOrg:
+			entity.orgName = parent.Name
+			return entity, nil
+		}
+		node = parent
+	}
+	if entity.orgName == "" {
+		return nil, fmt.Errorf("could not find the Org containing entity '%s'", urn)
+	}
+	return entity, nil
+}
+
+// findImportParentLink returns the link to the parent of an entity, if there is one.
+// Only links to containers that are part of an import path are considered
+func findImportParentLink(links types.LinkList) *types.Link {
+	for _, link := range links {
+		if link.Rel != "up" {
+			continue
+		}
+		switch link.Type {
+		case types.MimeVApp, types.MimeVDC, types.MimeAdminVDC, types.MimeCatalog, types.MimeAdminCatalog,
+			types.MimeEdgeGateway, types.MimeOrg, types.MimeAdminOrg:
+			return link
+		}
+	}
+	return nil
+}
+
+// splitImportPath splits an import ID into the elements of its path.
+// When the first element is a VCD URN, it gets expanded into the names of the parents of that entity.
+// If the URN is the only element, it identifies the entity to import, and it is kept as the last element
+// of the path. Otherwise, the URN identifies a parent, and it is replaced by the parent name.
+// Thus, for a VM, the following import IDs are equivalent:
+//   my-org.my-vdc.my-vapp.my-vm
+//   urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3  => my-org.my-vdc.my-vapp.urn:vcloud:vm:da2e8c37-...
+//   urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e.my-vm  => my-org.my-vdc.my-vapp.my-vm
+// Importers must then be able to retrieve the last element of the path either by name or by ID.
+func splitImportPath(vcdClient *VCDClient, importId string) ([]string, error) {
+	resourceURI := strings.Split(importId, ImportSeparator)
+	if !isImportUrn(resourceURI[0]) {
+		return resourceURI, nil
+	}
+	entity, err := getImportEntityByUrn(vcdClient, resourceURI[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceURI) == 1 {
+		return append(entity.parentPath(), entity.id), nil
+	}
+	return append(append(entity.parentPath(), entity.name), resourceURI[1:]...), nil
+}
@@ -0,0 +1,65 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestGetUrnEntityType checks that the entity type is correctly extracted from URNs,
+// and that invalid URNs are rejected
+func TestGetUrnEntityType(t *testing.T) {
+	type urnTest struct {
+		urn          string
+		expectedType string
+		wantError    bool
+	}
+	var tests = []urnTest{
+		{"urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3", "vm", false},
+		{"urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e", "vapp", false},
+		{"urn:vcloud:vdcComputePolicy:d7b6e1b4-5e3b-4b8c-9c3a-8f1e2d3c4b5a", "vdcComputePolicy", false},
+		{"urn:vcloud:vm:not-a-uuid", "", true},
+		{"urn:vcloud::da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3", "", true},
+		{"urn:vcloud:vm", "", true},
+		{"da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3", "", true},
+		{"my-org.my-vdc.my-vapp", "", true},
+	}
+	for _, test := range tests {
+		urnType, err := getUrnEntityType(test.urn)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("expected error for URN '%s', got type '%s'", test.urn, urnType)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for URN '%s': %s", test.urn, err)
+			continue
+		}
+		if urnType != test.expectedType {
+			t.Errorf("URN '%s': expected type '%s', got '%s'", test.urn, test.expectedType, urnType)
+		}
+	}
+}
+
+// TestSplitImportPathByName checks that import paths not starting with a URN are split without
+// contacting VCD
+func TestSplitImportPathByName(t *testing.T) {
+	var tests = map[string][]string{
+		"my-org":                        {"my-org"},
+		"my-org.my-vdc.my-vapp.my-vm":   {"my-org", "my-vdc", "my-vapp", "my-vm"},
+		"list@my-org.my-vdc.my-edge":    {"list@my-org", "my-vdc", "my-edge"},
+		"my-org.my-vdc.my-vapp.urn:x:y": {"my-org", "my-vdc", "my-vapp", "urn:x:y"},
+	}
+	for importId, expected := range tests {
+		path, err := splitImportPath(nil, importId)
+		if err != nil {
+			t.Errorf("unexpected error for '%s': %s", importId, err)
+			continue
+		}
+		if !reflect.DeepEqual(path, expected) {
+			t.Errorf("'%s': expected %v, got %v", importId, expected, path)
+		}
+	}
+}
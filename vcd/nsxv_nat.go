@@ -3,6 +3,7 @@ package vcd
 //lint:file-ignore SA1019 ignore deprecated functions
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -89,7 +90,16 @@ func natRuleRead(idField, natType string, setData natRuleDataSetter) schema.Read
 	return func(d *schema.ResourceData, meta interface{}) error {
 		vcdClient := meta.(*VCDClient)
 
+		// Only the resources use the default ID field 'id'. When the rule or its edge gateway
+		// are missing, the resources are removed from state, while the data sources fail
+		isResource := idField == "id"
+
 		edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+		if isResource && govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find edge gateway for NAT (%s) rule. Removing from tfstate", natType)
+			d.SetId("")
+			return nil
+		}
 		if err != nil {
 			return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 		}
@@ -97,15 +107,19 @@ func natRuleRead(idField, natType string, setData natRuleDataSetter) schema.Read
 		// if default ID field 'id' is used, then rely on Terraform's d.Id(). Otherwise use the
 		// string value
 		var idValue string
-		if idField == "id" {
+		if isResource {
 			idValue = d.Id()
 		} else {
 			idValue = d.Get(idField).(string)
 		}
 
 		readNatRule, err := edgeGateway.GetNsxvNatRuleById(idValue)
-		if err != nil {
+		if isResource && govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find NAT (%s) rule with ID '%s'. Removing from tfstate", natType, idValue)
 			d.SetId("")
+			return nil
+		}
+		if err != nil {
 			return fmt.Errorf("unable to find NAT (%s) rule with ID '%s': %s", natType, idValue, err)
 		}
 
@@ -144,13 +158,16 @@ func natRuleDelete(natType string) schema.DeleteFunc {
 // natRuleImporter returns a schema.StateFunc for both SNAT and DNAT rules
 func natRuleImport(natType string) schema.StateFunc {
 	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		vcdClient := meta.(*VCDClient)
+		resourceURI, err := splitImportPath(vcdClient, d.Id())
+		if err != nil {
+			return nil, err
+		}
 		if len(resourceURI) != 4 {
 			return nil, fmt.Errorf("resource name must be specified in such way org.vdc.edge-gw.rule-id")
 		}
 		orgName, vdcName, edgeName, natRuleId := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-		vcdClient := meta.(*VCDClient)
 		edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 		if err != nil {
 			return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
@@ -64,49 +64,59 @@ var globalDataSourceMap = map[string]*schema.Resource{
 	"vcd_vcenter":             datasourceVcdVcenter(),           // 3.0
 	"vcd_resource_list":       datasourceVcdResourceList(),      // 3.1
 	"vcd_resource_schema":     datasourceVcdResourceSchema(),    // 3.1
+	"vcd_vm":                  datasourceVcdStandaloneVm(),      // 3.1
+	"vcd_vm_snapshot":         datasourceVcdVmSnapshot(),        // 3.1
+	"vcd_vm_placement_policy": datasourceVcdVmPlacementPolicy(), // 3.1
+	"vcd_vm_guest_os":         datasourceVcdVmGuestOs(),         // 3.1
 }
 
 var globalResourceMap = map[string]*schema.Resource{
 
-	"vcd_network_routed":       resourceVcdNetworkRouted(),            // 2.0
-	"vcd_network_direct":       resourceVcdNetworkDirect(),            // 2.0
-	"vcd_network_isolated":     resourceVcdNetworkIsolated(),          // 2.0
-	"vcd_vapp_network":         resourceVcdVappNetwork(),              // 2.1
-	"vcd_vapp":                 resourceVcdVApp(),                     // 1.0
-	"vcd_edgegateway":          resourceVcdEdgeGateway(),              // 2.4
-	"vcd_edgegateway_vpn":      resourceVcdEdgeGatewayVpn(),           // 1.0
-	"vcd_edgegateway_settings": resourceVcdEdgeGatewaySettings(),      // 3.0
-	"vcd_vapp_vm":              resourceVcdVAppVm(),                   // 1.0
-	"vcd_org":                  resourceOrg(),                         // 2.0
-	"vcd_org_vdc":              resourceVcdOrgVdc(),                   // 2.2
-	"vcd_org_user":             resourceVcdOrgUser(),                  // 2.4
-	"vcd_catalog":              resourceVcdCatalog(),                  // 2.0
-	"vcd_catalog_item":         resourceVcdCatalogItem(),              // 2.0
-	"vcd_catalog_media":        resourceVcdCatalogMedia(),             // 2.0
-	"vcd_inserted_media":       resourceVcdInsertedMedia(),            // 2.1
-	"vcd_independent_disk":     resourceVcdIndependentDisk(),          // 2.1
-	"vcd_external_network":     resourceVcdExternalNetwork(),          // 2.2
-	"vcd_lb_service_monitor":   resourceVcdLbServiceMonitor(),         // 2.4
-	"vcd_lb_server_pool":       resourceVcdLBServerPool(),             // 2.4
-	"vcd_lb_app_profile":       resourceVcdLBAppProfile(),             // 2.4
-	"vcd_lb_app_rule":          resourceVcdLBAppRule(),                // 2.4
-	"vcd_lb_virtual_server":    resourceVcdLBVirtualServer(),          // 2.4
-	"vcd_nsxv_dnat":            resourceVcdNsxvDnat(),                 // 2.5
-	"vcd_nsxv_snat":            resourceVcdNsxvSnat(),                 // 2.5
-	"vcd_nsxv_firewall_rule":   resourceVcdNsxvFirewallRule(),         // 2.5
-	"vcd_nsxv_dhcp_relay":      resourceVcdNsxvDhcpRelay(),            // 2.6
-	"vcd_nsxv_ip_set":          resourceVcdIpSet(),                    // 2.6
-	"vcd_vm_internal_disk":     resourceVmInternalDisk(),              // 2.7
-	"vcd_vapp_org_network":     resourceVcdVappOrgNetwork(),           // 2.7
-	"vcd_org_group":            resourceVcdOrgGroup(),                 // 2.9
-	"vcd_vapp_firewall_rules":  resourceVcdVappFirewallRules(),        // 2.9
-	"vcd_vapp_nat_rules":       resourceVcdVappNetworkNatRules(),      // 2.9
-	"vcd_vapp_static_routing":  resourceVcdVappNetworkStaticRouting(), // 2.9
-	"vcd_vm_affinity_rule":     resourceVcdVmAffinityRule(),           // 2.9
-	"vcd_vapp_access_control":  resourceVcdAccessControlVapp(),        // 3.0
-	"vcd_external_network_v2":  resourceVcdExternalNetworkV2(),        // 3.0
-	"vcd_vm_sizing_policy":     resourceVcdVmSizingPolicy(),           // 3.0
-	"vcd_distributed_firewall": resourceVcdVdcDFW(),                   // 3.1
+	"vcd_network_routed":         resourceVcdNetworkRouted(),            // 2.0
+	"vcd_network_direct":         resourceVcdNetworkDirect(),            // 2.0
+	"vcd_network_isolated":       resourceVcdNetworkIsolated(),          // 2.0
+	"vcd_vapp_network":           resourceVcdVappNetwork(),              // 2.1
+	"vcd_vapp":                   resourceVcdVApp(),                     // 1.0
+	"vcd_edgegateway":            resourceVcdEdgeGateway(),              // 2.4
+	"vcd_edgegateway_vpn":        resourceVcdEdgeGatewayVpn(),           // 1.0
+	"vcd_edgegateway_settings":   resourceVcdEdgeGatewaySettings(),      // 3.0
+	"vcd_vapp_vm":                resourceVcdVAppVm(),                   // 1.0
+	"vcd_org":                    resourceOrg(),                         // 2.0
+	"vcd_org_vdc":                resourceVcdOrgVdc(),                   // 2.2
+	"vcd_org_user":               resourceVcdOrgUser(),                  // 2.4
+	"vcd_catalog":                resourceVcdCatalog(),                  // 2.0
+	"vcd_catalog_item":           resourceVcdCatalogItem(),              // 2.0
+	"vcd_catalog_media":          resourceVcdCatalogMedia(),             // 2.0
+	"vcd_inserted_media":         resourceVcdInsertedMedia(),            // 2.1
+	"vcd_independent_disk":       resourceVcdIndependentDisk(),          // 2.1
+	"vcd_external_network":       resourceVcdExternalNetwork(),          // 2.2
+	"vcd_lb_service_monitor":     resourceVcdLbServiceMonitor(),         // 2.4
+	"vcd_lb_server_pool":         resourceVcdLBServerPool(),             // 2.4
+	"vcd_lb_app_profile":         resourceVcdLBAppProfile(),             // 2.4
+	"vcd_lb_app_rule":            resourceVcdLBAppRule(),                // 2.4
+	"vcd_lb_virtual_server":      resourceVcdLBVirtualServer(),          // 2.4
+	"vcd_nsxv_dnat":              resourceVcdNsxvDnat(),                 // 2.5
+	"vcd_nsxv_snat":              resourceVcdNsxvSnat(),                 // 2.5
+	"vcd_nsxv_firewall_rule":     resourceVcdNsxvFirewallRule(),         // 2.5
+	"vcd_nsxv_dhcp_relay":        resourceVcdNsxvDhcpRelay(),            // 2.6
+	"vcd_nsxv_ip_set":            resourceVcdIpSet(),                    // 2.6
+	"vcd_vm_internal_disk":       resourceVmInternalDisk(),              // 2.7
+	"vcd_vapp_org_network":       resourceVcdVappOrgNetwork(),           // 2.7
+	"vcd_org_group":              resourceVcdOrgGroup(),                 // 2.9
+	"vcd_vapp_firewall_rules":    resourceVcdVappFirewallRules(),        // 2.9
+	"vcd_vapp_nat_rules":         resourceVcdVappNetworkNatRules(),      // 2.9
+	"vcd_vapp_static_routing":    resourceVcdVappNetworkStaticRouting(), // 2.9
+	"vcd_vm_affinity_rule":       resourceVcdVmAffinityRule(),           // 2.9
+	"vcd_vapp_access_control":    resourceVcdAccessControlVapp(),        // 3.0
+	"vcd_external_network_v2":    resourceVcdExternalNetworkV2(),        // 3.0
+	"vcd_vm_sizing_policy":       resourceVcdVmSizingPolicy(),           // 3.0
+	"vcd_distributed_firewall":   resourceVcdVdcDFW(),                   // 3.1
+	"vcd_vm":                     resourceVcdStandaloneVm(),             // 3.1
+	"vcd_vm_snapshot":            resourceVcdVmSnapshot(),               // 3.1
+	"vcd_vm_placement_policy":    resourceVcdVmPlacementPolicy(),        // 3.1
+	"vcd_catalog_vapp_template":  resourceVcdCatalogVappTemplate(),      // 3.1
+	"vcd_catalog_item_download":  resourceVcdCatalogItemDownload(),      // 3.1
+	"vcd_catalog_media_download": resourceVcdCatalogMediaDownload(),     // 3.1
 }
 
 // Provider returns a terraform.ResourceProvider.
@@ -3,7 +3,6 @@ package vcd
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/lmicke/go-vcloud-director/v2/govcd"
@@ -92,9 +91,12 @@ func resourceVcdCatalogRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	catalog, err := adminOrg.GetCatalogByNameOrId(d.Id(), false)
-	if err != nil {
+	if govcd.ContainsNotFound(err) {
 		log.Printf("[DEBUG] Unable to find catalog. Removing from tfstate")
 		d.SetId("")
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("error retrieving catalog %s : %s", d.Id(), err)
 	}
 
@@ -142,27 +144,31 @@ func resourceVcdCatalogDelete(d *schema.ResourceData, meta interface{}) error {
 // Expects the d.ID() to be a path to the resource made of org_name.catalog_name
 //
 // Example import path (id): org_name.catalog_name
+// Example import path (id): urn:vcloud:catalog:a7d4f0e1-8c1d-4c3d-9a2e-7b1f6f6c2a10
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdCatalogImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog")
 	}
 	orgName, catalogName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
 	}
 
-	catalog, err := adminOrg.GetCatalogByName(catalogName, false)
+	catalog, err := adminOrg.GetCatalogByNameOrId(catalogName, false)
 	if err != nil {
 		return nil, govcd.ErrorEntityNotFound
 	}
 
 	_ = d.Set("org", orgName)
-	_ = d.Set("name", catalogName)
+	_ = d.Set("name", catalog.Catalog.Name)
 	_ = d.Set("description", catalog.Catalog.Description)
 	d.SetId(catalog.Catalog.ID)
 
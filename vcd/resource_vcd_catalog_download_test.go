@@ -0,0 +1,147 @@
+// +build catalog ALL functional
+
+package vcd
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdCatalogItemDownload downloads the test suite catalog item as an OVA and as an OVF folder, and checks
+// that the files are kept after the resources are destroyed
+func TestAccVcdCatalogItemDownload(t *testing.T) {
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	downloadFolder := t.TempDir()
+	ovaPath := filepath.Join(downloadFolder, "item.ova")
+	ovfPath := filepath.Join(downloadFolder, "item")
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"OvaPath":     ovaPath,
+		"OvfPath":     ovfPath,
+		"FuncName":    t.Name(),
+		"Tags":        "catalog",
+	}
+
+	configText := templateFill(testAccCheckVcdCatalogItemDownload, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDownloadedFilesKept(ovaPath, filepath.Join(ovfPath, testSuiteCatalogOVAItem+".ovf")),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("vcd_catalog_item_download.ova", "checksum", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestMatchResourceAttr("vcd_catalog_item_download.ovf", "checksum", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttr("vcd_catalog_item_download.ovf", "format", "ovf"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccVcdCatalogMediaDownload uploads a media and downloads it again, checking that the checksum of the
+// downloaded file is the one of the uploaded file
+func TestAccVcdCatalogMediaDownload(t *testing.T) {
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	if testConfig.Media.MediaPath == "" {
+		t.Skip("Variable media.mediaPath must be set to run this test")
+		return
+	}
+	expectedChecksum, err := fileSha256(testConfig.Media.MediaPath)
+	if err != nil {
+		t.Fatalf("error computing checksum of %s: %s", testConfig.Media.MediaPath, err)
+	}
+
+	downloadPath := filepath.Join(t.TempDir(), "media.iso")
+	var params = StringMap{
+		"Org":              testConfig.VCD.Org,
+		"Catalog":          testSuiteCatalogName,
+		"MediaName":        "TestAccVcdCatalogMediaDownload",
+		"MediaPath":        testConfig.Media.MediaPath,
+		"DownloadPath":     downloadPath,
+		"ExpectedChecksum": expectedChecksum,
+		"FuncName":         t.Name(),
+		"Tags":             "catalog",
+	}
+
+	configText := templateFill(testAccCheckVcdCatalogMediaDownload, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckDownloadedFilesKept(downloadPath),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_catalog_media_download.media", "checksum", expectedChecksum),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckDownloadedFilesKept checks that the downloaded files are still there after destroying the resources
+func testAccCheckDownloadedFilesKept(paths ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, path := range paths {
+			if _, err := os.Stat(path); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+const testAccCheckVcdCatalogItemDownload = `
+resource "vcd_catalog_item_download" "ova" {
+  org           = "{{.Org}}"
+  catalog       = "{{.Catalog}}"
+  name          = "{{.CatalogItem}}"
+  download_path = "{{.OvaPath}}"
+}
+
+resource "vcd_catalog_item_download" "ovf" {
+  org                 = "{{.Org}}"
+  catalog             = "{{.Catalog}}"
+  name                = "{{.CatalogItem}}"
+  download_path       = "{{.OvfPath}}"
+  download_piece_size = 5
+  format              = "ovf"
+}
+`
+
+const testAccCheckVcdCatalogMediaDownload = `
+resource "vcd_catalog_media" "media" {
+  org     = "{{.Org}}"
+  catalog = "{{.Catalog}}"
+
+  name              = "{{.MediaName}}"
+  media_path        = "{{.MediaPath}}"
+  upload_piece_size = 1
+}
+
+resource "vcd_catalog_media_download" "media" {
+  org               = "{{.Org}}"
+  catalog           = "{{.Catalog}}"
+  name              = vcd_catalog_media.media.name
+  download_path     = "{{.DownloadPath}}"
+  expected_checksum = "{{.ExpectedChecksum}}"
+}
+`
@@ -3,7 +3,6 @@ package vcd
 import (
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -209,6 +208,9 @@ func createOrUpdateCatalogItemMetadata(d *schema.ResourceData, meta interface{})
 		log.Printf("[DEBUG] Unable to find media item: %s", err)
 		return err
 	}
+	if catalogItem == nil {
+		return fmt.Errorf("unable to find catalog item %s: %s", d.Get("name").(string), govcd.ErrorEntityNotFound)
+	}
 
 	// We have to add metadata to template to see in UI
 	// catalog item is another abstraction and has own metadata which we don't see in UI
@@ -251,9 +253,14 @@ func createOrUpdateCatalogItemMetadata(d *schema.ResourceData, meta interface{})
 // Expects the d.ID() to be a path to the resource made of org_name.catalog_name.catalog_item_name
 //
 // Example import path (id): org_name.catalog_name.catalog_item_name
+// Example import path (id): urn:vcloud:catalogitem:2b3c1e44-5f0e-4a6c-b2a1-93cf8f3dd2a7
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdCatalogItemImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified as org.catalog.catalog_item")
 	}
@@ -269,7 +276,6 @@ func resourceVcdCatalogItemImport(d *schema.ResourceData, meta interface{}) ([]*
 		return nil, fmt.Errorf("import: empty catalog item name provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
@@ -280,14 +286,14 @@ func resourceVcdCatalogItemImport(d *schema.ResourceData, meta interface{}) ([]*
 		return nil, govcd.ErrorEntityNotFound
 	}
 
-	catalogItem, err := catalog.GetCatalogItemByName(catalogItemName, false)
+	catalogItem, err := catalog.GetCatalogItemByNameOrId(catalogItemName, false)
 	if err != nil {
 		return nil, govcd.ErrorEntityNotFound
 	}
 
 	_ = d.Set("org", orgName)
 	_ = d.Set("catalog", catalogName)
-	_ = d.Set("name", catalogItemName)
+	_ = d.Set("name", catalogItem.CatalogItem.Name)
 	_ = d.Set("description", catalogItem.CatalogItem.Description)
 	d.SetId(catalogItem.CatalogItem.ID)
 
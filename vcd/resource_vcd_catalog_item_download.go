@@ -0,0 +1,206 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// catalogDownloadSchema returns the fields shared by the resources that download catalog items and media
+func catalogDownloadSchema(itemDescription, pathDescription string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"org": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+			Description: "The name of organization to use, optional if defined at provider " +
+				"level. Useful when connected as sysadmin working across different organizations",
+		},
+		"catalog": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: "Catalog name where the item to download is",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: itemDescription,
+		},
+		"download_path": {
+			Type:        schema.TypeString,
+			Required:    true,
+			ForceNew:    true,
+			Description: pathDescription,
+		},
+		"download_piece_size": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      1,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Size of download file piece size in mega bytes",
+		},
+		"show_download_progress": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Shows download progress in stdout",
+		},
+		"expected_checksum": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Expected SHA-256 checksum of the downloaded file. The download fails when it doesn't match",
+		},
+		"checksum": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "SHA-256 checksum of the downloaded file",
+		},
+	}
+}
+
+func resourceVcdCatalogItemDownload() *schema.Resource {
+	downloadSchema := catalogDownloadSchema("Name of the catalog item to download",
+		"Path of the OVA file, or of the folder of the OVF files, to write")
+	downloadSchema["format"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      catalogDownloadFormatOva,
+		ValidateFunc: validation.StringInSlice([]string{catalogDownloadFormatOva, catalogDownloadFormatOvf}, false),
+		Description:  "Format of the download: 'ova' for a single file, 'ovf' for a folder with the descriptor and the disks",
+	}
+	return &schema.Resource{
+		Create: resourceVcdCatalogItemDownloadCreate,
+		Read:   resourceVcdCatalogItemDownloadRead,
+		Update: resourceVcdCatalogDownloadUpdate,
+		Delete: resourceVcdCatalogDownloadDelete,
+		Schema: downloadSchema,
+	}
+}
+
+func resourceVcdCatalogItemDownloadCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+	catalogName := d.Get("catalog").(string)
+	catalog, err := adminOrg.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+	}
+	itemName := d.Get("name").(string)
+	catalogItem, err := catalog.GetCatalogItemByName(itemName, false)
+	if err != nil {
+		return fmt.Errorf("error finding catalog item %s: %s", itemName, err)
+	}
+	vAppTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return fmt.Errorf("error finding vApp template of catalog item %s: %s", itemName, err)
+	}
+
+	log.Printf("[TRACE] enabling download of catalog item %s", itemName)
+	err = enableDownload(&vcdClient.Client, vAppTemplate.VAppTemplate.HREF)
+	if err != nil {
+		return fmt.Errorf("error enabling download of catalog item %s: %s", itemName, err)
+	}
+	err = vAppTemplate.Refresh()
+	if err != nil {
+		return err
+	}
+	descriptorHref := getDownloadLink(vAppTemplate.VAppTemplate.Link)
+	if descriptorHref == "" {
+		return fmt.Errorf("no download link found for catalog item %s", itemName)
+	}
+
+	downloader := &catalogDownloader{
+		client:         &vcdClient.Client,
+		pieceSize:      int64(d.Get("download_piece_size").(int)) * 1024 * 1024, // Convert from megabytes to bytes
+		showProgress:   d.Get("show_download_progress").(bool),
+		progressPrefix: "vcd_catalog_item_download." + itemName,
+	}
+	downloadPath := d.Get("download_path").(string)
+
+	if d.Get("format").(string) == catalogDownloadFormatOvf {
+		err = downloader.downloadOvf(descriptorHref, itemName, downloadPath)
+		if err != nil {
+			return err
+		}
+	} else {
+		folder := downloadPath + ".download"
+		err = downloader.downloadOvf(descriptorHref, itemName, folder)
+		if err == nil {
+			err = packOva(folder, itemName+".ovf", downloadPath)
+		}
+		removeErr := os.RemoveAll(folder)
+		if err != nil {
+			return err
+		}
+		if removeErr != nil {
+			return fmt.Errorf("error removing temporary folder %s: %s", folder, removeErr)
+		}
+	}
+
+	checksum, err := verifyChecksum(downloadPath, d.Get("expected_checksum").(string), getCatalogItemChecksumFunc(d))
+	if err != nil {
+		return err
+	}
+	_ = d.Set("checksum", checksum)
+	d.SetId(catalogItem.CatalogItem.ID)
+
+	return resourceVcdCatalogItemDownloadRead(d, meta)
+}
+
+// resourceVcdCatalogItemDownloadRead checks that the downloaded files are still there. Otherwise, they are downloaded
+// again
+func resourceVcdCatalogItemDownloadRead(d *schema.ResourceData, meta interface{}) error {
+	return readCatalogDownload(d, d.Get("download_path").(string), getCatalogItemChecksumFunc(d))
+}
+
+// getCatalogItemChecksumFunc returns the function that computes the checksum of a downloaded catalog item: the one of
+// the OVA file, or the one of the descriptor and all the files of the OVF folder
+func getCatalogItemChecksumFunc(d *schema.ResourceData) func(string) (string, error) {
+	if d.Get("format").(string) != catalogDownloadFormatOvf {
+		return fileSha256
+	}
+	descriptorName := d.Get("name").(string) + ".ovf"
+	return func(folder string) (string, error) {
+		return ovfFolderSha256(folder, descriptorName)
+	}
+}
+
+// readCatalogDownload removes the resource from the state when the downloaded files are missing or were changed
+func readCatalogDownload(d *schema.ResourceData, downloadPath string, checksumFunc func(string) (string, error)) error {
+	checksum, err := checksumFunc(downloadPath)
+	if os.IsNotExist(err) {
+		log.Printf("[DEBUG] downloaded file in %s not found. Removing from tfstate", downloadPath)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error computing checksum of %s: %s", downloadPath, err)
+	}
+	if checksum != d.Get("checksum").(string) {
+		log.Printf("[DEBUG] downloaded file in %s was changed. Removing from tfstate", downloadPath)
+		d.SetId("")
+	}
+	return nil
+}
+
+// resourceVcdCatalogDownloadUpdate only stores the download options, which are used when downloading
+func resourceVcdCatalogDownloadUpdate(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// resourceVcdCatalogDownloadDelete keeps the downloaded files, which are meant to outlive the resource
+func resourceVcdCatalogDownloadDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[TRACE] keeping downloaded files in %s", d.Get("download_path").(string))
+	d.SetId("")
+	return nil
+}
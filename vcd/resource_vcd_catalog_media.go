@@ -3,7 +3,6 @@ package vcd
 import (
 	"fmt"
 	"log"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -341,12 +340,23 @@ func createOrUpdateMediaItemMetadata(d *schema.ResourceData, meta interface{}) e
 //
 // Example resource name (_resource_name_): vcd_catalog_media.my-media
 // Example import path (_the_id_string_): org.catalog.my-media-name
+// Example import path (_the_id_string_): urn:vcloud:media:0a6f8d5e-3c1b-4f3a-8f5e-9d2e7c1b4a58
+// Example import path (_the_id_string_): urn:vcloud:catalog:a7d4f0e1-8c1d-4c3d-9a2e-7b1f6f6c2a10.my-media-name
 func resourceVcdCatalogMediaImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+
+	if urnType, _ := getUrnEntityType(d.Id()); urnType == "media" {
+		return getCatalogMediaForImportByUrn(d, vcdClient)
+	}
+
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalog.my-media-name")
+		return nil, fmt.Errorf("resource name must be specified as org.catalog.my-media-name or as media URN")
 	}
-	orgName, catalogName, mediaName := resourceURI[0], resourceURI[1], resourceURI[2]
+	orgName, catalogName, mediaIdentifier := resourceURI[0], resourceURI[1], resourceURI[2]
 
 	if orgName == "" {
 		return nil, fmt.Errorf("import: empty org name provided")
@@ -354,11 +364,10 @@ func resourceVcdCatalogMediaImport(d *schema.ResourceData, meta interface{}) ([]
 	if catalogName == "" {
 		return nil, fmt.Errorf("import: empty catalog name provided")
 	}
-	if mediaName == "" {
+	if mediaIdentifier == "" {
 		return nil, fmt.Errorf("import: empty media item name provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
@@ -369,14 +378,65 @@ func resourceVcdCatalogMediaImport(d *schema.ResourceData, meta interface{}) ([]
 		return nil, govcd.ErrorEntityNotFound
 	}
 
-	media, err := catalog.GetMediaByName(mediaName, false)
+	media, err := catalog.GetMediaByNameOrId(mediaIdentifier, false)
 	if err != nil {
 		return nil, govcd.ErrorEntityNotFound
 	}
 
+	return setCatalogMediaImportData(d, orgName, catalogName, media)
+}
+
+// getCatalogMediaForImportByUrn finds a media by URN.
+// The media refers to its VDC rather than to its catalog, so the catalogs of its Org are searched
+// until the one containing the media is found
+func getCatalogMediaForImportByUrn(d *schema.ResourceData, vcdClient *VCDClient) ([]*schema.ResourceData, error) {
+	entity, err := getImportEntityByUrn(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrgByName(entity.orgName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrg, entity.orgName)
+	}
+
+	catalog, media, err := findCatalogMediaById(adminOrg, entity.id)
+	if err != nil {
+		return nil, err
+	}
+	return setCatalogMediaImportData(d, entity.orgName, catalog.Catalog.Name, media)
+}
+
+// findCatalogMediaById searches the catalogs of an Org for the media with the given ID, and
+// returns the media together with the catalog that contains it.
+// Returns govcd.ErrorEntityNotFound if no catalog contains the media
+func findCatalogMediaById(adminOrg *govcd.AdminOrg, mediaId string) (*govcd.Catalog, *govcd.Media, error) {
+	catalogRecords, err := adminOrg.QueryCatalogList()
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving catalogs from org %s: %s", adminOrg.AdminOrg.Name, err)
+	}
+	for _, catalogRecord := range catalogRecords {
+		catalog, err := adminOrg.GetCatalogByName(catalogRecord.Name, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error retrieving catalog %s: %s", catalogRecord.Name, err)
+		}
+		media, err := catalog.GetMediaById(mediaId)
+		if govcd.ContainsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("error retrieving media %s from catalog %s: %s", mediaId, catalogRecord.Name, err)
+		}
+		return catalog, media, nil
+	}
+	return nil, nil, govcd.ErrorEntityNotFound
+}
+
+// setCatalogMediaImportData fills the resource data container of an imported media
+func setCatalogMediaImportData(d *schema.ResourceData, orgName, catalogName string, media *govcd.Media) ([]*schema.ResourceData, error) {
 	_ = d.Set("org", orgName)
 	_ = d.Set("catalog", catalogName)
-	_ = d.Set("name", mediaName)
+	_ = d.Set("name", media.Media.Name)
 	_ = d.Set("description", media.Media.Description)
 	d.SetId(media.Media.ID)
 
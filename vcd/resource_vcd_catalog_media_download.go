@@ -0,0 +1,98 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func resourceVcdCatalogMediaDownload() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdCatalogMediaDownloadCreate,
+		Read:   resourceVcdCatalogMediaDownloadRead,
+		Update: resourceVcdCatalogDownloadUpdate,
+		Delete: resourceVcdCatalogDownloadDelete,
+		Schema: catalogDownloadSchema("Name of the media to download", "Path of the ISO file to write"),
+	}
+}
+
+func resourceVcdCatalogMediaDownloadCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+	catalogName := d.Get("catalog").(string)
+	catalog, err := adminOrg.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+	}
+	mediaName := d.Get("name").(string)
+	media, err := catalog.GetMediaByName(mediaName, false)
+	if err != nil {
+		return fmt.Errorf("error finding media %s: %s", mediaName, err)
+	}
+
+	log.Printf("[TRACE] enabling download of media %s", mediaName)
+	err = enableDownload(&vcdClient.Client, media.Media.HREF)
+	if err != nil {
+		return fmt.Errorf("error enabling download of media %s: %s", mediaName, err)
+	}
+	err = media.Refresh()
+	if err != nil {
+		return err
+	}
+
+	file := getMediaDownloadFile(media.Media)
+	if file.href == "" {
+		return fmt.Errorf("no download link found for media %s", mediaName)
+	}
+
+	downloader := &catalogDownloader{
+		client:         &vcdClient.Client,
+		pieceSize:      int64(d.Get("download_piece_size").(int)) * 1024 * 1024, // Convert from megabytes to bytes
+		showProgress:   d.Get("show_download_progress").(bool),
+		progressPrefix: "vcd_catalog_media_download." + mediaName,
+	}
+	downloadPath := d.Get("download_path").(string)
+	err = downloader.download(file, downloadPath)
+	if err != nil {
+		return err
+	}
+
+	checksum, err := verifyChecksum(downloadPath, d.Get("expected_checksum").(string), fileSha256)
+	if err != nil {
+		return err
+	}
+	_ = d.Set("checksum", checksum)
+	d.SetId(media.Media.ID)
+
+	return resourceVcdCatalogMediaDownloadRead(d, meta)
+}
+
+// getMediaDownloadFile returns the file of a media with its download link, which can be in the file or in the media
+func getMediaDownloadFile(media *types.Media) catalogDownloadFile {
+	file := catalogDownloadFile{name: media.Name, href: getDownloadLink(media.Link), size: media.Size}
+	if media.Files == nil {
+		return file
+	}
+	for _, mediaFile := range media.Files.File {
+		if href := getDownloadLink(mediaFile.Link); href != "" {
+			file.href = href
+			if mediaFile.Size > 0 {
+				file.size = mediaFile.Size
+			}
+			break
+		}
+	}
+	return file
+}
+
+// resourceVcdCatalogMediaDownloadRead checks that the downloaded file is still there. Otherwise, it is downloaded
+// again
+func resourceVcdCatalogMediaDownloadRead(d *schema.ResourceData, meta interface{}) error {
+	return readCatalogDownload(d, d.Get("download_path").(string), fileSha256)
+}
@@ -0,0 +1,324 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	mimeCaptureVAppParams = "application/vnd.vmware.vcloud.captureVAppParams+xml"
+	// vappTemplateOverwriteSuffix is added to the name of a vApp template captured to replace an existing one, until
+	// the existing one is deleted
+	vappTemplateOverwriteSuffix = "-tf-overwrite"
+)
+
+// captureVAppParams is the payload for the catalog action that captures a vApp into a vApp template
+type captureVAppParams struct {
+	XMLName              xml.Name                     `xml:"CaptureVAppParams"`
+	Xmlns                string                       `xml:"xmlns,attr"`
+	Ovf                  string                       `xml:"xmlns:ovf,attr"`
+	Name                 string                       `xml:"name,attr"`
+	Description          string                       `xml:"Description,omitempty"`
+	Source               *types.Reference             `xml:"Source"`
+	CustomizationSection *captureCustomizationSection `xml:"ovf:CustomizationSection,omitempty"`
+}
+
+// catalogEntityRename is the payload that renames a catalog item or a vApp template
+type catalogEntityRename struct {
+	XMLName     xml.Name
+	Xmlns       string           `xml:"xmlns,attr"`
+	Name        string           `xml:"name,attr"`
+	Description string           `xml:"Description,omitempty"`
+	Entity      *types.Reference `xml:"Entity,omitempty"`
+}
+
+// captureCustomizationSection tells whether the VMs of the vApp template are customized when instantiated
+type captureCustomizationSection struct {
+	Info                   string `xml:"ovf:Info"`
+	CustomizeOnInstantiate bool   `xml:"CustomizeOnInstantiate"`
+}
+
+func resourceVcdCatalogVappTemplate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdCatalogVappTemplateCreate,
+		Read:   resourceVcdCatalogVappTemplateRead,
+		Update: resourceVcdCatalogVappTemplateUpdate,
+		Delete: resourceVcdCatalogVappTemplateDelete,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"catalog": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Catalog name where the vApp template is captured",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Name of the catalog item and of the vApp template",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of the vApp template",
+			},
+			"vapp_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"vapp_id", "vm_id"},
+				Description:  "ID of the vApp to capture",
+			},
+			"vm_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"vapp_id", "vm_id"},
+				Description:  "ID of the VM to capture. It must be the only VM of its vApp",
+			},
+			"customize_on_instantiate": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "True if the VMs created from the vApp template are customized when instantiated",
+			},
+			"overwrite": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "True if an existing catalog item with the same name is replaced. Otherwise the capture fails",
+			},
+			"vapp_template_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the captured vApp template",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Time stamp of when the vApp template was created",
+			},
+		},
+	}
+}
+
+func resourceVcdCatalogVappTemplateCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	catalogName := d.Get("catalog").(string)
+	catalog, err := adminOrg.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+	}
+
+	sourceHref, err := getCaptureSourceHref(d, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	itemName := d.Get("name").(string)
+	existingItem, err := catalog.GetCatalogItemByName(itemName, false)
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return fmt.Errorf("error checking catalog item %s: %s", itemName, err)
+	}
+
+	// An existing item is replaced only after the capture succeeds, so the vApp template is captured under a
+	// temporary name
+	captureName := itemName
+	if existingItem != nil {
+		if !d.Get("overwrite").(bool) {
+			return fmt.Errorf("catalog item %s already exists in catalog %s. Set 'overwrite' to replace it", itemName, catalogName)
+		}
+		captureName = itemName + vappTemplateOverwriteSuffix
+		err = deleteCatalogItemIfExists(catalog, captureName)
+		if err != nil {
+			return err
+		}
+	}
+
+	params := &captureVAppParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		Name:        captureName,
+		Description: d.Get("description").(string),
+		Source:      &types.Reference{HREF: sourceHref},
+		CustomizationSection: &captureCustomizationSection{
+			Info:                   "VApp template customization section",
+			CustomizeOnInstantiate: d.Get("customize_on_instantiate").(bool),
+		},
+	}
+
+	log.Printf("[TRACE] Capturing %s into catalog %s as %s", sourceHref, catalogName, captureName)
+	vAppTemplate := govcd.NewVAppTemplate(&vcdClient.Client)
+	_, err = vcdClient.Client.ExecuteRequest(catalog.Catalog.HREF+"/action/captureVApp", http.MethodPost,
+		mimeCaptureVAppParams, "error capturing vApp template: %s", params, vAppTemplate.VAppTemplate)
+	if err != nil {
+		return err
+	}
+
+	if vAppTemplate.VAppTemplate.Tasks != nil {
+		for _, taskInProgress := range vAppTemplate.VAppTemplate.Tasks.Task {
+			task := govcd.NewTask(&vcdClient.Client)
+			task.Task = taskInProgress
+			err = task.WaitTaskCompletion()
+			if err != nil {
+				// The failed capture leaves an incomplete item, which would make the next capture fail
+				errDelete := deleteCatalogItemIfExists(catalog, captureName)
+				if errDelete != nil {
+					return fmt.Errorf("error completing capture task: %s. The incomplete catalog item %s can't be removed: %s",
+						err, captureName, errDelete)
+				}
+				return fmt.Errorf(errorCompletingTask, err)
+			}
+		}
+	}
+
+	catalogItem, err := catalog.GetCatalogItemByName(captureName, true)
+	if err != nil {
+		return fmt.Errorf("error retrieving catalog item %s: %s", captureName, err)
+	}
+	// The new item is tracked before the old one is replaced, so that a failure below leaves it in the state
+	d.SetId(catalogItem.CatalogItem.ID)
+
+	if existingItem != nil {
+		log.Printf("[TRACE] Removing catalog item %s, replaced by %s", itemName, captureName)
+		err = existingItem.Delete()
+		if err != nil {
+			return fmt.Errorf("error removing catalog item %s, replaced by %s: %s", itemName, captureName, err)
+		}
+		err = renameCatalogVappTemplate(vcdClient, catalogItem, itemName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceVcdCatalogVappTemplateRead(d, meta)
+}
+
+// deleteCatalogItemIfExists removes a catalog item left by a previous capture that didn't complete
+func deleteCatalogItemIfExists(catalog *govcd.Catalog, itemName string) error {
+	catalogItem, err := catalog.GetCatalogItemByName(itemName, true)
+	if govcd.ContainsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("error checking catalog item %s: %s", itemName, err)
+	}
+	log.Printf("[TRACE] Removing catalog item %s left by a previous capture", itemName)
+	err = catalogItem.Delete()
+	if err != nil {
+		return fmt.Errorf("error removing catalog item %s: %s", itemName, err)
+	}
+	return nil
+}
+
+// renameCatalogVappTemplate gives a new name to a catalog item and to its vApp template
+func renameCatalogVappTemplate(vcdClient *VCDClient, catalogItem *govcd.CatalogItem, name string) error {
+	vAppTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return fmt.Errorf("error retrieving vApp template %s: %s", catalogItem.CatalogItem.Name, err)
+	}
+
+	log.Printf("[TRACE] Renaming vApp template %s to %s", vAppTemplate.VAppTemplate.Name, name)
+	task, err := vcdClient.Client.ExecuteTaskRequest(vAppTemplate.VAppTemplate.HREF, http.MethodPut,
+		types.MimeVAppTemplate, "error renaming vApp template: %s", &catalogEntityRename{
+			XMLName:     xml.Name{Local: "VAppTemplate"},
+			Xmlns:       types.XMLNamespaceVCloud,
+			Name:        name,
+			Description: vAppTemplate.VAppTemplate.Description,
+		})
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf(errorCompletingTask, err)
+	}
+
+	_, err = vcdClient.Client.ExecuteRequest(catalogItem.CatalogItem.HREF, http.MethodPut, types.MimeCatalogItem,
+		"error renaming catalog item: %s", &catalogEntityRename{
+			XMLName:     xml.Name{Local: "CatalogItem"},
+			Xmlns:       types.XMLNamespaceVCloud,
+			Name:        name,
+			Description: catalogItem.CatalogItem.Description,
+			Entity:      &types.Reference{HREF: vAppTemplate.VAppTemplate.HREF},
+		}, catalogItem.CatalogItem)
+	return err
+}
+
+// getCaptureSourceHref returns the HREF of the vApp to capture. When a VM is given, its vApp is captured, as long
+// as the VM is the only one in it
+func getCaptureSourceHref(d *schema.ResourceData, vcdClient *VCDClient) (string, error) {
+	if vappId, ok := d.GetOk("vapp_id"); ok {
+		return getCloneSourceHref(vcdClient, vappId.(string), "vapp")
+	}
+
+	vmId := d.Get("vm_id").(string)
+	vmHref, err := getCloneSourceHref(vcdClient, vmId, "vm")
+	if err != nil {
+		return "", err
+	}
+	vm, err := vcdClient.Client.GetVMByHref(vmHref)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving VM %s: %s", vmId, err)
+	}
+	vapp, err := vm.GetParentVApp()
+	if err != nil {
+		return "", fmt.Errorf("error retrieving the vApp of VM %s: %s", vmId, err)
+	}
+	if vapp.VApp.Children != nil && len(vapp.VApp.Children.VM) > 1 {
+		return "", fmt.Errorf("VM %s can't be captured alone, as its vApp %s has %d VMs. Use 'vapp_id' instead",
+			vm.VM.Name, vapp.VApp.Name, len(vapp.VApp.Children.VM))
+	}
+	return vapp.VApp.HREF, nil
+}
+
+func resourceVcdCatalogVappTemplateRead(d *schema.ResourceData, meta interface{}) error {
+	catalogItem, err := findCatalogItem(d, meta.(*VCDClient), "resource")
+	if err != nil {
+		return err
+	}
+	if catalogItem == nil {
+		return nil
+	}
+
+	vAppTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return err
+	}
+
+	_ = d.Set("name", catalogItem.CatalogItem.Name)
+	_ = d.Set("description", catalogItem.CatalogItem.Description)
+	_ = d.Set("vapp_template_id", vAppTemplate.VAppTemplate.ID)
+	_ = d.Set("created", vAppTemplate.VAppTemplate.DateCreated)
+	return nil
+}
+
+// resourceVcdCatalogVappTemplateUpdate only stores "overwrite", which is used when capturing
+func resourceVcdCatalogVappTemplateUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceVcdCatalogVappTemplateRead(d, meta)
+}
+
+func resourceVcdCatalogVappTemplateDelete(d *schema.ResourceData, meta interface{}) error {
+	return deleteCatalogItem(d, meta.(*VCDClient))
+}
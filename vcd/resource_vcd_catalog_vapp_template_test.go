@@ -0,0 +1,102 @@
+// +build catalog ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdCatalogVappTemplate captures a VM into the catalog and creates a new VM from the captured vApp template
+// in the same run. It also checks that a capture with the name of an existing item fails without "overwrite"
+func TestAccVcdCatalogVappTemplate(t *testing.T) {
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	var params = StringMap{
+		"Org":          testConfig.VCD.Org,
+		"Vdc":          testConfig.VCD.Vdc,
+		"Catalog":      testSuiteCatalogName,
+		"CatalogItem":  testSuiteCatalogOVAItem,
+		"VappName":     "TestCaptureVapp",
+		"VmName":       "TestCaptureVm",
+		"TemplateName": "TestCapturedTemplate",
+		"FuncName":     t.Name(),
+	}
+
+	configText := templateFill(testAccCheckVcdCatalogVappTemplate, params)
+	params["FuncName"] = t.Name() + "-existing"
+	params["TemplateName"] = testSuiteCatalogOVAItem
+	configTextExisting := templateFill(testAccCheckVcdCatalogVappTemplate, params)
+
+	resourceName := "vcd_catalog_vapp_template.captured"
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckCatalogItemDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdCatalogItemExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "id", regexp.MustCompile(`^urn:vcloud:catalogitem:`)),
+					resource.TestMatchResourceAttr(resourceName, "vapp_template_id", regexp.MustCompile(`^urn:vcloud:vapptemplate:`)),
+					resource.TestCheckResourceAttr(resourceName, "customize_on_instantiate", "true"),
+					resource.TestCheckResourceAttr("vcd_vapp_vm.from_capture", "name", "TestVmFromCapture"),
+				),
+			},
+			resource.TestStep{
+				Config:      configTextExisting,
+				ExpectError: regexp.MustCompile(`already exists in catalog`),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdCatalogVappTemplate = `
+resource "vcd_vapp" "source" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "source" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.source.name
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = false
+}
+
+resource "vcd_catalog_vapp_template" "captured" {
+  org                      = "{{.Org}}"
+  catalog                  = "{{.Catalog}}"
+  name                     = "{{.TemplateName}}"
+  description              = "Captured from {{.VmName}}"
+  vm_id                    = vcd_vapp_vm.source.id
+  customize_on_instantiate = true
+}
+
+resource "vcd_vapp_vm" "from_capture" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.source.name
+  name          = "TestVmFromCapture"
+  catalog_name  = vcd_catalog_vapp_template.captured.catalog
+  template_name = vcd_catalog_vapp_template.captured.name
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = false
+}
+`
@@ -4,7 +4,6 @@ package vcd
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -198,17 +197,20 @@ func resourceVcdEdgeGatewaySettingsDelete(d *schema.ResourceData, meta interface
 //
 // Example resource name (_resource_name_): vcd_edgegateway_settings.my-edge-gateway-name
 // Example import path (_the_id_string_): org.vdc.my-edge-gw
+// Example import path (_the_id_string_): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 // Note: the edge gateway can be identified by either the name or the ID
 func resourceVcdEdgeGatewaySettingsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[resourceVcdEdgeGatewaySettingsImport] resource name must be specified as org-name.vdc-name.edge-gw-name (or edge-gw-ID)")
 	}
 	orgName, vdcName, edgeName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
-
 	org, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find org %s: %s", orgName, err)
@@ -7,6 +7,7 @@ import (
 	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 )
 
@@ -15,6 +16,9 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 		Create: resourceVcdEdgeGatewayVpnCreate,
 		Read:   resourceVcdEdgeGatewayVpnRead,
 		Delete: resourceVcdEdgeGatewayVpnDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVcdEdgeGatewayVpnImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -282,6 +286,11 @@ func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) err
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing VPN from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
@@ -294,30 +303,94 @@ func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if len(egsc.Tunnel) == 1 {
-		tunnel := egsc.Tunnel[0]
-		_ = d.Set("name", tunnel.Name)
-		_ = d.Set("description", tunnel.Description)
-		_ = d.Set("encryption_protocol", tunnel.EncryptionProtocol)
-		_ = d.Set("local_ip_address", tunnel.LocalIPAddress)
-		_ = d.Set("local_id", tunnel.LocalID)
-		_ = d.Set("mtu", tunnel.Mtu)
-		_ = d.Set("peer_ip_address", tunnel.PeerIPAddress)
-		_ = d.Set("peer_id", tunnel.PeerID)
-		err := convertAndSet("local_subnets", "local", tunnel.LocalSubnet, d)
-		if err != nil {
-			return fmt.Errorf("error setting 'local_subnets': %s", err)
-		}
-		err = convertAndSet("peer_subnets", "peer", tunnel.PeerSubnet, d)
-		if err != nil {
-			return fmt.Errorf("error setting 'peer_subnets': %s", err)
-		}
-	} else {
-		return fmt.Errorf("multiple tunnels not currently supported")
+		return setEdgeGatewayVpnData(d, egsc.Tunnel[0])
 	}
+	return fmt.Errorf("multiple tunnels not currently supported")
+}
 
+// setEdgeGatewayVpnData fills the resource data with the properties of a VPN tunnel
+func setEdgeGatewayVpnData(d *schema.ResourceData, tunnel *types.GatewayIpsecVpnTunnel) error {
+	_ = d.Set("name", tunnel.Name)
+	_ = d.Set("description", tunnel.Description)
+	_ = d.Set("encryption_protocol", tunnel.EncryptionProtocol)
+	_ = d.Set("local_ip_address", tunnel.LocalIPAddress)
+	_ = d.Set("local_id", tunnel.LocalID)
+	_ = d.Set("mtu", tunnel.Mtu)
+	_ = d.Set("peer_ip_address", tunnel.PeerIPAddress)
+	_ = d.Set("peer_id", tunnel.PeerID)
+	err := convertAndSet("local_subnets", "local", tunnel.LocalSubnet, d)
+	if err != nil {
+		return fmt.Errorf("error setting 'local_subnets': %s", err)
+	}
+	err = convertAndSet("peer_subnets", "peer", tunnel.PeerSubnet, d)
+	if err != nil {
+		return fmt.Errorf("error setting 'peer_subnets': %s", err)
+	}
 	return nil
 }
 
+// resourceVcdEdgeGatewayVpnImport imports the VPN tunnel of an edge gateway.
+// The tunnel is identified by its name. As the resource manages a single tunnel,
+// edge gateways with more than one tunnel can't be imported.
+//
+// Example import path (id): org-name.vdc-name.edge-gw-name.tunnel-name
+// Example import path (id): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8.tunnel-name
+func resourceVcdEdgeGatewayVpnImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.edge-gw-name.tunnel-name")
+	}
+	orgName, vdcName, edgeName, tunnelName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	edgeGateway, err := vdc.GetEdgeGatewayByNameOrId(edgeName, false)
+	if err != nil {
+		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
+	}
+
+	serviceConfiguration := edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration
+	if serviceConfiguration == nil || serviceConfiguration.GatewayIpsecVpnService == nil {
+		return nil, fmt.Errorf("no VPN tunnels found in edge gateway %s", edgeGateway.EdgeGateway.Name)
+	}
+	tunnels := serviceConfiguration.GatewayIpsecVpnService.Tunnel
+	var foundTunnel *types.GatewayIpsecVpnTunnel
+	for _, tunnel := range tunnels {
+		if tunnel.Name == tunnelName {
+			foundTunnel = tunnel
+			break
+		}
+	}
+	if foundTunnel == nil {
+		return nil, fmt.Errorf("VPN tunnel %s not found in edge gateway %s", tunnelName, edgeGateway.EdgeGateway.Name)
+	}
+	if len(tunnels) > 1 {
+		return nil, fmt.Errorf("edge gateway %s has %d VPN tunnels: multiple tunnels not currently supported",
+			edgeGateway.EdgeGateway.Name, len(tunnels))
+	}
+
+	err = setEdgeGatewayVpnData(d, foundTunnel)
+	if err != nil {
+		return nil, err
+	}
+	// The shared secret is not refreshed by the read function, but it is needed to avoid a replacement
+	// of the tunnel at the first apply after import
+	if foundTunnel.SharedSecret != "" {
+		_ = d.Set("shared_secret", foundTunnel.SharedSecret)
+	}
+	_ = d.Set("org", orgName)
+	_ = d.Set("vdc", vdcName)
+	_ = d.Set("edge_gateway", edgeGateway.EdgeGateway.Name)
+	d.SetId(edgeGateway.EdgeGateway.Name)
+	return []*schema.ResourceData{d}, nil
+}
+
 func convertAndSet(key, prefix string, subNets []*types.IpsecVpnSubnet, d *schema.ResourceData) error {
 	var items []map[string]interface{}
 
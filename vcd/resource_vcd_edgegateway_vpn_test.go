@@ -52,6 +52,14 @@ func TestAccVcdVpn_Basic(t *testing.T) {
 						"vcd_edgegateway_vpn."+vpnName, "encryption_protocol", "AES256"),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "vcd_edgegateway_vpn." + vpnName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdEdgeGatewayObject(testConfig, testConfig.Networking.EdgeGateway, params["SiteName"].(string)),
+				// The shared secret may not be returned by the API
+				ImportStateVerifyIgnore: []string{"shared_secret"},
+			},
 		},
 	})
 }
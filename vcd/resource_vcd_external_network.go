@@ -189,6 +189,10 @@ func setExternalNetworkData(d *schema.ResourceData, extNetRes StringMap) error {
 
 // resourceVcdExternalNetworkRead fetches information about an existing external network
 func resourceVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdExternalNetworkRead(d, meta, "resource")
+}
+
+func genericVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}, origin string) error {
 	log.Printf("[TRACE] external network read initiated")
 
 	vcdClient := meta.(*VCDClient)
@@ -198,7 +202,11 @@ func resourceVcdExternalNetworkRead(d *schema.ResourceData, meta interface{}) er
 		identifier = d.Get("name").(string)
 	}
 	extNeRes, ID, err := getExternalNetworkResource(vcdClient.VCDClient, identifier)
-
+	if govcd.ContainsNotFound(err) && origin == "resource" {
+		log.Printf("[DEBUG] Unable to find external network %s. Removing from tfstate", identifier)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error fetching external network (%s) details %s", identifier, err)
 	}
@@ -430,9 +438,10 @@ func getExternalNetworkResource(vcdClient *govcd.VCDClient, extNetIdentifier str
 // The d.ID() field as being passed from `terraform import _resource_name_ _the_id_string_ requires
 // a name based dot-formatted path to the object to lookup the object and sets the id of object.
 // `terraform import` automatically performs `refresh` operation which loads up all other fields.
-// For this resource, the import path is just the external network name.
+// For this resource, the import path is just the external network name or ID.
 //
 // Example import path (id): externalNetworkName
+// Example import path (id): urn:vcloud:network:5e8b7a8d-0f2b-4c7e-8d3f-3a1b2c4d5e6f
 // Example import command:   terraform import vcd_external_network.externalNetworkName externalNetworkName
 func resourceVcdExternalNetworkImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
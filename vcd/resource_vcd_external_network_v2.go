@@ -241,15 +241,22 @@ func resourceVcdExternalNetworkV2Delete(d *schema.ResourceData, meta interface{}
 // The d.ID() field as being passed from `terraform import _resource_name_ _the_id_string_ requires
 // a name based dot-formatted path to the object to lookup the object and sets the id of object.
 // `terraform import` automatically performs `refresh` operation which loads up all other fields.
-// For this resource, the import path is just the external network name.
+// For this resource, the import path is just the external network name or URN.
 //
 // Example import path (id): externalNetworkName
+// Example import path (id): urn:vcloud:network:5e8b7a8d-0f2b-4c7e-8d3f-3a1b2c4d5e6f
 // Example import command:   terraform import vcd_external_network_v2.externalNetworkResourceName externalNetworkName
 func resourceVcdExternalNetworkV2Import(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 	vcdClient := meta.(*VCDClient)
 
-	extNetRes, err := govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, d.Id())
+	var extNetRes *govcd.ExternalNetworkV2
+	var err error
+	if isImportUrn(d.Id()) {
+		extNetRes, err = govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, d.Id())
+	} else {
+		extNetRes, err = govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, d.Id())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error fetching external network V2 details %s", err)
 	}
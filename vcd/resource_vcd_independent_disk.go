@@ -309,7 +309,8 @@ func resourceVcdIndependentDiskDelete(d *schema.ResourceData, meta interface{})
 }
 
 var errHelpDiskImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'org-name.vdc-name.my-independent-disk-id' to import by rule id
+'org-name.vdc-name.my-independent-disk-id' to import by disk id
+'urn:vcloud:disk:my-independent-disk-uuid' to import by disk URN
 'list@org-name.vdc-name.my-independent-disk-name' to get a list of disks with their IDs`)
 
 // resourceVcdIndependentDiskImport is responsible for importing the resource.
@@ -327,11 +328,16 @@ var errHelpDiskImport = fmt.Errorf(`resource id must be specified in one of thes
 //
 // Example resource name (_resource_name_): vcd_independent_disk.my-disk
 // Example import path (_the_id_string_): org-name.vdc-name.my-independent-disk-id
+// Example import path (_the_id_string_): urn:vcloud:disk:c4b8e6a1-3f8e-4f5b-9e6f-1d2c3b4a5e6f
 // Example list path (_the_id_string_): list@org-name.vdc-name.my-independent-disk-name
 func resourceVcdIndependentDiskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, diskName, diskId string
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Printf("[DEBUG] importing vcd_independent_disk resource with provided id %s", d.Id())
 
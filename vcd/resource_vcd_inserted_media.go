@@ -15,6 +15,9 @@ func resourceVcdInsertedMedia() *schema.Resource {
 		Delete: resourceVcdMediaEject,
 		Read:   resourceVcdVmInsertedMediaRead,
 		Update: resourceVcdMediaEjectUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceVcdInsertedMediaImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"vdc": {
@@ -166,6 +169,73 @@ func getVM(d *schema.ResourceData, meta interface{}) (*govcd.VM, *govcd.Org, err
 	return vm, org, nil
 }
 
+// getVmInsertedMedia returns the reference to the media inserted in the VM, or nil if no media is inserted
+func getVmInsertedMedia(vm *govcd.VM) *types.Reference {
+	if vm.VM.VmSpecSection == nil || vm.VM.VmSpecSection.MediaSection == nil {
+		return nil
+	}
+	for _, mediaSettings := range vm.VM.VmSpecSection.MediaSection.MediaSettings {
+		if mediaSettings.MediaImage != nil && mediaSettings.MediaImage.HREF != "" {
+			return mediaSettings.MediaImage
+		}
+	}
+	return nil
+}
+
+// resourceVcdInsertedMediaImport imports the media inserted in a VM.
+// The catalog containing the media is found by searching the catalogs of the Org
+//
+// Example import path (id): org-name.vdc-name.vapp-name.vm-name
+// Example import path (id): urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3
+func resourceVcdInsertedMediaImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.vapp-name.vm-name")
+	}
+	orgName, vdcName, vappName, vmName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	vapp, err := vdc.GetVAppByNameOrId(vappName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp %s: %s", vappName, err)
+	}
+	vm, err := vapp.GetVMByNameOrId(vmName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VM %s: %s", vmName, err)
+	}
+
+	mediaImage := getVmInsertedMedia(vm)
+	if mediaImage == nil {
+		return nil, fmt.Errorf("no media inserted in VM %s", vm.VM.Name)
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrg(orgName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrg, err)
+	}
+	catalog, media, err := findCatalogMediaById(adminOrg, normalizeId("urn:vcloud:media:", extractUuid(mediaImage.HREF)))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving catalog of media %s: %s", mediaImage.Name, err)
+	}
+
+	_ = d.Set("org", orgName)
+	_ = d.Set("vdc", vdcName)
+	_ = d.Set("vapp_name", vapp.VApp.Name)
+	_ = d.Set("vm_name", vm.VM.Name)
+	_ = d.Set("catalog", catalog.Catalog.Name)
+	_ = d.Set("name", media.Media.Name)
+	_ = d.Set("eject_force", true)
+	d.SetId(vapp.VApp.Name + "_" + vm.VM.Name + "_" + media.Media.Name)
+	return []*schema.ResourceData{d}, nil
+}
+
 //update function for "eject_force"
 func resourceVcdMediaEjectUpdate(d *schema.ResourceData, m interface{}) error {
 	d.Set("eject_force", d.Get("eject_force"))
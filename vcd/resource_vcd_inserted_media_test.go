@@ -57,6 +57,12 @@ func TestAccVcdMediaInsertBasic(t *testing.T) {
 					testAccCheckMediaEjected("vcd_inserted_media."+TestAccVcdMediaInsert),
 				),
 			},
+			resource.TestStep{
+				ResourceName:      "vcd_inserted_media." + TestAccVcdMediaInsert,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdVappObject(testConfig, vappNameForInsert, vmNameForInsert),
+			},
 		},
 	})
 }
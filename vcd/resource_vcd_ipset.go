@@ -188,20 +188,22 @@ func resourceVcdIpSetDelete(d *schema.ResourceData, meta interface{}) error {
 // resourceVcdIpSetImport
 func resourceVcdIpSetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.ipset-name")
 	}
 	orgName, vdcName, ipSetName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
-
-	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
-	ipSet, err := vdc.GetNsxvIpSetByName(ipSetName)
+	ipSet, err := vdc.GetNsxvIpSetByNameOrId(ipSetName)
 
 	if err != nil {
 		return nil, fmt.Errorf("unable to find IP set with name %s", ipSetName)
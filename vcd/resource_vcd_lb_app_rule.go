@@ -2,9 +2,10 @@ package vcd
 
 import (
 	"fmt"
-	"strings"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 )
 
@@ -85,13 +86,22 @@ func resourceVcdLBAppRuleRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing load balancer application rule from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBRule, err := edgeGateway.GetLbAppRuleById(d.Id())
-	if err != nil {
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find load balancer application rule with ID %s. Removing from tfstate", d.Id())
 		d.SetId("")
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("unable to find load balancer application rule with ID %s: %s", d.Id(), err)
 	}
 
@@ -157,21 +167,28 @@ func resourceVcdLBAppRuleDelete(d *schema.ResourceData, meta interface{}) error
 //
 // Example resource name (_resource_name_): vcd_lb_app_rule.my-test-app-rule
 // Example import path (_the_id_string_): org.vdc.edge-gw.existing-app-rule
+// Example import path (_the_id_string_): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8.applicationRule-1
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLBAppRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified in such way org.vdc.edge-gw.existing-app-rule")
 	}
 	orgName, vdcName, edgeName, appRuleName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBRule, err := edgeGateway.GetLbAppRuleByName(appRuleName)
+	if govcd.IsNotFound(err) {
+		readLBRule, err = edgeGateway.GetLbAppRuleById(appRuleName)
+	}
 	if err != nil {
 		return []*schema.ResourceData{}, fmt.Errorf("unable to find load balancer application rule with name %s: %s",
 			d.Id(), err)
@@ -180,7 +197,7 @@ func resourceVcdLBAppRuleImport(d *schema.ResourceData, meta interface{}) ([]*sc
 	d.Set("org", orgName)
 	d.Set("vdc", vdcName)
 	d.Set("edge_gateway", edgeName)
-	d.Set("name", appRuleName)
+	d.Set("name", readLBRule.Name)
 
 	d.SetId(readLBRule.ID)
 	return []*schema.ResourceData{d}, nil
@@ -2,9 +2,10 @@ package vcd
 
 import (
 	"fmt"
-	"strings"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 )
 
@@ -171,13 +172,22 @@ func resourceVcdLBServerPoolRead(d *schema.ResourceData, meta interface{}) error
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing load balancer server pool from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBPool, err := edgeGateway.GetLbServerPoolById(d.Id())
-	if err != nil {
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find load balancer server pool with ID %s. Removing from tfstate", d.Id())
 		d.SetId("")
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("unable to find load balancer server pool with ID %s: %s", d.Id(), err)
 	}
 
@@ -233,21 +243,28 @@ func resourceVcdLBServerPoolDelete(d *schema.ResourceData, meta interface{}) err
 // `terraform import` automatically performs `refresh` operation which loads up all other fields.
 //
 // Example import path (id): org.vdc.edge-gw.lb-server-pool
+// Example import path (id): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8.pool-1
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLBServerPoolImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-server-pool")
 	}
 	orgName, vdcName, edgeName, poolName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBPool, err := edgeGateway.GetLbServerPoolByName(poolName)
+	if govcd.IsNotFound(err) {
+		readLBPool, err = edgeGateway.GetLbServerPoolById(poolName)
+	}
 	if err != nil {
 		return []*schema.ResourceData{}, fmt.Errorf("unable to find load balancer server pool with name %s: %s", d.Id(), err)
 	}
@@ -255,7 +272,7 @@ func resourceVcdLBServerPoolImport(d *schema.ResourceData, meta interface{}) ([]
 	d.Set("org", orgName)
 	d.Set("vdc", vdcName)
 	d.Set("edge_gateway", edgeName)
-	d.Set("name", poolName)
+	d.Set("name", readLBPool.Name)
 
 	d.SetId(readLBPool.ID)
 	return []*schema.ResourceData{d}, nil
@@ -2,9 +2,11 @@ package vcd
 
 import (
 	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 )
 
@@ -130,13 +132,22 @@ func resourceVcdLbServiceMonitorRead(d *schema.ResourceData, meta interface{}) e
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing load balancer service monitor from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBMonitor, err := edgeGateway.GetLbServiceMonitorById(d.Id())
-	if err != nil {
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find load balancer service monitor with ID %s. Removing from tfstate", d.Id())
 		d.SetId("")
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("unable to find load balancer service monitor with ID %s: %s", d.Id(), err)
 	}
 
@@ -193,22 +204,29 @@ func resourceVcdLbServiceMonitorDelete(d *schema.ResourceData, meta interface{})
 // `terraform import` automatically performs `refresh` operation which loads up all other fields.
 //
 // Example import path (id): org.vdc.edge-gw.lb-service-monitor
+// Example import path (id): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8.monitor-1
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLbServiceMonitorImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-service-monitor")
 	}
 	orgName, vdcName, edgeName, monitorName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readLBMonitor, err := edgeGateway.GetLbServiceMonitorByName(monitorName)
+	if govcd.IsNotFound(err) {
+		readLBMonitor, err = edgeGateway.GetLbServiceMonitorById(monitorName)
+	}
 	if err != nil {
 		return []*schema.ResourceData{}, fmt.Errorf("unable to find load balancer service monitor with ID %s: %s", d.Id(), err)
 	}
@@ -216,7 +234,7 @@ func resourceVcdLbServiceMonitorImport(d *schema.ResourceData, meta interface{})
 	d.Set("org", orgName)
 	d.Set("vdc", vdcName)
 	d.Set("edge_gateway", edgeName)
-	d.Set("name", monitorName)
+	d.Set("name", readLBMonitor.Name)
 
 	d.SetId(readLBMonitor.ID)
 	return []*schema.ResourceData{d}, nil
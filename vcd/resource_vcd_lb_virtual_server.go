@@ -2,10 +2,11 @@ package vcd
 
 import (
 	"fmt"
-	"strings"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 )
 
@@ -137,13 +138,22 @@ func resourceVcdLBVirtualServerRead(d *schema.ResourceData, meta interface{}) er
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing load balancer virtual server from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readVirtualServer, err := edgeGateway.GetLbVirtualServerById(d.Id())
-	if err != nil {
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find load balancer virtual server with ID %s. Removing from tfstate", d.Id())
 		d.SetId("")
+		return nil
+	}
+	if err != nil {
 		return fmt.Errorf("unable to find load balancer virtual server with ID %s: %s", d.Id(), err)
 	}
 
@@ -206,21 +216,28 @@ func resourceVcdLBVirtualServerDelete(d *schema.ResourceData, meta interface{})
 //
 // Example resource name (_resource_name_): vcd_lb_virtual_server.my-test-virtual-server
 // Example import path (_the_id_string_): org.vdc.edge-gw.existing-virtual-server
+// Example import path (_the_id_string_): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8.virtualServer-1
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLBVirtualServerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-virtual-server")
 	}
 	orgName, vdcName, edgeName, virtualServerName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
 
 	readVirtualServer, err := edgeGateway.GetLbVirtualServerByName(virtualServerName)
+	if govcd.IsNotFound(err) {
+		readVirtualServer, err = edgeGateway.GetLbVirtualServerById(virtualServerName)
+	}
 	if err != nil {
 		return []*schema.ResourceData{}, fmt.Errorf("unable to find load balancer virtual server with name %s: %s",
 			d.Id(), err)
@@ -229,7 +246,7 @@ func resourceVcdLBVirtualServerImport(d *schema.ResourceData, meta interface{})
 	d.Set("org", orgName)
 	d.Set("vdc", vdcName)
 	d.Set("edge_gateway", edgeName)
-	d.Set("name", virtualServerName)
+	d.Set("name", readVirtualServer.Name)
 
 	d.SetId(readVirtualServer.ID)
 	return []*schema.ResourceData{d}, nil
@@ -3,7 +3,6 @@ package vcd
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/lmicke/go-vcloud-director/v2/govcd"
@@ -267,21 +266,25 @@ func getNetwork(d *schema.ResourceData, vcdClient *VCDClient, isDataSource bool,
 //
 // Example resource name (_resource_name_): vcd_network_direct.my-network
 // Example import path (_the_id_string_): org.vdc.my-network
+// Example import path (_the_id_string_): urn:vcloud:network:1f5d4a7b-62a4-43ab-9a6b-6d93c7f5e0a2
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdNetworkDirectImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[direct network import] resource name must be specified as org-name.vdc-name.network-name")
 	}
 	orgName, vdcName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[direct network import] unable to find VDC %s: %s ", vdcName, err)
 	}
 
-	network, err := vdc.GetOrgVdcNetworkByName(networkName, false)
+	network, err := vdc.GetOrgVdcNetworkByNameOrId(networkName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[direct network import] error retrieving network %s: %s", networkName, err)
 	}
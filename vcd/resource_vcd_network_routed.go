@@ -519,26 +519,30 @@ func resourceVcdNetworkRoutedDhcpPoolHash(v interface{}) int {
 //
 // Example resource name (_resource_name_): vcd_network_routed.my-network
 // Example import path (_the_id_string_): org.vdc.my-network
+// Example import path (_the_id_string_): urn:vcloud:network:1f5d4a7b-62a4-43ab-9a6b-6d93c7f5e0a2
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdNetworkRoutedImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[routed network import] resource name must be specified as org-name.vdc-name.network-name")
 	}
 	orgName, vdcName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[routed network import] unable to find VDC %s: %s ", vdcName, err)
 	}
 
-	network, err := vdc.GetOrgVdcNetworkByName(networkName, false)
+	network, err := vdc.GetOrgVdcNetworkByNameOrId(networkName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[routed network import] error retrieving network %s: %s", networkName, err)
 	}
 
-	edgeGatewayName, err := vdc.FindEdgeGatewayNameByNetwork(networkName)
+	edgeGatewayName, err := vdc.FindEdgeGatewayNameByNetwork(network.OrgVDCNetwork.Name)
 	if err != nil {
 		return nil, fmt.Errorf("[routed network] no edge gateway connection found for network %s: %s", network.OrgVDCNetwork.Name, err)
 	}
@@ -2,7 +2,7 @@ package vcd
 
 import (
 	"fmt"
-	"strings"
+	"log"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/lmicke/go-vcloud-director/v2/govcd"
@@ -131,9 +131,18 @@ func resourceVcdNsxvDhcpRelayUpdate(d *schema.ResourceData, meta interface{}) er
 
 // resourceVcdNsxvDhcpRelayRead reads DHCP relay configuration and persists to statefile
 func resourceVcdNsxvDhcpRelayRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdNsxvDhcpRelayRead(d, meta, "resource")
+}
+
+func genericVcdNsxvDhcpRelayRead(d *schema.ResourceData, meta interface{}, origin string) error {
 	vcdClient := meta.(*VCDClient)
 
 	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if govcd.ContainsNotFound(err) && origin == "resource" {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing DHCP relay settings from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
@@ -192,15 +201,25 @@ func resourceVcdNsxvDhcpRelayDelete(d *schema.ResourceData, meta interface{}) er
 // resourceVcdNsxvDhcpRelayImport imports DHCP relay configuration. Because DHCP relay is just a
 // settings on edge gateway and not a separate object - the ID actually does not represent any
 // object
+//
+// Example import path (id): org-name.vdc-name.edge-gw-name
+// Example import path (id): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8
 func resourceVcdNsxvDhcpRelayImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.edge-gw-name")
 	}
 	orgName, vdcName, edgeName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
-	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	edgeGateway, err := vdc.GetEdgeGatewayByNameOrId(edgeName, false)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
 	}
@@ -212,7 +231,7 @@ func resourceVcdNsxvDhcpRelayImport(d *schema.ResourceData, meta interface{}) ([
 
 	d.Set("org", orgName)
 	d.Set("vdc", vdcName)
-	d.Set("edge_gateway", edgeName)
+	d.Set("edge_gateway", edgeGateway.EdgeGateway.Name)
 	d.SetId(compositeId)
 	return []*schema.ResourceData{d}, nil
 }
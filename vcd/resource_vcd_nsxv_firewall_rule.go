@@ -314,16 +314,6 @@ func resourceVcdNsxvFirewallRuleUpdate(d *schema.ResourceData, meta interface{})
 func resourceVcdNsxvFirewallRuleRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 
-	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
-	if err != nil {
-		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
-	}
-
-	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
-	if err != nil {
-		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
-	}
-
 	// Detect if this is data source or resource field and pick correct ID field
 	var isDatasource bool
 	id := d.Id()
@@ -335,10 +325,29 @@ func resourceVcdNsxvFirewallRuleRead(d *schema.ResourceData, meta interface{}) e
 
 	}
 
-	readFirewallRule, err := edgeGateway.GetNsxvFirewallRuleById(id)
+	edgeGateway, err := vcdClient.GetEdgeGatewayFromResource(d, "edge_gateway")
+	if !isDatasource && govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find edge gateway %s. Removing firewall rule from tfstate", d.Get("edge_gateway").(string))
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf(errorUnableToFindEdgeGateway, err)
+	}
+
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
+		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+
+	readFirewallRule, err := edgeGateway.GetNsxvFirewallRuleById(id)
+	if !isDatasource && govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find firewall rule with ID %s. Removing from tfstate", id)
 		d.SetId("")
-		return fmt.Errorf("unable to find firewall rule with ID %s: %s", d.Id(), err)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to find firewall rule with ID %s: %s", id, err)
 	}
 
 	err = setFirewallRuleData(d, readFirewallRule, edgeGateway, vdc)
@@ -388,13 +397,18 @@ func resourceVcdNsxvFirewallRuleDelete(d *schema.ResourceData, meta interface{})
 //
 // Example resource name (_resource_name_): vcd_nsxv_firewall_rule.my-test-fw-rule
 // Example import path (_the_id_string_): org.vdc.edge-gw.132730
+// Example import path (_the_id_string_): urn:vcloud:gateway:4bc6a5cf-d9b6-4ba6-8aef-2c4d5c3ef1a8.132730
 // Example import by UI ID path (_the_id_string_): org.vdc.edge-gw.ui-no.2
 // Example list path (_the_id_string_): list@org.vdc.edge-gw
 func resourceVcdNsxvFirewallRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, edgeName, firewallRuleId, uiId string
 	var listRules, importRule bool
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	helpError := fmt.Errorf(`resource id must be specified in one of these formats:
 'org-name.vdc-name.edge-gw-name.real-firewall-rule-id' to import by rule id
 'org-name.vdc-name.edge-gw-name.ui-no.X' where X is the firewall rule number shown in UI
@@ -424,7 +438,6 @@ func resourceVcdNsxvFirewallRuleImport(d *schema.ResourceData, meta interface{})
 		return nil, helpError
 	}
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
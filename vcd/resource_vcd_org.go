@@ -457,14 +457,25 @@ func resourceOrgRead(d *schema.ResourceData, m interface{}) error {
 // The d.ID() field as being passed from `terraform import _resource_name_ _the_id_string_ requires
 // a name based dot-formatted path to the object to lookup the object and sets the id of object.
 // `terraform import` automatically performs `refresh` operation which loads up all other fields.
-// For this resource, the import path is just the org name.
+// For this resource, the import path is just the org name or the org URN.
 //
 // Example import path (id): orgName
+// Example import path (id): urn:vcloud:org:1e7eb8ed-9b48-43f4-a8c5-6e36c6ef0ee8
 func resourceVcdOrgImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	orgName := d.Id()
+	orgIdentifier := d.Id()
+
+	if isImportUrn(orgIdentifier) {
+		urnType, err := getUrnEntityType(orgIdentifier)
+		if err != nil {
+			return nil, err
+		}
+		if urnType != "org" {
+			return nil, fmt.Errorf("URN '%s' does not identify an Org", orgIdentifier)
+		}
+	}
 
 	vcdClient := meta.(*VCDClient)
-	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
+	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgIdentifier)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, err)
 	}
@@ -3,7 +3,6 @@ package vcd
 import (
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -174,25 +173,30 @@ func resourceVcdOrgGroupDelete(d *schema.ResourceData, meta interface{}) error {
 // Expects the d.ID() to be a path to the resource made of Org name + dot + OrgGroup name
 //
 // Example import path (id): my-org.my-group
+// Example import path (id): urn:vcloud:group:6e1c9ee2-1b4f-4b1b-9ac0-5b9c2c4e8a61
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdOrgGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.org_group")
 	}
 	orgName, groupName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
 	}
 
-	group, err := adminOrg.GetGroupByName(groupName, false)
+	group, err := adminOrg.GetGroupByNameOrId(groupName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[group import] error retrieving group %s: %s", groupName, err)
 	}
 
+	_ = d.Set("org", orgName)
 	d.SetId(group.Group.ID)
 	return []*schema.ResourceData{d}, nil
 }
@@ -3,6 +3,7 @@ package vcd
 import (
 	"fmt"
 	"io/ioutil"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -275,6 +276,11 @@ func resourceVcdOrgUserDelete(d *schema.ResourceData, meta interface{}) error {
 func resourceVcdOrgUserRead(d *schema.ResourceData, meta interface{}) error {
 
 	orgUser, adminOrg, err := resourceToOrgUser(d, meta)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find user %s. Removing from tfstate", d.Get("name").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("[user read] error filling data %s", err)
 	}
@@ -304,21 +310,25 @@ func resourceVcdOrgUserUpdate(d *schema.ResourceData, meta interface{}) error {
 // Expects the d.ID() to be a path to the resource made of Org name + dot + OrgUser name
 //
 // Example import path (id): my-org.my-user-admin
+// Example import path (id): urn:vcloud:user:6e1c9ee2-1b4f-4b1b-9ac0-5b9c2c4e8a61
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdOrgUserImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.org_user")
 	}
 	orgName, userName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
 	}
 
-	user, err := adminOrg.GetUserByName(userName, false)
+	user, err := adminOrg.GetUserByNameOrId(userName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[user import] error retrieving user %s: %s", userName, err)
 	}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -247,6 +246,15 @@ func resourceVcdOrgVdc() *schema.Resource {
 				Computed:    true,
 				Description: "ID of default VM sizing policy ID",
 			},
+			"vm_placement_policy_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "Set of VM placement policy IDs",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -311,6 +319,11 @@ func resourceVcdVdcCreate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error assigning VM sizing policies to VDC: %s", err)
 	}
 
+	err = updateAssignedVmPlacementPolicies(vcdClient, d, meta)
+	if err != nil {
+		return fmt.Errorf("error assigning VM placement policies to VDC: %s", err)
+	}
+
 	return resourceVcdVdcRead(d, meta)
 }
 
@@ -321,6 +334,9 @@ func isSizingPolicyAllowed(d *schema.ResourceData, vcdClient *VCDClient) error {
 		if okSizingPolicy || okDefaultPolicy {
 			return fmt.Errorf("'vm_sizing_policy_ids' and `default_vm_sizing_policy_id` only available for VCD 10.0+")
 		}
+		if _, okPlacementPolicy := d.GetOk("vm_placement_policy_ids"); okPlacementPolicy {
+			return fmt.Errorf("'vm_placement_policy_ids' only available for VCD 10.0+")
+		}
 	}
 	return nil
 }
@@ -431,8 +447,14 @@ func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd
 			log.Printf("[DEBUG] Unable to get assigned VM sizing policies")
 			return fmt.Errorf("unable to get assigned VM sizing policies %s", err)
 		}
+		// Sizing and placement policies are assigned together, and are told apart by their VM groups
 		var policyIds []string
+		var placementPolicyIds []string
 		for _, policy := range assignedVmSizingPolicies {
+			if isVmPlacementPolicy(policy.VdcComputePolicy) {
+				placementPolicyIds = append(placementPolicyIds, policy.VdcComputePolicy.ID)
+				continue
+			}
 			policyIds = append(policyIds, policy.VdcComputePolicy.ID)
 		}
 		vmSizingPoliciesSlice := convertToTypeSet(policyIds)
@@ -445,6 +467,11 @@ func setOrgVdcData(d *schema.ResourceData, vcdClient *VCDClient, adminOrg *govcd
 			return err
 		}
 
+		err = d.Set("vm_placement_policy_ids", convertToTypeSet(placementPolicyIds))
+		if err != nil {
+			return err
+		}
+
 	}
 
 	log.Printf("[TRACE] vdc read completed: %#v", adminVdc.AdminVdc)
@@ -571,6 +598,11 @@ func resourceVcdVdcUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error assigning VM sizing policies to VDC: %s", err)
 	}
 
+	err = updateAssignedVmPlacementPolicies(vcdClient, d, meta)
+	if err != nil {
+		return fmt.Errorf("error assigning VM placement policies to VDC: %s", err)
+	}
+
 	if d.HasChange("storage_profile") {
 		vdcStorageProfilesConfigurations := d.Get("storage_profile").(*schema.Set)
 		for _, storageConfigurationValues := range vdcStorageProfilesConfigurations.List() {
@@ -700,6 +732,8 @@ func updateAssignedVmSizingPolicies(vcdClient *VCDClient, d *schema.ResourceData
 			for _, policyId := range vmSizingPolicyIdStrings {
 				vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, &types.Reference{HREF: vcdComputePolicyHref.String() + policyId})
 			}
+			// The assigned policies are replaced, so the placement policies must be kept
+			vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, getVmPlacementPolicyReferences(d, vcdComputePolicyHref.String())...)
 			policyReferences.VdcComputePolicyReference = vdcComputePolicyReferenceList
 
 			_, err = vdc.SetAssignedComputePolicies(policyReferences)
@@ -774,6 +808,7 @@ func changeVmSizingPoliciesAndDefaultId(d *schema.ResourceData, vcdComputePolicy
 		for _, policyId := range vmSizingPolicyIdStrings {
 			vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, &types.Reference{HREF: vcdComputePolicyHref + policyId})
 		}
+		vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, getVmPlacementPolicyReferences(d, vcdComputePolicyHref)...)
 		policyReferences.VdcComputePolicyReference = vdcComputePolicyReferenceList
 
 		_, err = updatedVdc.SetAssignedComputePolicies(policyReferences)
@@ -823,6 +858,57 @@ func addAssignedVmSizingPolicies(vcdClient *VCDClient, d *schema.ResourceData, m
 	return nil
 }
 
+// getVmPlacementPolicyReferences returns the references of the VM placement policies set in "vm_placement_policy_ids"
+func getVmPlacementPolicyReferences(d *schema.ResourceData, vcdComputePolicyHref string) []*types.Reference {
+	var references []*types.Reference
+	for _, policyId := range convertSchemaSetToSliceOfStrings(d.Get("vm_placement_policy_ids").(*schema.Set)) {
+		references = append(references, &types.Reference{HREF: vcdComputePolicyHref + policyId})
+	}
+	return references
+}
+
+// updateAssignedVmPlacementPolicies handles VM placement policies. They are assigned to the VDC together with the
+// VM sizing policies, which are kept as they are.
+func updateAssignedVmPlacementPolicies(vcdClient *VCDClient, d *schema.ResourceData, meta interface{}) error {
+	if vcdClient.Client.APIVCDMaxVersionIs("< 33.0") || !d.HasChange("vm_placement_policy_ids") {
+		return nil
+	}
+	log.Printf("[TRACE] updating assigned VM placement policies to VDC")
+
+	vcdComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies)
+	if err != nil {
+		return fmt.Errorf("error constructing HREF for compute policy")
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	vdc, err := adminOrg.GetAdminVDCByName(d.Get("name").(string), false)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingVdcFromOrg, d.Get("org").(string), d.Get("name").(string), err)
+	}
+
+	existingPolicies, err := vdc.GetAllAssignedVdcComputePolicies(nil)
+	if err != nil {
+		return fmt.Errorf("error getting assigned VM policies. %s", err)
+	}
+	var vdcComputePolicyReferenceList []*types.Reference
+	for _, existingPolicy := range existingPolicies {
+		if !isVmPlacementPolicy(existingPolicy.VdcComputePolicy) {
+			vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, &types.Reference{HREF: vcdComputePolicyHref.String() + existingPolicy.VdcComputePolicy.ID})
+		}
+	}
+	vdcComputePolicyReferenceList = append(vdcComputePolicyReferenceList, getVmPlacementPolicyReferences(d, vcdComputePolicyHref.String())...)
+
+	_, err = vdc.SetAssignedComputePolicies(types.VdcComputePolicyReferences{VdcComputePolicyReference: vdcComputePolicyReferenceList})
+	if err != nil {
+		return fmt.Errorf("error setting VM placement policies. %s", err)
+	}
+	return nil
+}
+
 func createOrUpdateMetadata(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[TRACE] adding/updating metadata to VDC")
@@ -1180,29 +1266,32 @@ func getStorageProfileHREF(vcdClient *VCDClient, name string) (string, error) {
 //
 // Example resource name (_resource_name_): vcd_org_vdc.my_existing_vdc
 // Example import path (_the_id_string_): org.my_existing_vdc
+// Example import path (_the_id_string_): urn:vcloud:vdc:8b4a1a5c-e5b5-4ec4-86c6-4a8b0e2fd0d3
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdOrgVdcImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
 		return nil, fmt.Errorf("resource name must be specified as org.my_existing_vdc")
 	}
 	orgName, vdcName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
-
 	adminOrg, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, err)
 	}
 
-	adminVdc, err := adminOrg.GetAdminVDCByName(vdcName, false)
+	adminVdc, err := adminOrg.GetAdminVDCByNameOrId(vdcName, false)
 	if err != nil {
 		log.Printf("[DEBUG] Unable to find VDC %s", vdcName)
 		return nil, fmt.Errorf("unable to find VDC %s, err: %s", vdcName, err)
 	}
 
 	_ = d.Set("org", orgName)
-	_ = d.Set("name", vdcName)
+	_ = d.Set("name", adminVdc.AdminVdc.Name)
 
 	d.SetId(adminVdc.AdminVdc.ID)
 
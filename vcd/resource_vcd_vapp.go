@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"regexp"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/lmicke/go-vcloud-director/v2/govcd"
@@ -47,6 +46,12 @@ func resourceVcdVApp() *schema.Resource {
 				Optional:    true,
 				Description: "Optional description of the vApp",
 			},
+			"source_vapp_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The ID of an existing vApp to copy, with its VMs and networks. It can be in another VDC of the Org",
+			},
 			"metadata": {
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -70,6 +75,8 @@ func resourceVcdVApp() *schema.Resource {
 				Optional:    true,
 				Description: "Key/value settings for guest properties. Will be picked up by new VMs when created.",
 			},
+			"cloud_init": cloudInitSchema("Cloud-init user data, metadata and network configuration, " +
+				"stored in vApp guest properties. Will be picked up by new VMs when created."),
 			"status": {
 				Type:        schema.TypeInt,
 				Computed:    true,
@@ -95,23 +102,38 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 	vcdClient.lockVapp(d)
 	defer vcdClient.unLockVapp(d)
 
-	e := vdc.ComposeRawVApp(d.Get("name").(string))
-
-	if e != nil {
-		return fmt.Errorf("error: %#v", e)
+	_, err = getCloudInitProperties(d)
+	if err != nil {
+		return err
 	}
 
-	e = vdc.Refresh()
-	if e != nil {
-		return fmt.Errorf("error: %#v", e)
-	}
+	var vapp *govcd.VApp
+	if _, ok := d.GetOk("source_vapp_id"); ok {
+		vapp, err = cloneVApp(d, vcdClient, vdc)
+		if err != nil {
+			return err
+		}
+	} else {
+		e := vdc.ComposeRawVApp(d.Get("name").(string))
 
-	vapp, err := vdc.GetVAppByName(vappName, true)
-	if err != nil {
-		return fmt.Errorf("unable to find vApp by name %s: %s", vappName, err)
+		if e != nil {
+			return fmt.Errorf("error: %#v", e)
+		}
+
+		e = vdc.Refresh()
+		if e != nil {
+			return fmt.Errorf("error: %#v", e)
+		}
+
+		vapp, err = vdc.GetVAppByName(vappName, true)
+		if err != nil {
+			return fmt.Errorf("unable to find vApp by name %s: %s", vappName, err)
+		}
 	}
 
-	if _, ok := d.GetOk("guest_properties"); ok {
+	_, hasGuestProperties := d.GetOk("guest_properties")
+	_, hasCloudInit := d.GetOk("cloud_init")
+	if hasGuestProperties || hasCloudInit {
 
 		// Even though vApp has a task and waits for its completion it happens that it is not ready
 		// for operation just after provisioning therefore we wait for it to exit UNRESOLVED state
@@ -122,7 +144,7 @@ func resourceVcdVAppCreate(d *schema.ResourceData, meta interface{}) error {
 
 		guestProperties, err := getGuestProperties(d)
 		if err != nil {
-			return fmt.Errorf("unable to convert guest properties to data structure")
+			return fmt.Errorf("unable to convert guest properties to data structure: %s", err)
 		}
 
 		log.Printf("[TRACE] Setting vApp guest properties")
@@ -151,10 +173,10 @@ func resourceVcdVAppUpdate(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("error finding VApp: %#v", err)
 	}
 
-	if d.HasChange("guest_properties") {
+	if d.HasChanges("guest_properties", "cloud_init") {
 		vappProperties, err := getGuestProperties(d)
 		if err != nil {
-			return fmt.Errorf("unable to convert guest properties to data structure")
+			return fmt.Errorf("unable to convert guest properties to data structure: %s", err)
 		}
 
 		log.Printf("[TRACE] Updating vApp guest properties")
@@ -352,24 +374,28 @@ func tryUndeploy(vapp govcd.VApp) error {
 //
 // Example resource name (_resource_name_): vcd_vapp.vapp_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name
+// Example import path (_the_id_string_): urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e
 func resourceVcdVappImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[vapp import] resource name must be specified as org-name.vdc-name.vapp-name")
 	}
 	orgName, vdcName, vappName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[vapp import] unable to find VDC %s: %s ", vdcName, err)
 	}
 
-	vapp, err := vdc.GetVAppByName(vappName, false)
+	vapp, err := vdc.GetVAppByNameOrId(vappName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[vapp import] error retrieving vapp %s: %s", vappName, err)
 	}
-	_ = d.Set("name", vappName)
+	_ = d.Set("name", vapp.VApp.Name)
 	_ = d.Set("org", orgName)
 	_ = d.Set("vdc", vdcName)
 	d.SetId(vapp.VApp.ID)
@@ -2,6 +2,7 @@ package vcd
 
 import (
 	"fmt"
+	"log"
 	"os"
 	"strings"
 
@@ -168,6 +169,11 @@ func resourceAccessControlVappRead(d *schema.ResourceData, meta interface{}) err
 
 	vappId := d.Get("vapp_id").(string)
 	vapp, err := vdc.GetVAppByNameOrId(vappId, false)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp %s. Removing access control from tfstate", vappId)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("[resourceAccessControlVappRead] error retrieving vApp %s. %s", vappId, err)
 	}
@@ -218,7 +224,11 @@ func resourceAccessControlVappDelete(d *schema.ResourceData, meta interface{}) e
 }
 
 func accessControlVappImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[vApp access control import] resource identifier must be specified as org.vdc.my-vapp")
 	}
@@ -242,7 +252,6 @@ func accessControlVappImport(d *schema.ResourceData, meta interface{}) ([]*schem
 		return nil, fmt.Errorf("[vApp access control import] empty vApp access control identifier provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
@@ -227,11 +227,21 @@ func resourceVappFirewallRulesDelete(d *schema.ResourceData, meta interface{}) e
 func resourceVappFirewallRulesRead(d *schema.ResourceData, meta interface{}) error {
 	vcdClient := meta.(*VCDClient)
 	vapp, err := getVapp(vcdClient, d, meta)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp %s. Removing firewall rules from tfstate", d.Get("vapp_id").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
 
 	vappNetwork, err := vapp.GetVappNetworkById(d.Get("network_id").(string), false)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp network %s. Removing firewall rules from tfstate", d.Get("network_id").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding vApp network. %s", err)
 	}
@@ -350,7 +360,8 @@ func expandVappFirewallRules(d *schema.ResourceData, vapp *govcd.VApp) ([]*types
 }
 
 var errHelpVappNetworkRulesImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'org-name.vdc-name.vapp-name.network_name', 'org.vdc-name.vapp-id.network-id' or 
+'org-name.vdc-name.vapp-name.network_name', 'org.vdc-name.vapp-id.network-id',
+'vapp-urn.network_name' or 
 'list@org-name.vdc-name.vapp-name' to get a list of vapp networks with their IDs`)
 
 // vappFirewallRulesImport is responsible for importing the resource.
@@ -365,6 +376,7 @@ var errHelpVappNetworkRulesImport = fmt.Errorf(`resource id must be specified in
 //
 // Example resource name (_resource_name_): vcd_vapp_firewall_rules.my_existing_firewall_rules
 // Example import path (_the_id_string_): org.my_existing_vdc.vapp_name.network_name or org.my_existing_vdc.vapp_id.network_id
+// Example import path (_the_id_string_): urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e.network_name
 // Example list path (_the_id_string_): list@org-name.vdc-name.vapp-name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func vappFirewallRulesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
@@ -372,7 +384,11 @@ func vappFirewallRulesImport(d *schema.ResourceData, meta interface{}) ([]*schem
 }
 func vappNetworkRuleImport(d *schema.ResourceData, meta interface{}, resourceType string) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, vappName string
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Printf("[DEBUG] importing %s resource with provided id %s", resourceType, d.Id())
 
@@ -204,17 +204,32 @@ func resourceVappNetworkNatRulesRead(d *schema.ResourceData, meta interface{}) e
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find VDC. Removing NAT rules from tfstate")
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
 	vappId := d.Get("vapp_id").(string)
 	vapp, err := vdc.GetVAppById(vappId, false)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp %s. Removing NAT rules from tfstate", vappId)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding vApp. %s", err)
 	}
 
 	vappNetwork, err := vapp.GetVappNetworkById(d.Get("network_id").(string), false)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp network %s. Removing NAT rules from tfstate", d.Get("network_id").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding vApp network. %s", err)
 	}
@@ -322,6 +337,7 @@ func expandVappNetworkNatRules(d *schema.ResourceData, vapp *govcd.VApp, natType
 //
 // Example resource name (_resource_name_): vcd_vapp_nat_rules.my_existing_nat_rules
 // Example import path (_the_id_string_): org.my_existing_vdc.vapp_name.network_name or org.my_existing_vdc.vapp_id.network_id
+// Example import path (_the_id_string_): urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e.network_name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func vappNetworkNatRulesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return vappNetworkRuleImport(d, meta, "vcd_vapp_nat_rules")
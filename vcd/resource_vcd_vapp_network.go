@@ -258,6 +258,11 @@ func genericVappNetworkRead(d *schema.ResourceData, meta interface{}, origin str
 	}
 
 	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+	if govcd.ContainsNotFound(err) && origin == "resource" {
+		log.Printf("[DEBUG] Unable to find vApp %s. Removing network from tfstate", d.Get("vapp_name").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding Vapp: %s", err)
 	}
@@ -446,20 +451,24 @@ func resourceVappNetworkDelete(d *schema.ResourceData, meta interface{}) error {
 //
 // Example resource name (_resource_name_): vcd_vapp_network.network_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.network-name
+// Example import path (_the_id_string_): urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e.network-name
 func resourceVcdVappNetworkImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("[vApp network import] resource name must be specified as org-name.vdc-name.vapp-name.network-name")
 	}
 	orgName, vdcName, vappName, networkName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[vApp network import] unable to find VDC %s: %s ", vdcName, err)
 	}
 
-	vapp, err := vdc.GetVAppByName(vappName, false)
+	vapp, err := vdc.GetVAppByNameOrId(vappName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[vApp network import] error retrieving vapp %s: %s", vappName, err)
 	}
@@ -470,7 +479,8 @@ func resourceVcdVappNetworkImport(d *schema.ResourceData, meta interface{}) ([]*
 
 	vappNetworkToImport := types.VAppNetworkConfiguration{}
 	for _, networkConfig := range vAppNetworkConfig.NetworkConfig {
-		if networkConfig.NetworkName == networkName {
+		if networkConfig.NetworkName == networkName ||
+			(networkConfig.Link != nil && haveSameUuid(networkConfig.Link.HREF, networkName)) {
 			vappNetworkToImport = networkConfig
 			break
 		}
@@ -497,8 +507,8 @@ func resourceVcdVappNetworkImport(d *schema.ResourceData, meta interface{}) ([]*
 	if vcdClient.Vdc != vdcName {
 		_ = d.Set("vdc", vdcName)
 	}
-	_ = d.Set("name", networkName)
-	_ = d.Set("vapp_name", vappName)
+	_ = d.Set("name", vappNetworkToImport.NetworkName)
+	_ = d.Set("vapp_name", vapp.VApp.Name)
 
 	return []*schema.ResourceData{d}, nil
 }
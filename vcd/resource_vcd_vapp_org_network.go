@@ -6,7 +6,6 @@ import (
 	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 	"log"
-	"strings"
 )
 
 func resourceVcdVappOrgNetwork() *schema.Resource {
@@ -125,6 +124,11 @@ func genericVappOrgNetworkRead(d *schema.ResourceData, meta interface{}, origin
 	}
 
 	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+	if govcd.ContainsNotFound(err) && origin == "resource" {
+		log.Printf("[DEBUG] Unable to find vApp %s. Removing network from tfstate", d.Get("vapp_name").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding Vapp: %s", err)
 	}
@@ -239,20 +243,24 @@ func resourceVappOrgNetworkDelete(d *schema.ResourceData, meta interface{}) erro
 //
 // Example resource name (_resource_name_): vcd_vapp_org_network.org_network_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.org-network-name
+// Example import path (_the_id_string_): urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e.org-network-name
 func resourceVcdVappOrgNetworkImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("[vApp org network import] resource name must be specified as org-name.vdc-name.vapp-name.org-network-name")
 	}
 	orgName, vdcName, vappName, networkName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[vApp org network import] unable to find VDC %s: %s ", vdcName, err)
 	}
 
-	vapp, err := vdc.GetVAppByName(vappName, false)
+	vapp, err := vdc.GetVAppByNameOrId(vappName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[vApp org network import] error retrieving vapp %s: %s", vappName, err)
 	}
@@ -263,7 +271,8 @@ func resourceVcdVappOrgNetworkImport(d *schema.ResourceData, meta interface{}) (
 
 	vappNetworkToImport := types.VAppNetworkConfiguration{}
 	for _, networkConfig := range vAppNetworkConfig.NetworkConfig {
-		if networkConfig.NetworkName == networkName {
+		if networkConfig.NetworkName == networkName ||
+			(networkConfig.Link != nil && haveSameUuid(networkConfig.Link.HREF, networkName)) {
 			vappNetworkToImport = networkConfig
 			break
 		}
@@ -290,8 +299,8 @@ func resourceVcdVappOrgNetworkImport(d *schema.ResourceData, meta interface{}) (
 	if vcdClient.Vdc != vdcName {
 		_ = d.Set("vdc", vdcName)
 	}
-	_ = d.Set("org_network_name", networkName)
-	_ = d.Set("vapp_name", vappName)
+	_ = d.Set("org_network_name", vappNetworkToImport.NetworkName)
+	_ = d.Set("vapp_name", vapp.VApp.Name)
 
 	return []*schema.ResourceData{d}, nil
 }
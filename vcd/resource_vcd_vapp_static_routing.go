@@ -3,6 +3,7 @@ package vcd
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
 	"github.com/lmicke/go-vcloud-director/v2/types/v56"
 	"log"
 )
@@ -142,17 +143,32 @@ func resourceVappNetworkStaticRoutingRead(d *schema.ResourceData, meta interface
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find VDC. Removing static routing rules from tfstate")
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
 	vappId := d.Get("vapp_id").(string)
 	vapp, err := vdc.GetVAppById(vappId, false)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp %s. Removing static routing rules from tfstate", vappId)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding vApp. %s", err)
 	}
 
 	vappNetwork, err := vapp.GetVappNetworkById(d.Get("network_id").(string), false)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find vApp network %s. Removing static routing rules from tfstate", d.Get("network_id").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf("error finding vApp network. %s", err)
 	}
@@ -206,6 +222,7 @@ func expandVappNetworkStaticRouting(d *schema.ResourceData) ([]*types.StaticRout
 //
 // Example resource name (_resource_name_): vcd_vapp_static_routing.my_existing_static_routing_rules
 // Example import path (_the_id_string_): org.my_existing_vdc.vapp_name.network_name or org.my_existing_vdc.vapp_id.network_id
+// Example import path (_the_id_string_): urn:vcloud:vapp:0a8b6b2e-4b0d-4aa3-8b3e-91e3fc4c6b8e.network_name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func vappNetworkStaticRoutingImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return vappNetworkRuleImport(d, meta, "vcd_vapp_static_routing")
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -22,8 +24,7 @@ var vappVmSchema = map[string]*schema.Schema{
 	"vapp_name": &schema.Schema{
 		Type:        schema.TypeString,
 		Required:    true,
-		ForceNew:    true,
-		Description: "The vApp this VM belongs to",
+		Description: "The vApp this VM belongs to. Changing it moves the VM to another vApp",
 	},
 	"name": &schema.Schema{
 		Type:        schema.TypeString,
@@ -47,8 +48,7 @@ var vappVmSchema = map[string]*schema.Schema{
 	"vdc": {
 		Type:        schema.TypeString,
 		Optional:    true,
-		ForceNew:    true,
-		Description: "The name of VDC to use, optional if defined at provider level",
+		Description: "The name of VDC to use, optional if defined at provider level. Changing it moves the VM to a vApp of another VDC",
 	},
 	"template_name": &schema.Schema{
 		Type:        schema.TypeString,
@@ -62,6 +62,13 @@ var vappVmSchema = map[string]*schema.Schema{
 		ForceNew:    true,
 		Description: "The name of the VM in vApp Template to use. In cases when vApp template has more than one VM",
 	},
+	"source_vm_id": &schema.Schema{
+		Type:          schema.TypeString,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"template_name", "vm_name_in_template"},
+		Description:   "The ID of an existing VM to copy, instead of using a vApp Template. It can be in another VDC of the Org",
+	},
 	"catalog_name": &schema.Schema{
 		Type:        schema.TypeString,
 		Optional:    true,
@@ -140,6 +147,45 @@ var vappVmSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Media name to add as boot image.",
 	},
+	"firmware": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice([]string{vmFirmwareBios, vmFirmwareEfi}, false),
+		Description:  "Boot firmware of the VM. One of 'bios', 'efi'. Requires VCD 10.3+",
+	},
+	"secure_boot": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Enables EFI secure boot. Requires 'efi' firmware, hardware version vmx-13 or later and VCD 10.3+",
+	},
+	"boot_delay": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Milliseconds to wait before the VM starts booting. Requires VCD 10.3+",
+	},
+	"enter_bios_setup_on_next_boot": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+		Description: "Enter the BIOS or EFI setup on the next boot of the VM. VCD resets it after the boot. Requires VCD 10.3+",
+	},
+	"boot_retry_enabled": {
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Computed:    true,
+		Description: "Retry the boot when the VM fails to find a boot device. Requires VCD 10.3+",
+	},
+	"boot_retry_delay": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Milliseconds to wait before retrying the boot. Requires 'boot_retry_enabled' and VCD 10.3+",
+	},
 	"network_dhcp_wait_seconds": {
 		Optional:     true,
 		Type:         schema.TypeInt,
@@ -207,6 +253,14 @@ var vappVmSchema = map[string]*schema.Schema{
 					Default:     true,
 					Description: "It defines if NIC is connected or not.",
 				},
+				"nic_index": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Computed:     true,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description: "Network connection index of the NIC in VCD. A NIC is identified by this index, so that " +
+						"adding or removing a NIC doesn't change the others. A new NIC gets the first free index when omitted",
+				},
 			},
 		},
 	},
@@ -240,48 +294,69 @@ var vappVmSchema = map[string]*schema.Schema{
 	"override_template_disk": {
 		Type:        schema.TypeSet,
 		Optional:    true,
-		ForceNew:    true,
 		Description: "A block to match internal_disk interface in template. Multiple can be used. Disk will be matched by bus_type, bus_number and unit_number.",
 		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
 			"bus_type": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"ide", "parallel", "sas", "paravirtual", "sata"}, false),
 				Description:  "The type of disk controller. Possible values: ide, parallel( LSI Logic Parallel SCSI), sas(LSI Logic SAS (SCSI)), paravirtual(Paravirtual (SCSI)), sata",
 			},
 			"size_in_mb": {
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 				Description: "The size of the disk in MB.",
 			},
 			"bus_number": {
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 				Description: "The number of the SCSI or IDE controller itself.",
 			},
 			"unit_number": {
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Required:    true,
 				Description: "The device number on the SCSI or IDE controller of the disk.",
 			},
 			"iops": {
 				Type:        schema.TypeInt,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "Specifies the IOPS for the disk. Default is 0.",
 			},
 			"storage_profile": &schema.Schema{
 				Type:        schema.TypeString,
-				ForceNew:    true,
 				Optional:    true,
 				Description: "Storage profile to override the VM default one",
 			},
 		}},
 	},
+	"disk_controller": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Computed: true,
+		Description: "A block to define a disk controller of the VM. The disks of 'override_template_disk' must be placed " +
+			"on the declared controllers. When omitted, the controllers of the VM are reported",
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"bus_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"ide", "parallel", "sas", "paravirtual", "sata"}, false),
+				Description:  "The type of disk controller. Possible values: ide, parallel( LSI Logic Parallel SCSI), sas(LSI Logic SAS (SCSI)), paravirtual(Paravirtual (SCSI)), sata",
+			},
+			"bus_number": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				ValidateFunc: validation.IntBetween(0, 3),
+				Description:  "The number of the controller. SCSI controllers share the same bus numbers",
+			},
+			"sharing": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      vmDiskControllerSharingNone,
+				ValidateFunc: validation.StringInSlice([]string{vmDiskControllerSharingNone, vmDiskControllerSharingVirtual, vmDiskControllerSharingPhysical}, false),
+				Description:  "Bus sharing of a SCSI controller: 'none', 'virtual' or 'physical'",
+			},
+		}},
+	},
 	"internal_disk": {
 		Type:        schema.TypeList,
 		Computed:    true,
@@ -340,6 +415,8 @@ var vappVmSchema = map[string]*schema.Schema{
 		Optional:    true,
 		Description: "Key/value settings for guest properties",
 	},
+	"cloud_init": cloudInitSchema("Cloud-init user data, metadata and network configuration, " +
+		"stored in guest properties"),
 	"customization": &schema.Schema{
 		Optional:    true,
 		Computed:    true,
@@ -448,9 +525,47 @@ var vappVmSchema = map[string]*schema.Schema{
 					Computed:    true,
 					Description: "Script to run on initial boot or with customization.force=true set",
 				},
+				"initscript_file": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					ConflictsWith: []string{"customization.0.initscript"},
+					Description:   "Name of a local file with the script to run on initial boot or with customization.force=true set",
+				},
+				"sysprep_answer_file": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Description: "Content of a Windows sysprep answer file, delivered to the guest in the OVF environment " +
+						"as guest property '" + vmSysprepAnswerFileKey + "'",
+				},
+				"linux_hostname": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(vmLinuxHostnameRegexp, "must be a valid host name"),
+					Description:  "Host name of a Linux guest. Defaults to the computer name",
+				},
+				"linux_domain": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(vmLinuxDomainRegexp, "must be a valid DNS domain"),
+					Description:  "DNS domain of a Linux guest, appended to the host name",
+				},
+				"linux_timezone": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validation.StringMatch(vmLinuxTimezoneRegexp, "must be a time zone name, such as Europe/Rome"),
+					Description:  "Time zone of a Linux guest, such as Europe/Rome",
+				},
 			},
 		},
 	},
+	"generated_admin_password": {
+		Type:      schema.TypeString,
+		Computed:  true,
+		Sensitive: true,
+		Description: "Administrator password generated by VCD when 'customization.0.auto_generate_password' is true. " +
+			"Available once the guest customization is done",
+	},
 	"cpu_hot_add_enabled": {
 		Type:        schema.TypeBool,
 		Optional:    true,
@@ -469,12 +584,139 @@ var vappVmSchema = map[string]*schema.Schema{
 		Default:     false,
 		Description: "True if the update of resource should fail when virtual machine power off needed.",
 	},
+	"readiness_check": {
+		Type:     schema.TypeList,
+		Optional: true,
+		Description: "Conditions that the VM must reach before its creation is complete. " +
+			"They are evaluated in order, after the VM is powered on",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+					ValidateFunc: validation.StringInSlice([]string{vmReadinessCustomizationDone,
+						vmReadinessToolsRunning, vmReadinessGuestProperty, vmReadinessNicIps}, false),
+					Description: "The condition to wait for. One of 'customization_done', 'tools_running', " +
+						"'guest_property', 'nic_ips'",
+				},
+				"timeout": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      600,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Seconds to wait for the condition before failing the creation",
+				},
+				"guest_property_key": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Guest property to check. Required by the 'guest_property' type",
+				},
+				"guest_property_value": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Value that the guest property must reach. When empty, the property only " +
+						"needs to have a value",
+				},
+			},
+		},
+	},
+	"power_cycle_required": {
+		Type:     schema.TypeBool,
+		Computed: true,
+		Description: "True when the last plan with changes expected the update to power off the running VM. " +
+			"The plan fails instead when 'prevent_update_power_off' is true",
+	},
+	"power_state": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: validation.StringInSlice([]string{vmPowerStateOn, vmPowerStateOff, vmPowerStateSuspended}, false),
+		Description: "Desired power state of the VM, enforced on every apply. One of 'on', 'off', 'suspended'. " +
+			"Takes precedence over 'power_on'",
+	},
+	"guest_shutdown_timeout": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Default:      0,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description: "Seconds to wait for the guest OS to shut down through VMware Tools when the VM needs to be " +
+			"powered off. When the timeout expires, the VM is powered off. 0 powers off the VM without shutting down the guest",
+	},
 	"sizing_policy_id": {
 		Type:        schema.TypeString,
 		Optional:    true,
 		Computed:    true,
 		Description: "VM sizing policy ID. Has to be assigned to Org VDC.",
 	},
+	"placement_policy_id": {
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		Description: "VM placement policy ID. Has to be assigned to Org VDC.",
+	},
+	"cpu_reservation": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "CPU reservation of the VM in MHz",
+	},
+	"cpu_limit": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(-1),
+		Description:  "CPU limit of the VM in MHz. -1 means unlimited",
+	},
+	"cpu_shares": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Custom number of CPU shares of the VM",
+	},
+	"memory_reservation": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Memory reservation of the VM in MB",
+	},
+	"memory_limit": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(-1),
+		Description:  "Memory limit of the VM in MB. -1 means unlimited",
+	},
+	"memory_shares": {
+		Type:         schema.TypeInt,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.IntAtLeast(0),
+		Description:  "Custom number of memory shares of the VM",
+	},
+	"latency_sensitivity": {
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ValidateFunc: validation.StringInSlice([]string{vmLatencySensitivityNormal, vmLatencySensitivityHigh}, false),
+		Description:  "Latency sensitivity of the VM: 'normal' or 'high'. 'high' requires the whole memory to be reserved",
+	},
+	"extra_config": {
+		Type:         schema.TypeMap,
+		Optional:     true,
+		Elem:         &schema.Schema{Type: schema.TypeString},
+		ValidateFunc: validateExtraConfig,
+		Description: "Key/value map of VM advanced settings (VMX parameters). Only the keys set here are managed. " +
+			"Changes require the VM to be powered off",
+	},
+	"extra_config_ignore_changes": {
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+		Description: "Keys of 'extra_config' whose value is not refreshed from VCD, for settings that VCD adjusts " +
+			"after they are set. A key ending with '*' matches all the keys with that prefix",
+	},
 }
 
 func resourceVcdVAppVm() *schema.Resource {
@@ -487,16 +729,43 @@ func resourceVcdVAppVm() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceVcdVappVmImport,
 		},
-		Schema: vappVmSchema,
+		CustomizeDiff: resourceVcdVmCustomizeDiff(vappVmType),
+		Schema:        vappVmSchema,
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			stateUpgrader(0, resourceVcdVAppVmV0(), upgradeVmStateV0),
+		},
 	}
 }
 
+// typeOfVm distinguishes the VMs that belong to a vApp defined by the user (vcd_vapp_vm) from the standalone
+// ones (vcd_vm), which live in a vApp that VCD creates and manages for them
+type typeOfVm string
+
+const (
+	vappVmType       typeOfVm = "vcd_vapp_vm"
+	standaloneVmType typeOfVm = "vcd_vm"
+)
+
 func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] [VM create] started")
+	return genericResourceVmCreate(d, meta, vappVmType)
+}
+
+func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) error {
+	log.Printf("[DEBUG] [VM create] started with type %s", vmType)
 	vcdClient := meta.(*VCDClient)
 
-	vcdClient.lockParentVapp(d)
-	defer vcdClient.unLockParentVapp(d)
+	// A standalone VM is the only VM of its vApp, so there is no need to serialize operations
+	if vmType == vappVmType {
+		vcdClient.lockParentVapp(d)
+		defer vcdClient.unLockParentVapp(d)
+	}
+
+	// Check cloud-init data before creating anything, as the VM would be left behind if it is not valid
+	_, err := getCloudInitProperties(d)
+	if err != nil {
+		return err
+	}
 
 	org, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
@@ -505,52 +774,24 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 
 	catalogName := d.Get("catalog_name").(string)
 	templateName := d.Get("template_name").(string)
+	// Only a VM of vcd_vapp_vm can be copied from another VM
+	sourceVmId := ""
+	if vmType == vappVmType {
+		sourceVmId = d.Get("source_vm_id").(string)
+	}
 
-	//create not empty VM - use provided template
-	if catalogName != "" && templateName != "" {
-
-		catalog, err := org.GetCatalogByName(catalogName, false)
-		if err != nil {
-			return fmt.Errorf("error finding catalog %s: %s", catalogName, err)
-		}
+	//create not empty VM - use provided template or copy an existing VM
+	if (catalogName != "" && templateName != "") || sourceVmId != "" {
 
 		var vappTemplate govcd.VAppTemplate
-		if vmNameInTemplate, ok := d.GetOk("vm_name_in_template"); ok {
-			vmInTempateRecord, err := vdc.QueryVappVmTemplate(catalogName, templateName, vmNameInTemplate.(string))
-			if err != nil {
-				return fmt.Errorf("error quering VM template %s: %s", vmNameInTemplate, err)
-			}
-			returnedVappTemplate, err := catalog.GetVappTemplateByHref(vmInTempateRecord.HREF)
-			if err != nil {
-				return fmt.Errorf("error quering VM template %s: %s", vmNameInTemplate, err)
-			}
-			vappTemplate = *returnedVappTemplate
-		} else {
-			catalogItem, err := catalog.GetCatalogItemByName(templateName, false)
-			if err != nil {
-				return fmt.Errorf("error finding catalog item %s: %s", templateName, err)
-			}
-			vappTemplate, err = catalogItem.GetVAppTemplate()
+		if sourceVmId == "" {
+			vappTemplate, err = getVmTemplate(d, org, vdc)
 			if err != nil {
-				return fmt.Errorf("error finding VAppTemplate: %s", err)
+				return err
 			}
-
 		}
 		acceptEulas := d.Get("accept_all_eulas").(bool)
 
-		vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
-		if err != nil {
-			return fmt.Errorf("error finding vApp: %s", err)
-		}
-
-		networkConnectionSection := types.NetworkConnectionSection{}
-		if len(d.Get("network").([]interface{})) > 0 {
-			networkConnectionSection, err = networksToConfig(d, vdc, *vapp, vcdClient)
-			if err != nil {
-				return fmt.Errorf("unable to process network configuration: %s", err)
-			}
-		}
-
 		log.Printf("[TRACE] Creating VM: %s", d.Get("name").(string))
 		var storageProfile types.Reference
 		storageProfilePtr := &storageProfile
@@ -573,26 +814,64 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 			sizingPolicy = vdcComputePolicy.VdcComputePolicy
 		}
 
-		task, err := vapp.AddNewVMWithComputePolicy(d.Get("name").(string), vappTemplate, &networkConnectionSection, storageProfilePtr, sizingPolicy, acceptEulas)
-		if err != nil {
-			return fmt.Errorf("[VM creation] error adding VM: %s", err)
-		}
-		err = task.WaitTaskCompletion()
-		if err != nil {
-			return fmt.Errorf(errorCompletingTask, err)
-		}
+		var vm *govcd.VM
+		if vmType == standaloneVmType {
+			// The networks are set by the update below, once the vApp created by VCD is known
+			vm, err = createStandaloneVmFromTemplate(d, vcdClient, vdc, vappTemplate, storageProfilePtr, sizingPolicy, acceptEulas)
+			if err != nil {
+				d.SetId("")
+				return err
+			}
+		} else {
+			vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+			if err != nil {
+				return fmt.Errorf("error finding vApp: %s", err)
+			}
 
-		vmName := d.Get("name").(string)
-		vm, err := vapp.GetVMByName(vmName, true)
+			networkConnectionSection := types.NetworkConnectionSection{}
+			if len(d.Get("network").([]interface{})) > 0 {
+				networkConnectionSection, err = networksToConfig(d, vdc, *vapp, vcdClient)
+				if err != nil {
+					return fmt.Errorf("unable to process network configuration: %s", err)
+				}
+			}
 
-		if err != nil {
-			d.SetId("")
-			return fmt.Errorf("[VM creation] error getting VM %s : %s", vmName, err)
+			if sourceVmId != "" {
+				vm, err = cloneVmIntoVApp(d, vcdClient, vapp, &networkConnectionSection, storageProfilePtr, sizingPolicy)
+				if err != nil {
+					d.SetId("")
+					return err
+				}
+			} else {
+				task, err := vapp.AddNewVMWithComputePolicy(d.Get("name").(string), vappTemplate, &networkConnectionSection, storageProfilePtr, sizingPolicy, acceptEulas)
+				if err != nil {
+					return fmt.Errorf("[VM creation] error adding VM: %s", err)
+				}
+				err = task.WaitTaskCompletion()
+				if err != nil {
+					return fmt.Errorf(errorCompletingTask, err)
+				}
+
+				vmName := d.Get("name").(string)
+				vm, err = vapp.GetVMByName(vmName, true)
+
+				if err != nil {
+					d.SetId("")
+					return fmt.Errorf("[VM creation] error getting VM %s : %s", vmName, err)
+				}
+			}
 		}
 
 		// VM creation already succeeded so ID must be set
 		d.SetId(vm.VM.ID)
 
+		if value, ok := d.GetOk("placement_policy_id"); ok {
+			err = updateVmPlacementPolicy(vcdClient, vm, value.(string))
+			if err != nil {
+				return err
+			}
+		}
+
 		err = handleExposeHardwareVirtualization(d, vm)
 		if err != nil {
 			return err
@@ -607,6 +886,12 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 			return err
 		}
 
+		// The controllers change type before the template disks are matched by bus type
+		err = updateVmDiskControllers(d, vcdClient, vm)
+		if err != nil {
+			return err
+		}
+
 		// update existing internal disks in template
 		err = updateTemplateInternalDisks(d, meta, *vm)
 		if err != nil {
@@ -627,7 +912,7 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 
 		// TODO do not trigger resourceVcdVAppVmUpdate from create. These must be separate actions.
-		err = resourceVcdVAppVmUpdateExecute(d, meta, "create")
+		err = resourceVcdVAppVmUpdateExecute(d, meta, "create", vmType)
 		if err != nil {
 			errAttachedDisk := updateStateOfAttachedDisks(d, *vm, vdc)
 			if errAttachedDisk != nil {
@@ -636,23 +921,74 @@ func resourceVcdVAppVmCreate(d *schema.ResourceData, meta interface{}) error {
 			}
 			return err
 		}
+
+		if len(d.Get("readiness_check").([]interface{})) > 0 {
+			err = waitForVmReadiness(d, vdc, vm)
+			if err != nil {
+				return err
+			}
+			// Read again, as the guest may have reported data that was not available before
+			return genericVcdVAppVmRead(d, meta, "resource", vmType)
+		}
 	} else {
 		//create empty VM
-		vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+		// A standalone VM gets its vApp from VCD during creation
+		var vapp *govcd.VApp
+		if vmType == vappVmType {
+			vapp, err = vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+			if err != nil {
+				return fmt.Errorf("error finding vApp: %s", err)
+			}
+		}
+		newVm, err := addEmptyVm(d, vcdClient, org, vdc, vapp, vmType)
 		if err != nil {
-			return fmt.Errorf("error finding vApp: %s", err)
+			return err
 		}
-		_, err = addEmptyVm(d, vcdClient, org, vdc, vapp)
+		err = waitForVmReadiness(d, vdc, newVm)
 		if err != nil {
 			return err
 		}
-		return resourceVcdVAppVmRead(d, meta)
+		return genericVcdVAppVmRead(d, meta, "resource", vmType)
 	}
 
 	log.Printf("[DEBUG] [VM create] finished")
 	return nil
 }
 
+// getVmTemplate retrieves the vApp template, or the VM of a vApp template when "vm_name_in_template" is set, used to
+// create a VM
+func getVmTemplate(d *schema.ResourceData, org *govcd.Org, vdc *govcd.Vdc) (govcd.VAppTemplate, error) {
+	catalogName := d.Get("catalog_name").(string)
+	templateName := d.Get("template_name").(string)
+
+	catalog, err := org.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return govcd.VAppTemplate{}, fmt.Errorf("error finding catalog %s: %s", catalogName, err)
+	}
+
+	if vmNameInTemplate, ok := d.GetOk("vm_name_in_template"); ok {
+		vmInTempateRecord, err := vdc.QueryVappVmTemplate(catalogName, templateName, vmNameInTemplate.(string))
+		if err != nil {
+			return govcd.VAppTemplate{}, fmt.Errorf("error quering VM template %s: %s", vmNameInTemplate, err)
+		}
+		returnedVappTemplate, err := catalog.GetVappTemplateByHref(vmInTempateRecord.HREF)
+		if err != nil {
+			return govcd.VAppTemplate{}, fmt.Errorf("error quering VM template %s: %s", vmNameInTemplate, err)
+		}
+		return *returnedVappTemplate, nil
+	}
+
+	catalogItem, err := catalog.GetCatalogItemByName(templateName, false)
+	if err != nil {
+		return govcd.VAppTemplate{}, fmt.Errorf("error finding catalog item %s: %s", templateName, err)
+	}
+	vappTemplate, err := catalogItem.GetVAppTemplate()
+	if err != nil {
+		return govcd.VAppTemplate{}, fmt.Errorf("error finding VAppTemplate: %s", err)
+	}
+	return vappTemplate, nil
+}
+
 // isItVappNetwork checks if it is an vApp network (not vApp Org Network)
 func isItVappNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -726,34 +1062,56 @@ func getVmIndependentDisks(vm govcd.VM) []string {
 }
 
 func resourceVcdVAppVmUpdate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] [VM update] started with lock")
+	return genericResourceVmUpdate(d, meta, vappVmType)
+}
+
+func genericResourceVmUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) error {
+	log.Printf("[DEBUG] [VM update] started with type %s", vmType)
 	vcdClient := meta.(*VCDClient)
 
 	// When there is more then one VM in a vApp Terraform will try to parallelise their creation.
 	// However, vApp throws errors when simultaneous requests are executed.
 	// To avoid them, below block is using mutex as a workaround,
 	// so that the one vApp VMs are created not in parallelisation.
+	// A standalone VM is the only VM of its vApp and doesn't need it.
+	if vmType == vappVmType {
+		// The VM moves first, so that the changes below find it in its new vApp. Both vApps stay locked until the end
+		if isVmMoved(d, vmType, vcdClient.Vdc) {
+			defer lockVmMoveVApps(d, vcdClient)()
+			err := moveVmToVApp(d, vcdClient)
+			if err != nil {
+				// The VM is still in its previous vApp and VDC, which the state keeps instead of the new ones
+				d.Partial(true)
+				return err
+			}
+		} else {
+			vcdClient.lockParentVapp(d)
+			defer vcdClient.unLockParentVapp(d)
+		}
+	}
 
-	vcdClient.lockParentVapp(d)
-	defer vcdClient.unLockParentVapp(d)
-
-	// Exit early only if "network_dhcp_wait_seconds" is changed because this field only supports
-	// update so that its value can be written into statefile and be accessible in read function
-	if onlyHasChange("network_dhcp_wait_seconds", vappVmSchema, d) {
-		log.Printf("[DEBUG] [VM update] exiting early because only 'network_dhcp_wait_seconds' has change")
-		return resourceVcdVAppVmRead(d, meta)
+	// Exit early only if "network_dhcp_wait_seconds", "readiness_check" or "extra_config_ignore_changes" are changed
+	// because these fields only support update so that their value can be written into statefile and be accessible in read function
+	if onlyHasChanges([]string{"network_dhcp_wait_seconds", "readiness_check", "extra_config_ignore_changes"}, vappVmSchema, d) {
+		log.Printf("[DEBUG] [VM update] exiting early because only 'network_dhcp_wait_seconds', 'readiness_check' or 'extra_config_ignore_changes' have changes")
+		return genericVcdVAppVmRead(d, meta, "resource", vmType)
 	}
 
-	err := resourceVmHotUpdate(d, meta)
+	err := resourceVmHotUpdate(d, meta, vmType)
 	if err != nil {
 		return err
 	}
 
-	return resourceVcdVAppVmUpdateExecute(d, meta, "update")
+	return resourceVcdVAppVmUpdateExecute(d, meta, "update", vmType)
 }
 
-func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}) error {
-	vcdClient, _, vdc, vapp, _, vm, err := getVmFromResource(d, meta)
+func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) error {
+	vcdClient, _, vdc, vapp, _, vm, err := getVmFromResource(d, meta, vmType)
+	if err != nil {
+		return err
+	}
+	// Fail before changing anything if the update includes operations that a snapshot would block
+	err = checkVmSnapshotConstraints(d, vm)
 	if err != nil {
 		return err
 	}
@@ -773,7 +1131,7 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// due the bug in VCD 10.1 hot update possible for adding new or update existing network, removing of network has to be done with cold update
 	if d.HasChange("network") && !isNetworkRemovedInVcd101(d, meta) {
-		networkConnectionSection, err := networksToConfig(d, vdc, *vapp, vcdClient)
+		networkConnectionSection, err := vmNetworksToConfig(d, vcdClient, vdc, vapp, vmType)
 		if err != nil {
 			return fmt.Errorf("unable to setup network configuration for update: %s", err)
 		}
@@ -811,15 +1169,27 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// Changing the sizing policy with govcd removes the placement policy, which is then set again
+	placementPolicyId := d.Get("placement_policy_id").(string)
+	if placementPolicyId != "" && (d.HasChange("placement_policy_id") || d.HasChange("sizing_policy_id")) {
+		err = updateVmPlacementPolicy(vcdClient, vm, placementPolicyId)
+		if err != nil {
+			return err
+		}
+	}
+
 	storageProfileName := d.Get("storage_profile").(string)
 	if d.HasChange("storage_profile") && storageProfileName != "" {
-		storageProfile, err := vdc.FindStorageProfileReference(storageProfileName)
+		err = relocateVm(vdc, vm, storageProfileName)
 		if err != nil {
-			return fmt.Errorf("[vm update] error retrieving storage profile %s : %s", storageProfileName, err)
+			return err
 		}
-		_, err = vm.UpdateStorageProfile(storageProfile.HREF)
+	}
+
+	if d.HasChange("override_template_disk") {
+		err = relocateOverrideTemplateDisks(d, vdc, vm)
 		if err != nil {
-			return fmt.Errorf("error updating changing storage profile to %s: %s", storageProfileName, err)
+			return err
 		}
 	}
 
@@ -827,10 +1197,10 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func addRemoveGuestProperties(d *schema.ResourceData, vm *govcd.VM) error {
-	if d.HasChange("guest_properties") {
+	if d.HasChanges("guest_properties", "cloud_init", "customization.0.sysprep_answer_file") {
 		vmProperties, err := getGuestProperties(d)
 		if err != nil {
-			return fmt.Errorf("unable to convert guest properties to data structure")
+			return fmt.Errorf("unable to convert guest properties to data structure: %s", err)
 		}
 
 		log.Printf("[TRACE] Updating VM guest properties")
@@ -883,11 +1253,8 @@ func addRemoveMetaData(d *schema.ResourceData, vm *govcd.VM) error {
 // isNetworkRemovedInVcd101 returns true only if network removed and VCD version 10.1
 func isNetworkRemovedInVcd101(d *schema.ResourceData, meta interface{}) bool {
 	vcdClient := meta.(*VCDClient)
-	oldNetworksRaw, newNetworkRaw := d.GetChange("network")
-	oldNetworks := oldNetworksRaw.([]interface{})
-	newNetworks := newNetworkRaw.([]interface{})
 	// return true only VCD version 10.1
-	return len(oldNetworks) > len(newNetworks) && vcdClient.Client.APIVCDMaxVersionIs("= 34.0")
+	return isNetworkRemoved(d) && vcdClient.Client.APIVCDMaxVersionIs("= 34.0")
 }
 
 func changeCpuCount(d *schema.ResourceData, vm *govcd.VM) error {
@@ -914,10 +1281,10 @@ func changeMemorySize(d *schema.ResourceData, vm *govcd.VM) error {
 	return nil
 }
 
-func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, executionType string) error {
+func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm) error {
 	log.Printf("[DEBUG] [VM update] started without lock")
 
-	vcdClient, org, vdc, vapp, identifier, vm, err := getVmFromResource(d, meta)
+	vcdClient, org, vdc, vapp, identifier, vm, err := getVmFromResource(d, meta, vmType)
 	if err != nil {
 		return err
 	}
@@ -926,6 +1293,9 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 		if _, ok := d.GetOk("sizing_policy_id"); ok {
 			return fmt.Errorf("'sizing_policy_id' only available for VCD 10.0+")
 		}
+		if _, ok := d.GetOk("placement_policy_id"); ok {
+			return fmt.Errorf("'placement_policy_id' only available for VCD 10.0+")
+		}
 	}
 
 	vmStatusBeforeUpdate, err := vm.GetStatus()
@@ -965,15 +1335,16 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 	}
 
 	// this represent fields which has to be changed in cold (with VM power off)
-	if d.HasChanges("cpu_cores", "power_on", "disk", "expose_hardware_virtualization", "boot_image",
-		"hardware_version", "os_type", "description", "cpu_hot_add_enabled",
-		"memory_hot_add_enabled") || memoryNeedsColdChange || cpusNeedsColdChange || networksNeedsColdChange {
+	if d.HasChanges(vmColdUpdateFields...) || memoryNeedsColdChange || cpusNeedsColdChange || networksNeedsColdChange {
 
 		log.Printf("[TRACE] VM %s has changes: memory(%t), cpus(%t), cpu_cores(%t), power_on(%t), disk(%t), expose_hardware_virtualization(%t),"+
-			" boot_image(%t), hardware_version(%t), os_type(%t), description(%t), cpu_hot_add_enabled(%t), memory_hot_add_enabled(%t), network(%t)",
+			" boot_image(%t), hardware_version(%t), os_type(%t), description(%t), cpu_hot_add_enabled(%t), memory_hot_add_enabled(%t), network(%t),"+
+			" extra_config(%t), firmware(%t), secure_boot(%t), latency_sensitivity(%t), disk_controller(%t)",
 			vm.VM.Name, d.HasChange("memory"), d.HasChange("cpus"), d.HasChange("cpu_cores"), d.HasChange("power_on"), d.HasChange("disk"),
 			d.HasChange("expose_hardware_virtualization"), d.HasChange("boot_image"), d.HasChange("hardware_version"),
-			d.HasChange("os_type"), d.HasChange("description"), d.HasChange("cpu_hot_add_enabled"), d.HasChange("memory_hot_add_enabled"), d.HasChange("network"))
+			d.HasChange("os_type"), d.HasChange("description"), d.HasChange("cpu_hot_add_enabled"), d.HasChange("memory_hot_add_enabled"), d.HasChange("network"),
+			d.HasChange("extra_config"), d.HasChange("firmware"), d.HasChange("secure_boot"), d.HasChange("latency_sensitivity"),
+			d.HasChange("disk_controller"))
 
 		if vmStatusBeforeUpdate != "POWERED_OFF" {
 			if d.Get("prevent_update_power_off").(bool) && executionType == "update" {
@@ -981,13 +1352,9 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			}
 			log.Printf("[DEBUG] Un-deploying VM %s for offline update. Previous state %s",
 				vm.VM.Name, vmStatusBeforeUpdate)
-			task, err := vm.Undeploy()
-			if err != nil {
-				return fmt.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
-			}
-			err = task.WaitTaskCompletion()
+			err = powerOffVm(d, vcdClient, vm)
 			if err != nil {
-				return fmt.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+				return err
 			}
 		}
 
@@ -1032,7 +1399,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 		}
 
 		if networksNeedsColdChange {
-			networkConnectionSection, err := networksToConfig(d, vdc, *vapp, vcdClient)
+			networkConnectionSection, err := vmNetworksToConfig(d, vcdClient, vdc, vapp, vmType)
 			if err != nil {
 				return fmt.Errorf("unable to setup network configuration for update: %s", err)
 			}
@@ -1055,8 +1422,13 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			}
 		}
 
-		// updating fields of VM spec section
+		// updating fields of VM spec section. The hardware version is upgraded before the boot options, which may
+		// need it. The VM is refreshed, as the spec section is sent as a whole and the previous changes must be kept
 		if d.HasChange("hardware_version") || d.HasChange("os_type") || d.HasChange("description") {
+			err = vm.Refresh()
+			if err != nil {
+				return fmt.Errorf("error refreshing VM %s: %s", vm.VM.Name, err)
+			}
 			vmSpecSection := vm.VM.VmSpecSection
 			description := vm.VM.Description
 			if d.HasChange("hardware_version") {
@@ -1083,14 +1455,44 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			}
 		}
 
-		// we detach boot image if it's value change to empty.
-		bootImage := d.Get("boot_image")
-		if d.HasChange("boot_image") && bootImage.(string) == "" {
-			previousBootImageValue, _ := d.GetChange("boot_image")
-			previousCatalogName, _ := d.GetChange("catalog_name")
-			catalog, err := org.GetCatalogByName(previousCatalogName.(string), false)
+		if d.HasChange("extra_config") {
+			err = updateVmExtraConfig(vcdClient, vm, getVmExtraConfigChanges(d))
 			if err != nil {
-				return fmt.Errorf("[VM Update] error finding catalog %s: %s", previousCatalogName, err)
+				return err
+			}
+		}
+
+		if d.HasChange("latency_sensitivity") {
+			err = updateVmExtraConfig(vcdClient, vm, map[string]string{vmLatencySensitivityKey: d.Get("latency_sensitivity").(string)})
+			if err != nil {
+				return err
+			}
+		}
+
+		// During creation, the controllers were changed before the template disks
+		if d.HasChange("disk_controller") && executionType != "create" {
+			err = updateVmDiskControllers(d, vcdClient, vm)
+			if err != nil {
+				return err
+			}
+		}
+
+		// The boot options that changed together with the firmware are sent in the same request
+		if d.HasChanges(vmFirmwareFields...) {
+			err = updateVmBootSettings(d, vcdClient, vm)
+			if err != nil {
+				return err
+			}
+		}
+
+		// we detach boot image if it's value change to empty.
+		bootImage := d.Get("boot_image")
+		if d.HasChange("boot_image") && bootImage.(string) == "" {
+			previousBootImageValue, _ := d.GetChange("boot_image")
+			previousCatalogName, _ := d.GetChange("catalog_name")
+			catalog, err := org.GetCatalogByName(previousCatalogName.(string), false)
+			if err != nil {
+				return fmt.Errorf("[VM Update] error finding catalog %s: %s", previousCatalogName, err)
 			}
 			result, err := catalog.GetMediaByName(previousBootImageValue.(string), false)
 			if err != nil {
@@ -1109,8 +1511,33 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 		}
 	}
 
+	// Boot options alone don't need the VM to be powered off
+	if !d.HasChanges(vmFirmwareFields...) && d.HasChanges(vmBootOptionsFields...) {
+		err = updateVmBootSettings(d, vcdClient, vm)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChanges(vmResourceAllocationFields...) {
+		err = updateVmResourceAllocation(d, vm)
+		if err != nil {
+			return err
+		}
+	}
+
+	desiredPowerState := getVmDesiredPowerState(d)
+
+	// A VM that must be off or suspended doesn't go through the power on and customization below
+	if desiredPowerState == vmPowerStateOff || desiredPowerState == vmPowerStateSuspended {
+		err = setVmPowerState(d, vcdClient, vm, desiredPowerState)
+		if err != nil {
+			return err
+		}
+	}
+
 	// If the VM was powered off during update but it has to be powered on
-	if d.Get("power_on").(bool) {
+	if desiredPowerState == vmPowerStateOn {
 		vmStatus, err := vm.GetStatus()
 		if err != nil {
 			return fmt.Errorf("error getting VM status before ensuring it is powered on: %s", err)
@@ -1136,13 +1563,9 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 
 			if vmStatus != "POWERED_OFF" {
 				log.Printf("[TRACE] VM %s is in state %s. Un-deploying", vm.VM.Name, vmStatus)
-				task, err := vm.Undeploy()
+				err = powerOffVm(d, vcdClient, vm)
 				if err != nil {
-					return fmt.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
-				}
-				err = task.WaitTaskCompletion()
-				if err != nil {
-					return fmt.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+					return err
 				}
 			}
 
@@ -1154,10 +1577,10 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 		}
 	}
 	log.Printf("[DEBUG] [VM update] finished")
-	return resourceVcdVAppVmRead(d, meta)
+	return genericVcdVAppVmRead(d, meta, "resource", vmType)
 }
 
-func getVmFromResource(d *schema.ResourceData, meta interface{}) (*VCDClient, *govcd.Org, *govcd.Vdc, *govcd.VApp, string, *govcd.VM, error) {
+func getVmFromResource(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*VCDClient, *govcd.Org, *govcd.Vdc, *govcd.VApp, string, *govcd.VM, error) {
 	vcdClient := meta.(*VCDClient)
 
 	org, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -1165,7 +1588,7 @@ func getVmFromResource(d *schema.ResourceData, meta interface{}) (*VCDClient, *g
 		return nil, nil, nil, nil, "", nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
-	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+	vapp, err := getVmParentVapp(d, vcdClient, vdc, vmType)
 
 	if err != nil {
 		return nil, nil, nil, nil, "", nil, fmt.Errorf("error finding vApp: %s", err)
@@ -1188,6 +1611,24 @@ func getVmFromResource(d *schema.ResourceData, meta interface{}) (*VCDClient, *g
 	return vcdClient, org, vdc, vapp, identifier, vm, nil
 }
 
+// getVmParentVapp returns the vApp that contains the VM of the resource. For vcd_vapp_vm, it is the vApp named in
+// "vapp_name". A standalone VM (vcd_vm) lives in a vApp created by VCD, which is found through the VM itself
+func getVmParentVapp(d *schema.ResourceData, vcdClient *VCDClient, vdc *govcd.Vdc, vmType typeOfVm) (*govcd.VApp, error) {
+	if vmType == vappVmType {
+		return vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+	}
+
+	identifier := d.Id()
+	if identifier == "" {
+		identifier = d.Get("name").(string)
+	}
+	vm, err := getStandaloneVm(vcdClient, vdc, identifier)
+	if err != nil {
+		return nil, err
+	}
+	return vm.GetParentVApp()
+}
+
 // updates attached disks to latest state. Removed not needed and add new ones
 func attachDetachDisks(d *schema.ResourceData, vm govcd.VM, vdc *govcd.Vdc) error {
 	oldValues, newValues := d.GetChange("disk")
@@ -1264,11 +1705,11 @@ func attachDetachDisks(d *schema.ResourceData, vm govcd.VM, vdc *govcd.Vdc) erro
 }
 
 func resourceVcdVAppVmRead(d *schema.ResourceData, meta interface{}) error {
-	return genericVcdVAppVmRead(d, meta, "resource")
+	return genericVcdVAppVmRead(d, meta, "resource", vappVmType)
 }
 
-func genericVcdVAppVmRead(d *schema.ResourceData, meta interface{}, origin string) error {
-	log.Printf("[DEBUG] [VM read] started with origin %s", origin)
+func genericVcdVAppVmRead(d *schema.ResourceData, meta interface{}, origin string, vmType typeOfVm) error {
+	log.Printf("[DEBUG] [VM read] started with origin %s and type %s", origin, vmType)
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -1276,11 +1717,19 @@ func genericVcdVAppVmRead(d *schema.ResourceData, meta interface{}, origin strin
 		return fmt.Errorf("[VM read ]"+errorRetrievingOrgAndVdc, err)
 	}
 
-	vappName := d.Get("vapp_name").(string)
-	vapp, err := vdc.GetVAppByName(vappName, false)
+	vapp, err := getVmParentVapp(d, vcdClient, vdc, vmType)
 	if err != nil {
+		// The vApp of a standalone VM is found through the VM itself
+		if vmType == standaloneVmType && origin == "resource" && govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find VM. Removing from tfstate")
+			d.SetId("")
+			return nil
+		}
 		return fmt.Errorf("[VM read] error finding vApp: %s", err)
 	}
+	if vmType == standaloneVmType {
+		_ = d.Set("vapp_name", vapp.VApp.Name)
+	}
 
 	identifier := d.Id()
 	if identifier == "" {
@@ -1372,7 +1821,7 @@ func genericVcdVAppVmRead(d *schema.ResourceData, meta interface{}, origin strin
 		return fmt.Errorf("[VM read] error reading attached disks : %s", err)
 	}
 
-	if err := setGuestCustomizationData(d, vm); err != nil {
+	if err := setGuestCustomizationData(d, vm, guestProperties); err != nil {
 		return fmt.Errorf("error storing customzation block: %s", err)
 	}
 
@@ -1386,6 +1835,48 @@ func genericVcdVAppVmRead(d *schema.ResourceData, meta interface{}, origin strin
 	if vm.VM.ComputePolicy != nil && vm.VM.ComputePolicy.VmSizingPolicy != nil {
 		_ = d.Set("sizing_policy_id", vm.VM.ComputePolicy.VmSizingPolicy.ID)
 	}
+	if vm.VM.ComputePolicy != nil && vm.VM.ComputePolicy.VmPlacementPolicy != nil {
+		_ = d.Set("placement_policy_id", vm.VM.ComputePolicy.VmPlacementPolicy.ID)
+	}
+
+	setVmResourceAllocation(d, vm.VM.VmSpecSection)
+
+	var extraConfig map[string]string
+	_, latencySensitivitySet := d.GetOk("latency_sensitivity")
+	if origin == "datasource" || latencySensitivitySet || len(d.Get("extra_config").(map[string]interface{})) > 0 ||
+		hasVmDiskControllerSharing(d) {
+		extraConfig, err = getVmExtraConfig(vcdClient, vm)
+		if err != nil {
+			return fmt.Errorf("[VM read] %s", err)
+		}
+		err = setVmExtraConfig(d, extraConfig, origin)
+		if err != nil {
+			return fmt.Errorf("[VM read] unable to set extra configuration in state: %s", err)
+		}
+		setVmLatencySensitivity(d, extraConfig)
+	}
+
+	err = setVmDiskControllers(d, vm.VM.VmSpecSection, extraConfig)
+	if err != nil {
+		return fmt.Errorf("[VM read] unable to set disk controllers in state: %s", err)
+	}
+
+	if vcdClient.Client.APIVCDMaxVersionIs(">= " + vmBootOptionsApiVersion) {
+		bootSettings, err := getVmBootSettings(vcdClient, vm)
+		if err != nil {
+			return fmt.Errorf("[VM read] %s", err)
+		}
+		setVmBootSettings(d, bootSettings)
+	}
+
+	// The power state is only tracked when it is managed, so that a VM using "power_on" doesn't see it as drift
+	if origin == "datasource" || d.Get("power_state").(string) != "" {
+		vmStatus, err := vm.GetStatus()
+		if err != nil {
+			return fmt.Errorf("[VM read] error getting VM status: %s", err)
+		}
+		_ = d.Set("power_state", getVmPowerState(vmStatus))
+	}
 
 	log.Printf("[DEBUG] [VM read] finished with origin %s", origin)
 	return nil
@@ -1543,19 +2034,25 @@ func getMatchedDisk(internalDiskProvidedConfig map[string]interface{}, diskSetti
 }
 
 func resourceVcdVAppVmDelete(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("[DEBUG] [VM delete] started")
+	return genericResourceVmDelete(d, meta, vappVmType)
+}
+
+func genericResourceVmDelete(d *schema.ResourceData, meta interface{}, vmType typeOfVm) error {
+	log.Printf("[DEBUG] [VM delete] started with type %s", vmType)
 
 	vcdClient := meta.(*VCDClient)
 
-	vcdClient.lockParentVapp(d)
-	defer vcdClient.unLockParentVapp(d)
+	if vmType == vappVmType {
+		vcdClient.lockParentVapp(d)
+		defer vcdClient.unLockParentVapp(d)
+	}
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
 	if err != nil {
 		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
 	}
 
-	vapp, err := vdc.GetVAppByName(d.Get("vapp_name").(string), false)
+	vapp, err := getVmParentVapp(d, vcdClient, vdc, vmType)
 
 	if err != nil {
 		return fmt.Errorf("error finding vApp: %s", err)
@@ -1615,6 +2112,20 @@ func resourceVcdVAppVmDelete(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("[TRACE] Removing VM: %s", vm.VM.Name)
 
+	// The vApp of a standalone VM only exists for it, and gets removed together with the VM
+	if vmType == standaloneVmType {
+		task, err := vapp.Delete()
+		if err != nil {
+			return fmt.Errorf("error deleting: %s", err)
+		}
+		err = task.WaitTaskCompletion()
+		if err != nil {
+			return fmt.Errorf(errorCompletingTask, err)
+		}
+		log.Printf("[DEBUG] [VM delete] finished")
+		return nil
+	}
+
 	err = vapp.RemoveVM(*vm)
 	if err != nil {
 		return fmt.Errorf("error deleting: %s", err)
@@ -1638,22 +2149,34 @@ func resourceVcdVmIndependentDiskHash(v interface{}) int {
 // which is used for creating new VM
 func networksToConfig(d *schema.ResourceData, vdc *govcd.Vdc, vapp govcd.VApp, vcdClient *VCDClient) (types.NetworkConnectionSection, error) {
 	networks := d.Get("network").([]interface{})
+	oldNetworksRaw, _ := d.GetChange("network")
+	oldNetworks := oldNetworksRaw.([]interface{})
 
 	networkConnectionSection := types.NetworkConnectionSection{}
 
-	// sets existing primary network connection index. Further changes index only if change is found
-	for index, singleNetwork := range networks {
+	nicIndexes, err := getNicIndexes(networks, oldNetworks, getNicIndexSet(d, len(networks)))
+	if err != nil {
+		return types.NetworkConnectionSection{}, err
+	}
+
+	// sets existing primary network connection index. Further changes index only if change is found.
+	// The first NIC in the list is primary by default
+	if len(nicIndexes) > 0 {
+		networkConnectionSection.PrimaryNetworkConnectionIndex = nicIndexes[0]
+	}
+	for position, singleNetwork := range networks {
 		nic := singleNetwork.(map[string]interface{})
 		isPrimary := nic["is_primary"].(bool)
 		if isPrimary {
-			networkConnectionSection.PrimaryNetworkConnectionIndex = index
+			networkConnectionSection.PrimaryNetworkConnectionIndex = nicIndexes[position]
 		}
 	}
 
-	for index, singleNetwork := range networks {
+	for position, singleNetwork := range networks {
 
 		nic := singleNetwork.(map[string]interface{})
 		netConn := &types.NetworkConnection{}
+		index := nicIndexes[position]
 
 		networkName := nic["name"].(string)
 		if networkName == "" {
@@ -1661,10 +2184,13 @@ func networksToConfig(d *schema.ResourceData, vdc *govcd.Vdc, vapp govcd.VApp, v
 		}
 		ipAllocationMode := nic["ip_allocation_mode"].(string)
 		ip := nic["ip"].(string)
-		macAddress, macIsSet := nic["mac"].(string)
+		if ipAllocationMode != types.IPAllocationModeManual {
+			ip = getNicComputedValue(nic, "ip", position, index, oldNetworks)
+		}
+		macAddress := getNicComputedValue(nic, "mac", position, index, oldNetworks)
 
 		isPrimary := nic["is_primary"].(bool)
-		nicHasPrimaryChange := d.HasChange("network." + strconv.Itoa(index) + ".is_primary")
+		nicHasPrimaryChange := d.HasChange("network." + strconv.Itoa(position) + ".is_primary")
 		if nicHasPrimaryChange && isPrimary {
 			networkConnectionSection.PrimaryNetworkConnectionIndex = index
 		}
@@ -1694,9 +2220,7 @@ func networksToConfig(d *schema.ResourceData, vdc *govcd.Vdc, vapp govcd.VApp, v
 		netConn.IPAddressAllocationMode = ipAllocationMode
 		netConn.NetworkConnectionIndex = index
 		netConn.Network = networkName
-		if macIsSet {
-			netConn.MACAddress = macAddress
-		}
+		netConn.MACAddress = macAddress
 
 		if ipAllocationMode == types.IPAllocationModeNone {
 			netConn.Network = types.NoneNetwork
@@ -1706,16 +2230,34 @@ func networksToConfig(d *schema.ResourceData, vdc *govcd.Vdc, vapp govcd.VApp, v
 			netConn.IPAddress = ip
 		}
 
-		adapterType, isSetAdapterType := nic["adapter_type"]
-		if isSetAdapterType {
-			netConn.NetworkAdapterType = adapterType.(string)
-		}
+		netConn.NetworkAdapterType = getNicComputedValue(nic, "adapter_type", position, index, oldNetworks)
 
 		networkConnectionSection.NetworkConnection = append(networkConnectionSection.NetworkConnection, netConn)
 	}
+
+	// The indexes given to the NICs are stored, so that the NICs are read in the order of the list
+	for position, singleNetwork := range networks {
+		singleNetwork.(map[string]interface{})["nic_index"] = nicIndexes[position]
+	}
+	err = d.Set("network", networks)
+	if err != nil {
+		return types.NetworkConnectionSection{}, err
+	}
 	return networkConnectionSection, nil
 }
 
+// vmNetworksToConfig runs networksToConfig for the given type of VM. The Org VDC networks used by a standalone VM
+// are added to its vApp first, as the user has no way of defining them
+func vmNetworksToConfig(d *schema.ResourceData, vcdClient *VCDClient, vdc *govcd.Vdc, vapp *govcd.VApp, vmType typeOfVm) (types.NetworkConnectionSection, error) {
+	if vmType == standaloneVmType {
+		err := addStandaloneVmOrgNetworks(d, vdc, vapp)
+		if err != nil {
+			return types.NetworkConnectionSection{}, err
+		}
+	}
+	return networksToConfig(d, vdc, *vapp, vcdClient)
+}
+
 // isItVappOrgNetwork checks if it is an vApp Org network (not vApp Network)
 func isItVappOrgNetwork(vAppNetworkName string, vapp govcd.VApp) (bool, error) {
 	vAppNetworkConfig, err := vapp.GetNetworkConfig()
@@ -1782,11 +2324,8 @@ func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp) ([]map[s
 	}
 
 	var nets []map[string]interface{}
-	// Sort NIC cards by their virtual slot numbers as the API returns them in random order
-	sort.SliceStable(vm.VM.NetworkConnectionSection.NetworkConnection, func(i, j int) bool {
-		return vm.VM.NetworkConnectionSection.NetworkConnection[i].NetworkConnectionIndex <
-			vm.VM.NetworkConnectionSection.NetworkConnection[j].NetworkConnectionIndex
-	})
+	// The API returns the NICs in random order. They are sorted as in the 'network' list
+	sortNetworkConnections(vm.VM.NetworkConnectionSection.NetworkConnection, d.Get("network").([]interface{}))
 
 	for _, vmNet := range vm.VM.NetworkConnectionSection.NetworkConnection {
 		singleNIC := make(map[string]interface{})
@@ -1795,6 +2334,7 @@ func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp) ([]map[s
 		singleNIC["mac"] = vmNet.MACAddress
 		singleNIC["adapter_type"] = vmNet.NetworkAdapterType
 		singleNIC["connected"] = vmNet.IsConnected
+		singleNIC["nic_index"] = vmNet.NetworkConnectionIndex
 		if vmNet.Network != types.NoneNetwork {
 			singleNIC["name"] = vmNet.Network
 		}
@@ -1855,10 +2395,15 @@ func readNetworks(d *schema.ResourceData, vm govcd.VM, vapp govcd.VApp) ([]map[s
 			log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] VM '%s' waiting for DHCP IPs took '%s' (of '%ds')",
 				vm.VM.Name, time.Since(start), maxDhcpWaitSeconds)
 
+			// NIC indexes are not necessarily contiguous, nor in ascending order in the list
+			nicPositions := make(map[int]int)
+			for position, singleNIC := range nets {
+				nicPositions[singleNIC["nic_index"].(int)] = position
+			}
 			for sliceIndex, nicIndex := range dhcpNicIndexes {
 				log.Printf("[DEBUG] [VM read] [DHCP IP Lookup] VM '%s' NIC %d reported IP %s",
 					vm.VM.Name, nicIndex, nicIps[sliceIndex])
-				nets[nicIndex]["ip"] = nicIps[sliceIndex]
+				nets[nicPositions[nicIndex]]["ip"] = nicIps[sliceIndex]
 			}
 		}
 	}
@@ -1909,26 +2454,80 @@ func getGuestProperties(d *schema.ResourceData) (*types.ProductSectionList, erro
 		vmProperties.ProductSection.Property = append(vmProperties.ProductSection.Property, oneProp)
 	}
 
+	cloudInitProperties, err := getCloudInitProperties(d)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range cloudInitProperties {
+		if _, ok := guestProp[key]; ok {
+			return nil, fmt.Errorf("guest property '%s' is set by 'cloud_init' and can't be in 'guest_properties'", key)
+		}
+		log.Printf("[TRACE] Adding cloud-init guest property: key=%s to object", key)
+		vmProperties.ProductSection.Property = append(vmProperties.ProductSection.Property, &types.Property{
+			UserConfigurable: true,
+			Type:             "string",
+			Key:              key,
+			Label:            key,
+			Value:            &types.Value{Value: value},
+		})
+	}
+
+	sysprepProperties, err := getSysprepAnswerFileProperties(d)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range sysprepProperties {
+		if _, ok := guestProp[key]; ok {
+			return nil, fmt.Errorf("guest property '%s' is set by 'customization.0.sysprep_answer_file' and can't be in 'guest_properties'", key)
+		}
+		log.Printf("[TRACE] Adding sysprep answer file guest property: key=%s to object", key)
+		vmProperties.ProductSection.Property = append(vmProperties.ProductSection.Property, &types.Property{
+			UserConfigurable: true,
+			Type:             "string",
+			Key:              key,
+			Label:            key,
+			Value:            &types.Value{Value: value},
+		})
+	}
+
 	return vmProperties, nil
 }
 
-// setGuestProperties sets guest properties into state
+// setGuestProperties sets guest properties into state.
+// When "cloud_init" is set, its properties are not stored in "guest_properties". If they don't match the
+// ones built from "cloud_init", the block is removed from state, so that the next apply sets them again.
+// The property of "customization.0.sysprep_answer_file" is not stored either, and is read with the customization
 func setGuestProperties(d *schema.ResourceData, properties *types.ProductSectionList) error {
 	data := make(map[string]string)
 
-	// if properties object does not have actual properties - set state to empty
-	log.Printf("[TRACE] Setting empty properties into statefile because no properties were specified")
-	if properties == nil || properties.ProductSection == nil || len(properties.ProductSection.Property) == 0 {
-		return d.Set("guest_properties", make(map[string]string))
+	cloudInitProperties, err := getCloudInitProperties(d)
+	if err != nil {
+		return err
 	}
+	currentCloudInitProperties := make(map[string]string)
 
-	for _, prop := range properties.ProductSection.Property {
-		// if a value was set - use it
-		if prop.Value != nil {
+	if properties != nil && properties.ProductSection != nil {
+		for _, prop := range properties.ProductSection.Property {
+			// if a value was set - use it
+			if prop.Value == nil {
+				continue
+			}
+			if len(cloudInitProperties) > 0 && isCloudInitProperty(prop.Key) {
+				currentCloudInitProperties[prop.Key] = prop.Value.Value
+				continue
+			}
+			if prop.Key == vmSysprepAnswerFileKey && isSysprepAnswerFileSet(d) {
+				continue
+			}
 			data[prop.Key] = prop.Value.Value
 		}
 	}
 
+	if len(cloudInitProperties) > 0 && !reflect.DeepEqual(cloudInitProperties, currentCloudInitProperties) {
+		log.Printf("[DEBUG] cloud-init guest properties were changed outside of Terraform")
+		_ = d.Set("cloud_init", nil)
+	}
+
 	log.Printf("[TRACE] Setting properties into statefile")
 	return d.Set("guest_properties", data)
 }
@@ -1945,14 +2544,18 @@ func setGuestProperties(d *schema.ResourceData, properties *types.ProductSection
 //
 // Example resource name (_resource_name_): vcd_vapp_vm.VM_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.vm-name
+// Example import path (_the_id_string_): urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3
 func resourceVcdVappVmImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("[VM import] resource name must be specified as org-name.vdc-name.vapp-name.vm-name")
 	}
 	orgName, vdcName, vappName, vmName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[VM import] unable to find VDC %s: %s ", vdcName, err)
@@ -1962,11 +2565,11 @@ func resourceVcdVappVmImport(d *schema.ResourceData, meta interface{}) ([]*schem
 	if err != nil {
 		return nil, fmt.Errorf("[VM import] error retrieving vapp %s: %s", vappName, err)
 	}
-	vm, err := vapp.GetVMByName(vmName, false)
+	vm, err := vapp.GetVMByNameOrId(vmName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[VM import] error retrieving VM %s: %s", vmName, err)
 	}
-	_ = d.Set("name", vmName)
+	_ = d.Set("name", vm.VM.Name)
 	_ = d.Set("org", orgName)
 	_ = d.Set("vdc", vdcName)
 	_ = d.Set("vapp_name", vappName)
@@ -1995,7 +2598,10 @@ func updateGuestCustomizationSetting(d *schema.ResourceData, vm *govcd.VM) error
 	}
 
 	// Process parameters from 'customization' block
-	updateCustomizationSection(d.Get("customization"), d, customizationSection)
+	err = updateCustomizationSection(d.Get("customization"), d, customizationSection)
+	if err != nil {
+		return err
+	}
 
 	// Apply any of the settings we have set
 	if _, err = vm.SetGuestCustomizationSection(customizationSection); err != nil {
@@ -2005,7 +2611,7 @@ func updateGuestCustomizationSetting(d *schema.ResourceData, vm *govcd.VM) error
 	return nil
 }
 
-func updateCustomizationSection(customizationInterface interface{}, d *schema.ResourceData, customizationSection *types.GuestCustomizationSection) {
+func updateCustomizationSection(customizationInterface interface{}, d *schema.ResourceData, customizationSection *types.GuestCustomizationSection) error {
 	customizationSlice := customizationInterface.([]interface{})
 	if len(customizationSlice) == 1 {
 		cust := customizationSlice[0]
@@ -2014,9 +2620,20 @@ func updateCustomizationSection(customizationInterface interface{}, d *schema.Re
 			if enabled, isSetEnabled := d.GetOkExists("customization.0.enabled"); isSetEnabled {
 				customizationSection.Enabled = takeBoolPointer(enabled.(bool))
 			}
-			if initScript, isSetInitScript := d.GetOkExists("customization.0.initscript"); isSetInitScript {
-				customizationSection.CustomizationScript = initScript.(string)
+			// The Linux settings are applied by commands added to the script of the user, which is taken from
+			// 'initscript_file', 'initscript' or the existing script, in this order
+			userScript, _ := parseCustomizationScript(customizationSection.CustomizationScript)
+			if initScriptFile, isSetInitScriptFile := d.GetOk("customization.0.initscript_file"); isSetInitScriptFile {
+				script, err := readCustomizationScriptFile(initScriptFile.(string))
+				if err != nil {
+					return err
+				}
+				userScript = script
+			} else if initScript, isSetInitScript := d.GetOkExists("customization.0.initscript"); isSetInitScript {
+				userScript = initScript.(string)
 			}
+			linuxCustomization := getVmLinuxCustomization(cust.(map[string]interface{}))
+			customizationSection.CustomizationScript = buildCustomizationScript(userScript, linuxCustomization, customizationSection.ComputerName)
 
 			if changeSid, isSetChangeSid := d.GetOkExists("customization.0.change_sid"); isSetChangeSid {
 				customizationSection.ChangeSid = takeBoolPointer(changeSid.(bool))
@@ -2077,16 +2694,19 @@ func updateCustomizationSection(customizationInterface interface{}, d *schema.Re
 
 		}
 	}
+	return nil
 }
 
-// setGuestCustomizationData is responsible for persisting all guest customization details into statefile
-func setGuestCustomizationData(d *schema.ResourceData, vm *govcd.VM) error {
+// setGuestCustomizationData is responsible for persisting all guest customization details into statefile.
+// guestProperties holds the sysprep answer file, which is not part of the guest customization section
+func setGuestCustomizationData(d *schema.ResourceData, vm *govcd.VM, guestProperties *types.ProductSectionList) error {
 	customizationSection, err := vm.GetGuestCustomizationSection()
 	if err != nil {
 		return fmt.Errorf("unable to get guest customization section: %s", err)
 	}
 
 	_ = d.Set("computer_name", customizationSection.ComputerName)
+	_ = d.Set("generated_admin_password", getGeneratedAdminPassword(customizationSection))
 
 	customizationBlock := make([]interface{}, 1)
 	customizationBlockAttributes := make(map[string]interface{})
@@ -2104,7 +2724,18 @@ func setGuestCustomizationData(d *schema.ResourceData, vm *govcd.VM) error {
 	customizationBlockAttributes["join_domain_user"] = customizationSection.DomainUserName
 	customizationBlockAttributes["join_domain_password"] = customizationSection.DomainUserPassword
 	customizationBlockAttributes["join_domain_account_ou"] = customizationSection.MachineObjectOU
-	customizationBlockAttributes["initscript"] = customizationSection.CustomizationScript
+	userScript, linuxCustomization := parseCustomizationScript(customizationSection.CustomizationScript)
+	customizationBlockAttributes["initscript"] = userScript
+	customizationBlockAttributes["linux_hostname"] = linuxCustomization.hostname
+	customizationBlockAttributes["linux_domain"] = linuxCustomization.domain
+	customizationBlockAttributes["linux_timezone"] = linuxCustomization.timezone
+	// The file name is not known by VCD. It is only kept in the state of the resource
+	if initScriptFile, ok := d.Get("customization.0.initscript_file").(string); ok {
+		customizationBlockAttributes["initscript_file"] = initScriptFile
+	}
+	if isSysprepAnswerFileSet(d) {
+		customizationBlockAttributes["sysprep_answer_file"] = getSysprepAnswerFile(guestProperties)
+	}
 
 	customizationBlock[0] = customizationBlockAttributes
 
@@ -2116,7 +2747,18 @@ func setGuestCustomizationData(d *schema.ResourceData, vm *govcd.VM) error {
 	return nil
 }
 
-func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vdc *govcd.Vdc, vapp *govcd.VApp) (*govcd.VM, error) {
+// getGeneratedAdminPassword returns the administrator password generated by VCD. The generated password is returned in
+// the same field as the one set by the user, and is empty until the guest customization is done
+func getGeneratedAdminPassword(customizationSection *types.GuestCustomizationSection) string {
+	if customizationSection.AdminPasswordAuto == nil || !*customizationSection.AdminPasswordAuto {
+		return ""
+	}
+	return customizationSection.AdminPassword
+}
+
+// addEmptyVm creates a VM without template. When vmType is standaloneVmType, vapp is ignored and the VM is created in
+// a new vApp managed by VCD
+func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vdc *govcd.Vdc, vapp *govcd.VApp, vmType typeOfVm) (*govcd.VM, error) {
 	log.Printf("[TRACE] Creating empty VM: %s", d.Get("name").(string))
 
 	var ok bool
@@ -2165,7 +2807,10 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 	customizationSection.ComputerName = computerName.(string)
 
 	// Process parameters from 'customization' block
-	updateCustomizationSection(d.Get("customization"), d, customizationSection)
+	err := updateCustomizationSection(d.Get("customization"), d, customizationSection)
+	if err != nil {
+		return nil, err
+	}
 
 	isVirtualCpuType64 := strings.Contains(d.Get("os_type").(string), "64")
 	virtualCpuType := "VM32"
@@ -2209,12 +2854,22 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 		AllEULAsAccepted: true,
 	}
 
-	err := addSizingPolicy(d, vcdClient, org, recomposeVAppParamsForEmptyVm)
+	err = addSizingPolicy(d, vcdClient, org, recomposeVAppParamsForEmptyVm)
+	if err != nil {
+		return nil, err
+	}
+
+	err = addPlacementPolicy(d, vcdClient, recomposeVAppParamsForEmptyVm)
 	if err != nil {
 		return nil, err
 	}
 
-	newVm, err := vapp.AddEmptyVm(recomposeVAppParamsForEmptyVm)
+	var newVm *govcd.VM
+	if vmType == standaloneVmType {
+		newVm, err = createStandaloneEmptyVm(vcdClient, vdc, recomposeVAppParamsForEmptyVm.CreateItem)
+	} else {
+		newVm, err = vapp.AddEmptyVm(recomposeVAppParamsForEmptyVm)
+	}
 	if err != nil {
 		d.SetId("")
 		return nil, fmt.Errorf("[VM creation] error creating VM %s : %s", vmName, err)
@@ -2222,8 +2877,15 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 
 	d.SetId(newVm.VM.ID)
 
+	if vmType == standaloneVmType {
+		vapp, err = newVm.GetParentVApp()
+		if err != nil {
+			return nil, fmt.Errorf("[VM creation] error retrieving the vApp of VM %s: %s", vmName, err)
+		}
+	}
+
 	// Due the Bug in vCD VM creation(works only with org VDC networks, not vapp) - we setup network configuration with update. Fixed only 10.1 version.
-	networkConnectionSection, err := networksToConfig(d, vdc, *vapp, vcdClient)
+	networkConnectionSection, err := vmNetworksToConfig(d, vcdClient, vdc, vapp, vmType)
 	if err != nil {
 		return nil, fmt.Errorf("unable to setup network configuration for empty VM: %s", err)
 	}
@@ -2255,20 +2917,48 @@ func addEmptyVm(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, vd
 		}
 	}
 
-	err = addRemoveMetaData(d, newVm)
+	err = updateVmExtraConfig(vcdClient, newVm, convertToStringMap(d.Get("extra_config").(map[string]interface{})))
 	if err != nil {
 		return nil, err
 	}
 
-	if d.Get("power_on").(bool) {
-		log.Printf("[DEBUG] Powering on VM %s", newVm.VM.Name)
-		task, err := newVm.PowerOn()
+	err = updateVmDiskControllers(d, vcdClient, newVm)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasVmBootSettings(d) {
+		err = updateVmBootSettings(d, vcdClient, newVm)
 		if err != nil {
-			return nil, fmt.Errorf("error powering on: %s", err)
+			return nil, err
 		}
-		err = task.WaitTaskCompletion()
+	}
+
+	if hasVmResourceAllocation(d) {
+		err = updateVmResourceAllocation(d, newVm)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if isVmFieldSet(d, "latency_sensitivity") {
+		err = updateVmExtraConfig(vcdClient, newVm, map[string]string{vmLatencySensitivityKey: d.Get("latency_sensitivity").(string)})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = addRemoveMetaData(d, newVm)
+	if err != nil {
+		return nil, err
+	}
+
+	desiredPowerState := getVmDesiredPowerState(d)
+	if desiredPowerState != "" {
+		log.Printf("[DEBUG] Setting power state of VM %s to %s", newVm.VM.Name, desiredPowerState)
+		err = setVmPowerState(d, vcdClient, newVm, desiredPowerState)
 		if err != nil {
-			return nil, fmt.Errorf(errorCompletingTask, err)
+			return nil, err
 		}
 	}
 	return newVm, nil
@@ -2308,6 +2998,78 @@ func addSizingPolicy(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Or
 	return nil
 }
 
+func addPlacementPolicy(d *schema.ResourceData, vcdClient *VCDClient, recomposeVAppParamsForEmptyVm *types.RecomposeVAppParamsForEmptyVm) error {
+	value, ok := d.GetOk("placement_policy_id")
+	if !ok {
+		return nil
+	}
+	vcdComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies, value.(string))
+	if err != nil {
+		return fmt.Errorf("error constructing HREF for compute policy")
+	}
+	if recomposeVAppParamsForEmptyVm.CreateItem.ComputePolicy == nil {
+		recomposeVAppParamsForEmptyVm.CreateItem.ComputePolicy = &types.ComputePolicy{}
+	}
+	recomposeVAppParamsForEmptyVm.CreateItem.ComputePolicy.VmPlacementPolicy = &types.Reference{HREF: vcdComputePolicyHref.String()}
+	return nil
+}
+
+// validateVmPlacementPolicy rejects the removal of the placement policy of an existing VM. The compute policy sent to
+// VCD is built from a policy ID, and the provider has no way to remove it
+func validateVmPlacementPolicy(diff *schema.ResourceDiff) error {
+	if diff.Id() == "" || !diff.HasChange("placement_policy_id") || !diff.NewValueKnown("placement_policy_id") {
+		return nil
+	}
+	oldValue, newValue := diff.GetChange("placement_policy_id")
+	return validateVmPlacementPolicyChange(oldValue.(string), newValue.(string))
+}
+
+// validateVmPlacementPolicyChange checks a change of placement policy ID
+func validateVmPlacementPolicyChange(oldId, newId string) error {
+	if oldId != "" && newId == "" {
+		return fmt.Errorf("'placement_policy_id' cannot be removed from a VM. Set another placement policy, " +
+			"or recreate the VM without it")
+	}
+	return nil
+}
+
+// updateVmPlacementPolicy sets the placement policy of a VM. govcd only handles the sizing policy, so the current
+// sizing policy is sent along with the placement policy to keep it
+func updateVmPlacementPolicy(vcdClient *VCDClient, vm *govcd.VM, placementPolicyId string) error {
+	computePolicy := &types.ComputePolicy{}
+	placementPolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies, placementPolicyId)
+	if err != nil {
+		return fmt.Errorf("error constructing HREF for compute policy")
+	}
+	computePolicy.VmPlacementPolicy = &types.Reference{HREF: placementPolicyHref.String()}
+
+	if vm.VM.ComputePolicy != nil && vm.VM.ComputePolicy.VmSizingPolicy != nil && vm.VM.ComputePolicy.VmSizingPolicy.ID != "" {
+		sizingPolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies, vm.VM.ComputePolicy.VmSizingPolicy.ID)
+		if err != nil {
+			return fmt.Errorf("error constructing HREF for compute policy")
+		}
+		computePolicy.VmSizingPolicy = &types.Reference{HREF: sizingPolicyHref.String()}
+	}
+
+	log.Printf("[TRACE] updating placement policy of VM %s to %s", vm.VM.Name, placementPolicyId)
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error updating VM placement policy: %s", &types.VM{
+			Xmlns:         types.XMLNamespaceVCloud,
+			Ovf:           types.XMLNamespaceOVF,
+			Name:          vm.VM.Name,
+			Description:   vm.VM.Description,
+			ComputePolicy: computePolicy,
+		})
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for the placement policy update of VM %s: %s", vm.VM.Name, err)
+	}
+	return vm.Refresh()
+}
+
 // handleExposeHardwareVirtualization toggles hardware virtualization according `expose_hardware_virtualization` field value.
 func handleExposeHardwareVirtualization(d *schema.ResourceData, newVm *govcd.VM) error {
 	// The below operation assumes VM is powered off and does not check for it because VM is being
@@ -2326,3 +3088,310 @@ func handleExposeHardwareVirtualization(d *schema.ResourceData, newVm *govcd.VM)
 	}
 	return nil
 }
+
+const (
+	vmPowerStateOn        = "on"
+	vmPowerStateOff       = "off"
+	vmPowerStateSuspended = "suspended"
+)
+
+// getVmPowerState converts a VM status into one of the values of "power_state".
+// Other statuses are returned in lowercase, so that they never match the desired state
+func getVmPowerState(vmStatus string) string {
+	switch vmStatus {
+	case "POWERED_ON":
+		return vmPowerStateOn
+	case "POWERED_OFF":
+		return vmPowerStateOff
+	case "SUSPENDED":
+		return vmPowerStateSuspended
+	}
+	return strings.ToLower(vmStatus)
+}
+
+// getVmDesiredPowerState returns the power state that the VM must have at the end of create and update.
+// "power_state" takes precedence over "power_on". An empty result means that the current power state is kept
+func getVmDesiredPowerState(d vmChangeGetter) string {
+	powerState := d.Get("power_state").(string)
+	if powerState != "" {
+		return powerState
+	}
+	if d.Get("power_on").(bool) {
+		return vmPowerStateOn
+	}
+	return ""
+}
+
+// setVmPowerState brings the VM to the desired power state, if it is not already there
+func setVmPowerState(d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM, desiredPowerState string) error {
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting VM %s status: %s", vm.VM.Name, err)
+	}
+	currentPowerState := getVmPowerState(vmStatus)
+	if currentPowerState == desiredPowerState {
+		return nil
+	}
+	log.Printf("[DEBUG] changing power state of VM %s from %s to %s", vm.VM.Name, currentPowerState, desiredPowerState)
+
+	switch desiredPowerState {
+	case vmPowerStateOff:
+		return powerOffVm(d, vcdClient, vm)
+	case vmPowerStateOn, vmPowerStateSuspended:
+		// A VM can only be suspended when it is running
+		if currentPowerState != vmPowerStateOn {
+			task, err := vm.PowerOn()
+			if err != nil {
+				return fmt.Errorf("error powering on VM %s: %s", vm.VM.Name, err)
+			}
+			err = task.WaitTaskCompletion()
+			if err != nil {
+				return fmt.Errorf(errorCompletingTask, err)
+			}
+		}
+		if desiredPowerState == vmPowerStateSuspended {
+			task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/power/action/suspend", http.MethodPost,
+				"", "error suspending VM: %s", nil)
+			if err != nil {
+				return err
+			}
+			err = task.WaitTaskCompletion()
+			if err != nil {
+				return fmt.Errorf(errorCompletingTask, err)
+			}
+		}
+	default:
+		return fmt.Errorf("unknown power state '%s'", desiredPowerState)
+	}
+	return nil
+}
+
+// powerOffVm powers off and un-deploys a VM.
+// When "guest_shutdown_timeout" is set, the guest OS is shut down through VMware Tools first, and the VM is
+// powered off only if the guest doesn't stop within the timeout or the shutdown can't be requested
+func powerOffVm(d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) error {
+	timeout := d.Get("guest_shutdown_timeout").(int)
+	if timeout > 0 {
+		err := shutdownVmGuest(vcdClient, vm, time.Duration(timeout)*time.Second)
+		if err != nil {
+			log.Printf("[DEBUG] guest shutdown of VM %s did not complete, powering it off: %s", vm.VM.Name, err)
+		}
+	}
+
+	deployed, err := vm.IsDeployed()
+	if err != nil {
+		return fmt.Errorf("error checking if VM %s is deployed: %s", vm.VM.Name, err)
+	}
+	if !deployed {
+		return nil
+	}
+	task, err := vm.Undeploy()
+	if err != nil {
+		return fmt.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// shutdownVmGuest requests the shutdown of the guest OS and waits until the VM is powered off
+func shutdownVmGuest(vcdClient *VCDClient, vm *govcd.VM, timeout time.Duration) error {
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/power/action/shutdown", http.MethodPost,
+		"", "error shutting down VM: %s", nil)
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(timeout)
+	for {
+		// The task fails right away when VMware Tools are not running in the guest
+		err = task.Refresh()
+		if err != nil {
+			return err
+		}
+		if task.Task.Status == "error" {
+			if task.Task.Error != nil {
+				return fmt.Errorf("shutdown task failed: %s", task.Task.Error.Message)
+			}
+			return fmt.Errorf("shutdown task failed")
+		}
+		vmStatus, err := vm.GetStatus()
+		if err != nil {
+			return err
+		}
+		if vmStatus == "POWERED_OFF" {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the guest OS did not shut down within %s", timeout)
+		}
+		time.Sleep(5 * time.Second)
+	}
+}
+
+const (
+	vmReadinessCustomizationDone = "customization_done"
+	vmReadinessToolsRunning      = "tools_running"
+	vmReadinessGuestProperty     = "guest_property"
+	vmReadinessNicIps            = "nic_ips"
+
+	vmReadinessPollInterval = 5 * time.Second
+)
+
+// waitForVmReadiness evaluates the readiness checks of the VM in order. Each check polls the VM until its
+// condition is met, and fails when its timeout expires.
+// The checks are skipped when the VM is not powered on, as the guest can't reach any of the conditions
+func waitForVmReadiness(d *schema.ResourceData, vdc *govcd.Vdc, vm *govcd.VM) error {
+	checks := d.Get("readiness_check").([]interface{})
+	if len(checks) == 0 {
+		return nil
+	}
+
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("error getting VM %s status before readiness checks: %s", vm.VM.Name, err)
+	}
+	if vmStatus != "POWERED_ON" {
+		_, _ = fmt.Fprintf(getTerraformStdout(), "INFO: VM %s is not powered on. Skipping 'readiness_check'\n", vm.VM.Name)
+		return nil
+	}
+
+	for _, rawCheck := range checks {
+		check := rawCheck.(map[string]interface{})
+		checkType := check["type"].(string)
+		timeout := time.Duration(check["timeout"].(int)) * time.Second
+
+		var condition func() (bool, error)
+		switch checkType {
+		case vmReadinessCustomizationDone:
+			condition = func() (bool, error) {
+				customizationStatus, err := vm.GetGuestCustomizationStatus()
+				if err != nil {
+					return false, err
+				}
+				if customizationStatus == "GC_FAILED" {
+					return false, fmt.Errorf("guest customization failed")
+				}
+				return customizationStatus == "GC_COMPLETE", nil
+			}
+		case vmReadinessToolsRunning:
+			vapp, err := vm.GetParentVApp()
+			if err != nil {
+				return fmt.Errorf("error retrieving vApp of VM %s: %s", vm.VM.Name, err)
+			}
+			condition = func() (bool, error) {
+				vmRecord, err := vdc.QueryVM(vapp.VApp.Name, vm.VM.Name)
+				if err != nil {
+					return false, err
+				}
+				return isVmToolsRunning(vmRecord.VM.VmToolsStatus), nil
+			}
+		case vmReadinessGuestProperty:
+			key := check["guest_property_key"].(string)
+			if key == "" {
+				return fmt.Errorf("'guest_property_key' is required for readiness check '%s'", vmReadinessGuestProperty)
+			}
+			condition = func() (bool, error) {
+				properties, err := vm.GetProductSectionList()
+				if err != nil {
+					return false, err
+				}
+				return hasGuestPropertyValue(properties, key, check["guest_property_value"].(string)), nil
+			}
+		case vmReadinessNicIps:
+			condition = func() (bool, error) {
+				return vmNicsHaveIps(vm)
+			}
+		default:
+			return fmt.Errorf("unknown readiness check '%s'", checkType)
+		}
+
+		log.Printf("[DEBUG] [VM create] waiting up to %s for readiness check '%s' of VM %s", timeout, checkType, vm.VM.Name)
+		start := time.Now()
+		err = waitForVmCondition(timeout, condition)
+		if err != nil {
+			return fmt.Errorf("readiness check '%s' for VM %s failed: %s", checkType, vm.VM.Name, err)
+		}
+		log.Printf("[DEBUG] [VM create] readiness check '%s' of VM %s passed in %s", checkType, vm.VM.Name, time.Since(start))
+	}
+	return nil
+}
+
+// waitForVmCondition polls a condition until it is met, it returns an error, or the timeout expires
+func waitForVmCondition(timeout time.Duration, condition func() (bool, error)) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		done, err := condition()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+		time.Sleep(vmReadinessPollInterval)
+	}
+}
+
+// isVmToolsRunning checks the VMware Tools status reported by the query API, which uses the vSphere names
+// (toolsOk, toolsOld, toolsNotRunning, toolsNotInstalled)
+func isVmToolsRunning(toolsStatus string) bool {
+	switch strings.ToLower(strings.ReplaceAll(toolsStatus, "_", "")) {
+	case "toolsok", "toolsold":
+		return true
+	}
+	return false
+}
+
+// hasGuestPropertyValue checks that a guest property has the wanted value.
+// An empty wanted value matches any non empty value
+func hasGuestPropertyValue(properties *types.ProductSectionList, key, wantedValue string) bool {
+	if properties == nil || properties.ProductSection == nil {
+		return false
+	}
+	for _, property := range properties.ProductSection.Property {
+		if property.Key != key || property.Value == nil {
+			continue
+		}
+		if wantedValue == "" {
+			return property.Value.Value != ""
+		}
+		return property.Value.Value == wantedValue
+	}
+	return false
+}
+
+// vmNicsHaveIps checks that all the connected NICs of a VM have an IP address.
+// DHCP NICs are checked with the same lookup used by "network_dhcp_wait_seconds", which waits for one poll
+// interval at most, as the timeout is handled by the caller
+func vmNicsHaveIps(vm *govcd.VM) (bool, error) {
+	err := vm.Refresh()
+	if err != nil {
+		return false, err
+	}
+	if vm.VM.NetworkConnectionSection == nil {
+		return true, nil
+	}
+	for _, nic := range vm.VM.NetworkConnectionSection.NetworkConnection {
+		if !nic.IsConnected || nic.Network == types.NoneNetwork || nic.IPAddressAllocationMode == types.IPAllocationModeDHCP {
+			continue
+		}
+		if nic.IPAddress == "" {
+			return false, nil
+		}
+	}
+
+	dhcpNicIndexes := getVmNicIndexesWithDhcpEnabled(vm.VM.NetworkConnectionSection)
+	if len(dhcpNicIndexes) == 0 {
+		return true, nil
+	}
+	_, timeout, err := vm.WaitForDhcpIpByNicIndexes(dhcpNicIndexes, int(vmReadinessPollInterval.Seconds()), true)
+	if err != nil {
+		return false, err
+	}
+	return !timeout, nil
+}
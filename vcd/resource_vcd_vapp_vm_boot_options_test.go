@@ -0,0 +1,113 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmBootOptions creates an empty EFI VM with secure boot, then changes its boot options while it is
+// powered on. It also checks that secure boot is refused at plan time with an old hardware version
+func TestAccVcdVAppVmBootOptions(t *testing.T) {
+	var vapp govcd.VApp
+	var vm govcd.VM
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	vcdClient, err := getTestVCDFromJson(testConfig)
+	if err != nil {
+		t.Skip("unable to validate vCD version - skipping test")
+	}
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + vmBootOptionsApiVersion) {
+		t.Skip("TestAccVcdVAppVmBootOptions requires VCD 10.3+")
+	}
+
+	var params = StringMap{
+		"Org":             testConfig.VCD.Org,
+		"Vdc":             testConfig.VCD.Vdc,
+		"VappName":        vappName2,
+		"VmName":          vmName,
+		"HardwareVersion": "vmx-14",
+		"BootDelay":       1000,
+		"BootRetry":       false,
+		"BootRetryDelay":  0,
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmBootOptions, params)
+	params["FuncName"] = t.Name() + "-update"
+	params["BootDelay"] = 3000
+	params["BootRetry"] = true
+	params["BootRetryDelay"] = 10000
+	configTextUpdate := templateFill(testAccCheckVcdVAppVmBootOptions, params)
+	params["FuncName"] = t.Name() + "-old-hardware"
+	params["HardwareVersion"] = "vmx-11"
+	configTextOldHardware := templateFill(testAccCheckVcdVAppVmBootOptions, params)
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName2),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName2, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "firmware", "efi"),
+					resource.TestCheckResourceAttr(resourceName, "secure_boot", "true"),
+					resource.TestCheckResourceAttr(resourceName, "boot_delay", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "boot_retry_enabled", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "boot_delay", "3000"),
+					resource.TestCheckResourceAttr(resourceName, "boot_retry_enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "boot_retry_delay", "10000"),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_ON"),
+				),
+			},
+			resource.TestStep{
+				Config:      configTextOldHardware,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`'secure_boot' requires hardware version vmx-13 or later`),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmBootOptions = `
+resource "vcd_vapp" "{{.VappName}}" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org              = "{{.Org}}"
+  vdc              = "{{.Vdc}}"
+  vapp_name        = vcd_vapp.{{.VappName}}.name
+  name             = "{{.VmName}}"
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "ubuntu64Guest"
+  hardware_version = "{{.HardwareVersion}}"
+  computer_name    = "boot-options"
+
+  firmware           = "efi"
+  secure_boot        = true
+  boot_delay         = {{.BootDelay}}
+  boot_retry_enabled = {{.BootRetry}}
+  boot_retry_delay   = {{.BootRetryDelay}}
+}
+`
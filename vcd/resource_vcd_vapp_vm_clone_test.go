@@ -0,0 +1,108 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdVAppVmClone creates a VM from a template and powers it on, then copies it into another vApp with more
+// memory and CPUs, and copies its whole vApp
+func TestAccVcdVAppVmClone(t *testing.T) {
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    "TestCloneSourceVapp",
+		"VmName":      "TestCloneSourceVm",
+		"FuncName":    t.Name(),
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmClone, params)
+
+	sourceVm := "vcd_vapp_vm.source"
+	copiedVm := "vcd_vapp_vm.copy"
+	copiedVApp := "vcd_vapp.copy"
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(sourceVm, "power_state", "on"),
+					resource.TestCheckResourceAttr(copiedVm, "name", "TestCloneCopiedVm"),
+					resource.TestCheckResourceAttr(copiedVm, "vapp_name", "TestCloneTargetVapp"),
+					resource.TestCheckResourceAttr(copiedVm, "memory", "1024"),
+					resource.TestCheckResourceAttr(copiedVm, "cpus", "2"),
+					resource.TestCheckResourceAttrPair(copiedVm, "os_type", sourceVm, "os_type"),
+					resource.TestCheckResourceAttr(copiedVApp, "name", "TestCloneCopiedVapp"),
+					resource.TestCheckResourceAttr("data.vcd_vapp_vm.in_copied_vapp", "name", params["VmName"].(string)),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmClone = `
+resource "vcd_vapp" "source" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "source" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.source.name
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+}
+
+resource "vcd_vapp" "target" {
+  name = "TestCloneTargetVapp"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "copy" {
+  org          = "{{.Org}}"
+  vdc          = "{{.Vdc}}"
+  vapp_name    = vcd_vapp.target.name
+  name         = "TestCloneCopiedVm"
+  source_vm_id = vcd_vapp_vm.source.id
+  memory       = 1024
+  cpus         = 2
+  cpu_cores    = 1
+}
+
+resource "vcd_vapp" "copy" {
+  name           = "TestCloneCopiedVapp"
+  org            = "{{.Org}}"
+  vdc            = "{{.Vdc}}"
+  source_vapp_id = vcd_vapp.source.id
+
+  depends_on = [vcd_vapp_vm.source]
+}
+
+data "vcd_vapp_vm" "in_copied_vapp" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vapp.copy.name
+  name      = "{{.VmName}}"
+}
+`
@@ -4,6 +4,8 @@ package vcd
 
 import (
 	"fmt"
+	"io/ioutil"
+	"path/filepath"
 	"regexp"
 	"testing"
 
@@ -504,3 +506,297 @@ resource "vcd_vapp_vm" "test-vm-step3" {
   }
 }
 `
+
+// TestAccVcdVAppVmCustomizationLinux sets the Linux host name, domain and time zone, with a customization script read
+// from a file. It checks that the script of the user is kept apart from the commands added for the Linux settings, and
+// that a change in the file content is applied
+func TestAccVcdVAppVmCustomizationLinux(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vappName = t.Name()
+		vmName   = t.Name() + "VM"
+	)
+
+	scriptFile := filepath.Join(t.TempDir(), "customization.sh")
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"EdgeGateway": testConfig.Networking.EdgeGateway,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VAppName":    vappName,
+		"VMName":      vmName,
+		"ScriptFile":  scriptFile,
+		"Domain":      "example.com",
+		"Tags":        "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmCustomizationLinux, params)
+
+	params["FuncName"] = t.Name() + "-update"
+	params["Domain"] = "example.org"
+	configTextUpdate := templateFill(testAccCheckVcdVAppVmCustomizationLinux, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	writeScript := func(content string) func() {
+		return func() {
+			err := ioutil.WriteFile(scriptFile, []byte(content), 0600)
+			if err != nil {
+				t.Fatalf("error writing customization script: %s", err)
+			}
+		}
+	}
+	writeScript("#!/bin/bash\necho step1 > /tmp/customization.txt\n")()
+
+	resourceName := "vcd_vapp_vm.test-vm"
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.linux_hostname", "linux-vm"),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.linux_domain", "example.com"),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.linux_timezone", "Europe/Rome"),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.initscript", "#!/bin/bash\necho step1 > /tmp/customization.txt\n"),
+					resource.TestCheckResourceAttr("data.vcd_vapp_vm.test-vm", "customization.0.linux_domain", "example.com"),
+					resource.TestCheckResourceAttr("data.vcd_vapp_vm.test-vm", "customization.0.initscript", "#!/bin/bash\necho step1 > /tmp/customization.txt\n"),
+				),
+			},
+			// The new content of the file and the new domain are applied
+			resource.TestStep{
+				PreConfig: writeScript("#!/bin/bash\necho step2 > /tmp/customization.txt\n"),
+				Config:    configTextUpdate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "customization.0.linux_domain", "example.org"),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.initscript", "#!/bin/bash\necho step2 > /tmp/customization.txt\n"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmCustomizationLinux = testAccCheckVcdVAppVmCustomizationShared + `
+# skip-binary-test: the customization script is written by the test
+resource "vcd_vapp_vm" "test-vm" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "{{.VMName}}"
+  computer_name = "linux-vm"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  customization {
+    linux_hostname  = "linux-vm"
+    linux_domain    = "{{.Domain}}"
+    linux_timezone  = "Europe/Rome"
+    initscript_file = "{{.ScriptFile}}"
+  }
+}
+
+data "vcd_vapp_vm" "test-vm" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vapp.test-vapp.name
+  name      = vcd_vapp_vm.test-vm.name
+}
+`
+
+// TestAccVcdVAppVmCustomizationSysprep sets a sysprep answer file, and checks that it is kept apart from
+// 'guest_properties' and that a change of its content is applied
+func TestAccVcdVAppVmCustomizationSysprep(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vappName = t.Name()
+		vmName   = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":          testConfig.VCD.Org,
+		"Vdc":          testConfig.VCD.Vdc,
+		"Catalog":      testSuiteCatalogName,
+		"CatalogItem":  testSuiteCatalogOVAItem,
+		"VAppName":     vappName,
+		"VMName":       vmName,
+		"ComputerName": "sysprep1",
+		"Tags":         "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmCustomizationSysprep, params)
+
+	params["FuncName"] = t.Name() + "-update"
+	params["ComputerName"] = "sysprep2"
+	configTextUpdate := templateFill(testAccCheckVcdVAppVmCustomizationSysprep, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	answerFile := func(computerName string) string {
+		return fmt.Sprintf("<unattend xmlns=\"urn:schemas-microsoft-com:unattend\"><ComputerName>%s</ComputerName></unattend>\n",
+			computerName)
+	}
+
+	resourceName := "vcd_vapp_vm.test-vm"
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.sysprep_answer_file", answerFile("sysprep1")),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.guest.role", "windows"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextUpdate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "customization.0.sysprep_answer_file", answerFile("sysprep2")),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmCustomizationSysprep = `
+resource "vcd_vapp" "test-vapp" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VAppName}}"
+}
+
+resource "vcd_vapp_vm" "test-vm" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "{{.VMName}}"
+  computer_name = "{{.ComputerName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  guest_properties = {
+    "guest.role" = "windows"
+  }
+
+  customization {
+    enabled             = false
+    sysprep_answer_file = <<-EOT
+      <unattend xmlns="urn:schemas-microsoft-com:unattend"><ComputerName>{{.ComputerName}}</ComputerName></unattend>
+    EOT
+  }
+}
+`
+
+// TestAccVcdVAppVmGeneratedAdminPassword checks that the administrator password generated by VCD is stored in the
+// state once the guest customization is done
+func TestAccVcdVAppVmGeneratedAdminPassword(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vappName = t.Name()
+		vmName   = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VAppName":    vappName,
+		"VMName":      vmName,
+		"Tags":        "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmGeneratedAdminPassword, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm.test-vm"
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "customization.0.auto_generate_password", "true"),
+					resource.TestMatchResourceAttr(resourceName, "generated_admin_password", regexp.MustCompile(`^.+$`)),
+					resource.TestCheckResourceAttrPair("data.vcd_vapp_vm.test-vm", "generated_admin_password",
+						resourceName, "generated_admin_password"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmGeneratedAdminPassword = `
+resource "vcd_vapp" "test-vapp" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VAppName}}"
+}
+
+resource "vcd_vapp_vm" "test-vm" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "{{.VMName}}"
+  computer_name = "generated-pwd"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  customization {
+    enabled                    = true
+    allow_local_admin_password = true
+    auto_generate_password     = true
+  }
+
+  readiness_check {
+    type    = "customization_done"
+    timeout = 600
+  }
+}
+
+data "vcd_vapp_vm" "test-vm" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vapp.test-vapp.name
+  name      = vcd_vapp_vm.test-vm.name
+}
+`
@@ -0,0 +1,265 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmDiskController creates a VM with the disk controller of the template and a second SCSI
+// controller, then shares the SCSI buses. It also checks that a SATA controller can't be shared
+func TestAccVcdVAppVmDiskController(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vappName string = t.Name()
+		vmName   string = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VAppName":    vappName,
+		"VMName":      vmName,
+		"Sharing0":    "none",
+		"Sharing1":    "none",
+		"Tags":        "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmDiskController, params)
+
+	params["FuncName"] = t.Name() + "-step1"
+	params["Sharing0"] = "virtual"
+	params["Sharing1"] = "physical"
+	configTextStep1 := templateFill(testAccCheckVcdVAppVmDiskController, params)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configTextStep2 := templateFill(testAccCheckVcdVAppVmDiskControllerSharedSata, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "disk_controller.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "disk_controller.*", map[string]string{
+						"bus_type":   "paravirtual",
+						"bus_number": "0",
+						"sharing":    "none",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "disk_controller.*", map[string]string{
+						"bus_type":   "paravirtual",
+						"bus_number": "1",
+						"sharing":    "none",
+					}),
+				),
+			},
+			resource.TestStep{
+				Config: configTextStep1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "disk_controller.#", "2"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "disk_controller.*", map[string]string{
+						"bus_type":   "paravirtual",
+						"bus_number": "0",
+						"sharing":    "virtual",
+					}),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "disk_controller.*", map[string]string{
+						"bus_type":   "paravirtual",
+						"bus_number": "1",
+						"sharing":    "physical",
+					}),
+				),
+			},
+			resource.TestStep{
+				Config:      configTextStep2,
+				ExpectError: regexp.MustCompile(`Only SCSI controllers can be shared`),
+			},
+		},
+	})
+}
+
+// TestAccVcdVAppVmDiskControllerEmptyVm checks that the bus sharing of a SCSI controller is applied when creating an
+// empty VM
+func TestAccVcdVAppVmDiskControllerEmptyVm(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vappName string = t.Name()
+		vmName   string = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":      testConfig.VCD.Org,
+		"Vdc":      testConfig.VCD.Vdc,
+		"VAppName": vappName,
+		"VMName":   vmName,
+		"Tags":     "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmDiskControllerEmptyVm, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					testAccCheckVcdVmExtraConfigValue(vappName, vmName, vmDiskControllerSharingKey(0), "virtual"),
+					resource.TestCheckTypeSetElemNestedAttrs(resourceName, "disk_controller.*", map[string]string{
+						"bus_type":   "paravirtual",
+						"bus_number": "0",
+						"sharing":    "virtual",
+					}),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckVcdVmExtraConfigValue checks the value of an advanced setting of a VM in VCD
+func testAccCheckVcdVmExtraConfigValue(vappName, vmName, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*VCDClient)
+		_, vdc, err := conn.GetOrgAndVdc(testConfig.VCD.Org, testConfig.VCD.Vdc)
+		if err != nil {
+			return fmt.Errorf(errorRetrievingVdcFromOrg, testConfig.VCD.Vdc, testConfig.VCD.Org, err)
+		}
+
+		vapp, err := vdc.GetVAppByName(vappName, false)
+		if err != nil {
+			return err
+		}
+
+		vm, err := vapp.GetVMByName(vmName, false)
+		if err != nil {
+			return err
+		}
+
+		extraConfig, err := getVmExtraConfig(conn, vm)
+		if err != nil {
+			return err
+		}
+		if extraConfig[key] != expected {
+			return fmt.Errorf("expected %s of VM %s to be '%s', got '%s'", key, vmName, expected, extraConfig[key])
+		}
+		return nil
+	}
+}
+
+const testAccCheckVcdVAppVmDiskControllerShared = `
+resource "vcd_vapp" "{{.VAppName}}" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VAppName}}"
+}
+`
+
+const testAccCheckVcdVAppVmDiskController = testAccCheckVcdVAppVmDiskControllerShared + `
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = false
+
+  disk_controller {
+    bus_type   = "paravirtual"
+    bus_number = 0
+    sharing    = "{{.Sharing0}}"
+  }
+
+  disk_controller {
+    bus_type   = "paravirtual"
+    bus_number = 1
+    sharing    = "{{.Sharing1}}"
+  }
+}
+`
+
+const testAccCheckVcdVAppVmDiskControllerSharedSata = testAccCheckVcdVAppVmDiskControllerShared + `
+# skip-binary-test: expected to fail
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = false
+
+  disk_controller {
+    bus_type   = "paravirtual"
+    bus_number = 0
+    sharing    = "{{.Sharing0}}"
+  }
+
+  disk_controller {
+    bus_type   = "sata"
+    bus_number = 0
+    sharing    = "virtual"
+  }
+}
+`
+
+const testAccCheckVcdVAppVmDiskControllerEmptyVm = testAccCheckVcdVAppVmDiskControllerShared + `
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name        = vcd_vapp.{{.VAppName}}.name
+  name             = "{{.VMName}}"
+  computer_name    = "disk-controller"
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-13"
+  power_on         = false
+
+  disk_controller {
+    bus_type   = "paravirtual"
+    bus_number = 0
+    sharing    = "virtual"
+  }
+}
+`
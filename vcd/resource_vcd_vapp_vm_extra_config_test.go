@@ -0,0 +1,98 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmExtraConfig creates a VM with extra configuration, changes and removes keys, and reads them back
+// with the data source
+func TestAccVcdVAppVmExtraConfig(t *testing.T) {
+	var vapp govcd.VApp
+	var vm govcd.VM
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    vappName2,
+		"VmName":      vmName,
+		"ExtraConfig": `"disk.EnableUUID" = "TRUE"
+    "guestinfo.role"  = "worker"`,
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmExtraConfig, params)
+	params["FuncName"] = t.Name() + "-update"
+	params["ExtraConfig"] = `"disk.EnableUUID" = "FALSE"`
+	configTextUpdate := templateFill(testAccCheckVcdVAppVmExtraConfig, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName2),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName2, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "extra_config.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "extra_config.disk.EnableUUID", "TRUE"),
+					resource.TestCheckResourceAttr(resourceName, "extra_config.guestinfo.role", "worker"),
+					resource.TestCheckResourceAttr("data.vcd_vapp_vm.vm", "extra_config.disk.EnableUUID", "TRUE"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "extra_config.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "extra_config.disk.EnableUUID", "FALSE"),
+					resource.TestCheckNoResourceAttr("data.vcd_vapp_vm.vm", "extra_config.guestinfo.role"),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_ON"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmExtraConfig = `
+resource "vcd_vapp" "{{.VappName}}" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.{{.VappName}}.name
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  extra_config = {
+    {{.ExtraConfig}}
+  }
+}
+
+data "vcd_vapp_vm" "vm" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vapp_vm.{{.VmName}}.vapp_name
+  name      = vcd_vapp_vm.{{.VmName}}.name
+}
+`
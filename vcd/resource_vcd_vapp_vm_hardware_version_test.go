@@ -0,0 +1,128 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmHardwareVersion creates an empty VM with hardware version vmx-13, then upgrades the hardware
+// version and changes the guest OS type in place. It checks that a downgrade, and a change that needs a power off
+// with 'prevent_update_power_off', are refused
+func TestAccVcdVAppVmHardwareVersion(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vmId     testCachedFieldValue
+		vappName string = t.Name()
+		vmName   string = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":                   testConfig.VCD.Org,
+		"Vdc":                   testConfig.VCD.Vdc,
+		"VAppName":              vappName,
+		"VMName":                vmName,
+		"OsType":                "sles11_64Guest",
+		"HardwareVersion":       "vmx-13",
+		"PowerOn":               "true",
+		"PreventUpdatePowerOff": "false",
+		"Tags":                  "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmHardwareVersion, params)
+
+	params["FuncName"] = t.Name() + "-upgrade"
+	params["OsType"] = "sles12_64Guest"
+	params["HardwareVersion"] = "vmx-14"
+	configTextUpgrade := templateFill(testAccCheckVcdVAppVmHardwareVersion, params)
+
+	params["FuncName"] = t.Name() + "-prevent-power-off"
+	params["OsType"] = "sles15_64Guest"
+	params["PreventUpdatePowerOff"] = "true"
+	configTextPreventPowerOff := templateFill(testAccCheckVcdVAppVmHardwareVersion, params)
+
+	params["FuncName"] = t.Name() + "-downgrade"
+	params["OsType"] = "sles12_64Guest"
+	params["HardwareVersion"] = "vmx-13"
+	params["PreventUpdatePowerOff"] = "false"
+	configTextDowngrade := templateFill(testAccCheckVcdVAppVmHardwareVersion, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "hardware_version", "vmx-13"),
+					resource.TestCheckResourceAttr(resourceName, "os_type", "sles11_64Guest"),
+					resource.TestCheckTypeSetElemAttr("data.vcd_vm_guest_os.hw", "os_types.*", "sles12_64Guest"),
+					vmId.cacheTestResourceFieldValue(resourceName, "id"),
+				),
+			},
+			// The VM is upgraded in place
+			resource.TestStep{
+				Config: configTextUpgrade,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					vmId.testCheckCachedResourceFieldValue(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "hardware_version", "vmx-14"),
+					resource.TestCheckResourceAttr(resourceName, "os_type", "sles12_64Guest"),
+					resource.TestCheckResourceAttr(resourceName, "power_on", "true"),
+				),
+			},
+			resource.TestStep{
+				Config:      configTextPreventPowerOff,
+				ExpectError: regexp.MustCompile(`prevent_update_power_off`),
+			},
+			resource.TestStep{
+				Config:      configTextDowngrade,
+				ExpectError: regexp.MustCompile(`can't be downgraded`),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmHardwareVersion = `
+resource "vcd_vapp" "{{.VAppName}}" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VAppName}}"
+}
+
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  computer_name = "hw-version"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = {{.PowerOn}}
+
+  os_type                  = "{{.OsType}}"
+  hardware_version         = "{{.HardwareVersion}}"
+  prevent_update_power_off = {{.PreventUpdatePowerOff}}
+}
+
+data "vcd_vm_guest_os" "hw" {
+  hardware_version = "vmx-14"
+  family           = "Linux"
+}
+`
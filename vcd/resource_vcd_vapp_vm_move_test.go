@@ -0,0 +1,117 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmMove moves a running VM to another vApp of the same VDC. It checks that the move is refused at plan
+// time with 'prevent_update_power_off', and that the VM keeps its ID and is powered on again after the move
+func TestAccVcdVAppVmMove(t *testing.T) {
+	var (
+		vapp          govcd.VApp
+		vm            govcd.VM
+		vmId          testCachedFieldValue
+		vappName      string = t.Name()
+		otherVappName string = t.Name() + "Other"
+		vmName        string = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":                   testConfig.VCD.Org,
+		"Vdc":                   testConfig.VCD.Vdc,
+		"Catalog":               testSuiteCatalogName,
+		"CatalogItem":           testSuiteCatalogOVAItem,
+		"VAppName":              vappName,
+		"OtherVAppName":         otherVappName,
+		"VMName":                vmName,
+		"ParentVApp":            "first",
+		"PreventUpdatePowerOff": "false",
+		"Tags":                  "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmMove, params)
+
+	params["FuncName"] = t.Name() + "-prevent-power-off"
+	params["ParentVApp"] = "second"
+	params["PreventUpdatePowerOff"] = "true"
+	configTextPreventPowerOff := templateFill(testAccCheckVcdVAppVmMove, params)
+
+	params["FuncName"] = t.Name() + "-move"
+	params["PreventUpdatePowerOff"] = "false"
+	configTextMove := templateFill(testAccCheckVcdVAppVmMove, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(otherVappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "vapp_name", vappName),
+					resource.TestCheckResourceAttr(resourceName, "power_state", "on"),
+					vmId.cacheTestResourceFieldValue(resourceName, "id"),
+				),
+			},
+			resource.TestStep{
+				Config:      configTextPreventPowerOff,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`update stopped: VM needs to power off to change properties \(vapp_name\)`),
+			},
+			// The VM moves in place, without being recreated
+			resource.TestStep{
+				Config: configTextMove,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(otherVappName, vmName, resourceName, &vapp, &vm),
+					vmId.testCheckCachedResourceFieldValue(resourceName, "id"),
+					resource.TestCheckResourceAttr(resourceName, "vapp_name", otherVappName),
+					resource.TestCheckResourceAttr(resourceName, "power_state", "on"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmMove = `
+resource "vcd_vapp" "first" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VAppName}}"
+}
+
+resource "vcd_vapp" "second" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.OtherVAppName}}"
+}
+
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.ParentVApp}}.name
+  name          = "{{.VMName}}"
+  computer_name = "move-vm"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  prevent_update_power_off = {{.PreventUpdatePowerOff}}
+}
+`
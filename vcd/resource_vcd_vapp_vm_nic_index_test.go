@@ -0,0 +1,208 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmNicIndex removes the second of three NICs identified by 'nic_index', and checks that the third NIC
+// keeps its index, MAC and IP address. It then adds a NIC without index, which takes the free index
+func TestAccVcdVAppVmNicIndex(t *testing.T) {
+	var (
+		vapp        govcd.VApp
+		vm          govcd.VM
+		netVappName string = t.Name()
+		netVmName1  string = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"EdgeGateway": testConfig.Networking.EdgeGateway,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VAppName":    netVappName,
+		"VMName":      netVmName1,
+		"Tags":        "vapp vm",
+	}
+
+	nic0Mac := testCachedFieldValue{}
+	nic2Mac := testCachedFieldValue{}
+
+	configText := templateFill(testAccCheckVcdVAppVmNicIndex, params)
+
+	params["FuncName"] = t.Name() + "-step1"
+	configTextStep1 := templateFill(testAccCheckVcdVAppVmNicIndexStep1, params)
+
+	params["FuncName"] = t.Name() + "-step2"
+	configTextStep2 := templateFill(testAccCheckVcdVAppVmNicIndexStep2, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + netVmName1
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(netVappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(netVappName, netVmName1, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "network.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "network.0.nic_index", "0"),
+					resource.TestCheckResourceAttr(resourceName, "network.1.nic_index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "network.2.nic_index", "2"),
+					resource.TestCheckResourceAttr(resourceName, "network.2.ip", "11.10.0.170"),
+					nic0Mac.cacheTestResourceFieldValue(resourceName, "network.0.mac"),
+					nic2Mac.cacheTestResourceFieldValue(resourceName, "network.2.mac"),
+				),
+			},
+			// Remove the NIC in the middle
+			resource.TestStep{
+				Config: configTextStep1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(netVappName, netVmName1, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "network.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "network.0.nic_index", "0"),
+					nic0Mac.testCheckCachedResourceFieldValue(resourceName, "network.0.mac"),
+					resource.TestCheckResourceAttr(resourceName, "network.1.nic_index", "2"),
+					resource.TestCheckResourceAttr(resourceName, "network.1.ip", "11.10.0.170"),
+					resource.TestCheckResourceAttr(resourceName, "network.1.adapter_type", "E1000"),
+					nic2Mac.testCheckCachedResourceFieldValue(resourceName, "network.1.mac"),
+				),
+			},
+			// Add a NIC without index, which gets the free index 1
+			resource.TestStep{
+				Config: configTextStep2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(netVappName, netVmName1, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "network.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "network.1.nic_index", "2"),
+					nic2Mac.testCheckCachedResourceFieldValue(resourceName, "network.1.mac"),
+					resource.TestCheckResourceAttr(resourceName, "network.2.nic_index", "1"),
+					resource.TestCheckResourceAttr(resourceName, "network.2.type", "none"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmNicIndex = testAccCheckVcdVAppVmNetworkShared + `
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "POOL"
+    is_primary         = true
+    nic_index          = 0
+  }
+
+  network {
+    type               = "vapp"
+    name               = vcd_vapp_network.vappIsolatedNet.name
+    ip_allocation_mode = "POOL"
+    nic_index          = 1
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "11.10.0.170"
+    adapter_type       = "E1000"
+    nic_index          = 2
+  }
+}
+`
+
+const testAccCheckVcdVAppVmNicIndexStep1 = testAccCheckVcdVAppVmNetworkShared + `
+# skip-binary-test: only for updates
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "POOL"
+    is_primary         = true
+    nic_index          = 0
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "11.10.0.170"
+    adapter_type       = "E1000"
+    nic_index          = 2
+  }
+}
+`
+
+const testAccCheckVcdVAppVmNicIndexStep2 = testAccCheckVcdVAppVmNetworkShared + `
+# skip-binary-test: only for updates
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "POOL"
+    is_primary         = true
+    nic_index          = 0
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "11.10.0.170"
+    adapter_type       = "E1000"
+    nic_index          = 2
+  }
+
+  network {
+    type               = "none"
+    ip_allocation_mode = "NONE"
+    connected          = false
+  }
+}
+`
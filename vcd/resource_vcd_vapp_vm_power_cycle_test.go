@@ -0,0 +1,122 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmPowerCycle checks that a plan with changes that need a running VM to be powered off fails when
+// 'prevent_update_power_off' is true, and shows 'power_cycle_required' otherwise. A hot change is applied without
+// restarting the VM
+func TestAccVcdVAppVmPowerCycle(t *testing.T) {
+	var (
+		vapp     govcd.VApp
+		vm       govcd.VM
+		vappName string = t.Name()
+		vmName   string = t.Name() + "VM"
+	)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VAppName":    vappName,
+		"VMName":      vmName,
+		"Memory":      "1024",
+		"Cpus":        "1",
+		"CpuCores":    "1",
+		"Prevent":     "true",
+		"Tags":        "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmPowerCycle, params)
+
+	params["FuncName"] = t.Name() + "-cold"
+	params["Cpus"] = "2"
+	params["CpuCores"] = "2"
+	configTextCold := templateFill(testAccCheckVcdVAppVmPowerCycle, params)
+
+	params["FuncName"] = t.Name() + "-hot"
+	params["Cpus"] = "1"
+	params["CpuCores"] = "1"
+	params["Memory"] = "2048"
+	configTextHot := templateFill(testAccCheckVcdVAppVmPowerCycle, params)
+
+	params["FuncName"] = t.Name() + "-cold-allowed"
+	params["CpuCores"] = "2"
+	params["Prevent"] = "false"
+	configTextColdAllowed := templateFill(testAccCheckVcdVAppVmPowerCycle, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName, vmName, resourceName, &vapp, &vm),
+				),
+			},
+			// The plan fails before anything is changed
+			resource.TestStep{
+				Config:      configTextCold,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`update stopped: VM needs to power off to change properties \(cpu_cores, cpus\)`),
+			},
+			resource.TestStep{
+				Config: configTextHot,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckVcdVmNotRestarted(resourceName, vappName, vmName),
+					resource.TestCheckResourceAttr(resourceName, "memory", "2048"),
+					resource.TestCheckResourceAttr(resourceName, "power_cycle_required", "false"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextColdAllowed,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cpu_cores", "2"),
+					resource.TestCheckResourceAttr(resourceName, "power_cycle_required", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmPowerCycle = `
+resource "vcd_vapp" "{{.VAppName}}" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VAppName}}"
+}
+
+resource "vcd_vapp_vm" "{{.VMName}}" {
+  org = "{{.Org}}"
+  vdc = "{{.Vdc}}"
+
+  vapp_name     = vcd_vapp.{{.VAppName}}.name
+  name          = "{{.VMName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = {{.Memory}}
+  cpus          = {{.Cpus}}
+  cpu_cores     = {{.CpuCores}}
+
+  memory_hot_add_enabled   = true
+  cpu_hot_add_enabled      = false
+  prevent_update_power_off = {{.Prevent}}
+}
+`
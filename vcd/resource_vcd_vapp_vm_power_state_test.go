@@ -0,0 +1,123 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmPowerState creates a VM that stays off, then suspends it, and finally powers it on and changes
+// a property that needs the VM to be powered off, which is done with a guest shutdown
+func TestAccVcdVAppVmPowerState(t *testing.T) {
+	var vapp govcd.VApp
+	var vm govcd.VM
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    vappName2,
+		"VmName":      vmName,
+		"PowerState":  "off",
+		"CpuCores":    1,
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmPowerState, params)
+	params["FuncName"] = t.Name() + "-suspended"
+	params["PowerState"] = "suspended"
+	configTextSuspended := templateFill(testAccCheckVcdVAppVmPowerState, params)
+	params["FuncName"] = t.Name() + "-on"
+	params["PowerState"] = "on"
+	configTextOn := templateFill(testAccCheckVcdVAppVmPowerState, params)
+	params["FuncName"] = t.Name() + "-cold-update"
+	params["CpuCores"] = 2
+	configTextColdUpdate := templateFill(testAccCheckVcdVAppVmPowerState, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName2),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName2, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "power_state", "off"),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_OFF"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextSuspended,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "power_state", "suspended"),
+					testAccCheckVcdVAppVmStatus(&vm, "SUSPENDED"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextOn,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "power_state", "on"),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_ON"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextColdUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cpu_cores", "2"),
+					resource.TestCheckResourceAttr(resourceName, "power_state", "on"),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_ON"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckVcdVAppVmStatus checks the status of a VM retrieved by testAccCheckVcdVAppVmExists
+func testAccCheckVcdVAppVmStatus(vm *govcd.VM, expectedStatus string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		status, err := vm.GetStatus()
+		if err != nil {
+			return err
+		}
+		if status != expectedStatus {
+			return fmt.Errorf("VM %s has status %s instead of %s", vm.VM.Name, status, expectedStatus)
+		}
+		return nil
+	}
+}
+
+const testAccCheckVcdVAppVmPowerState = `
+resource "vcd_vapp" "{{.VappName}}" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.{{.VappName}}.name
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = {{.CpuCores}}
+
+  power_state            = "{{.PowerState}}"
+  guest_shutdown_timeout = 120
+}
+`
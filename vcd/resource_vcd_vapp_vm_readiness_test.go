@@ -0,0 +1,91 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmReadiness creates a VM that is only considered created when its guest is ready
+func TestAccVcdVAppVmReadiness(t *testing.T) {
+	var vapp govcd.VApp
+	var vm govcd.VM
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.VCD.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    vappName2,
+		"VmName":      vmName,
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmReadiness, params)
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName2),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName2, vmName, resourceName, &vapp, &vm),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_ON"),
+					resource.TestCheckResourceAttr(resourceName, "readiness_check.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "guest_properties.ready", "yes"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmReadiness = `
+resource "vcd_vapp" "{{.VappName}}" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.{{.VappName}}.name
+  name          = "{{.VmName}}"
+  computer_name = "ready-vm"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  guest_properties = {
+    "ready" = "yes"
+  }
+
+  readiness_check {
+    type    = "tools_running"
+    timeout = 300
+  }
+
+  readiness_check {
+    type    = "customization_done"
+    timeout = 600
+  }
+
+  readiness_check {
+    type                 = "guest_property"
+    guest_property_key   = "ready"
+    guest_property_value = "yes"
+  }
+}
+`
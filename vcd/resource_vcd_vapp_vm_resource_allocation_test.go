@@ -0,0 +1,131 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdVAppVmResourceAllocation creates an empty VM with CPU and memory reservations, limits and shares, then
+// changes them while the VM is powered on. It also sets a high latency sensitivity, which needs the whole memory
+// to be reserved, and checks that a partial reservation is refused at plan time
+func TestAccVcdVAppVmResourceAllocation(t *testing.T) {
+	var vapp govcd.VApp
+	var vm govcd.VM
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	var params = StringMap{
+		"Org":                testConfig.VCD.Org,
+		"Vdc":                testConfig.VCD.Vdc,
+		"VappName":           vappName2,
+		"VmName":             vmName,
+		"CpuReservation":     500,
+		"CpuLimit":           -1,
+		"CpuShares":          1000,
+		"MemoryReservation":  256,
+		"MemoryLimit":        -1,
+		"MemoryShares":       5120,
+		"LatencySensitivity": "normal",
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmResourceAllocation, params)
+	params["FuncName"] = t.Name() + "-update"
+	params["CpuReservation"] = 1000
+	params["CpuLimit"] = 2000
+	params["CpuShares"] = 2000
+	params["MemoryLimit"] = 1024
+	params["MemoryShares"] = 10240
+	configTextUpdate := templateFill(testAccCheckVcdVAppVmResourceAllocation, params)
+	params["FuncName"] = t.Name() + "-latency"
+	params["MemoryReservation"] = 512
+	params["LatencySensitivity"] = "high"
+	configTextLatency := templateFill(testAccCheckVcdVAppVmResourceAllocation, params)
+	params["FuncName"] = t.Name() + "-partial-reservation"
+	params["MemoryReservation"] = 256
+	configTextPartialReservation := templateFill(testAccCheckVcdVAppVmResourceAllocation, params)
+
+	resourceName := "vcd_vapp_vm." + vmName
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName2),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVAppVmExists(vappName2, vmName, resourceName, &vapp, &vm),
+					resource.TestCheckResourceAttr(resourceName, "cpu_reservation", "500"),
+					resource.TestCheckResourceAttr(resourceName, "cpu_limit", "-1"),
+					resource.TestCheckResourceAttr(resourceName, "cpu_shares", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "memory_reservation", "256"),
+					resource.TestCheckResourceAttr(resourceName, "memory_limit", "-1"),
+					resource.TestCheckResourceAttr(resourceName, "memory_shares", "5120"),
+					resource.TestCheckResourceAttr(resourceName, "latency_sensitivity", "normal"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "cpu_reservation", "1000"),
+					resource.TestCheckResourceAttr(resourceName, "cpu_limit", "2000"),
+					resource.TestCheckResourceAttr(resourceName, "cpu_shares", "2000"),
+					resource.TestCheckResourceAttr(resourceName, "memory_limit", "1024"),
+					resource.TestCheckResourceAttr(resourceName, "memory_shares", "10240"),
+					testAccCheckVcdVAppVmStatus(&vm, "POWERED_ON"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextLatency,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "memory_reservation", "512"),
+					resource.TestCheckResourceAttr(resourceName, "latency_sensitivity", "high"),
+				),
+			},
+			resource.TestStep{
+				Config:      configTextPartialReservation,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`requires 'memory_reservation' to be the whole 'memory'`),
+			},
+		},
+	})
+}
+
+const testAccCheckVcdVAppVmResourceAllocation = `
+resource "vcd_vapp" "{{.VappName}}" {
+  name = "{{.VappName}}"
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org                    = "{{.Org}}"
+  vdc                    = "{{.Vdc}}"
+  vapp_name              = vcd_vapp.{{.VappName}}.name
+  name                   = "{{.VmName}}"
+  memory                 = 512
+  cpus                   = 1
+  cpu_cores              = 1
+  os_type                = "ubuntu64Guest"
+  hardware_version       = "vmx-14"
+  computer_name          = "allocation"
+  cpu_hot_add_enabled    = true
+  memory_hot_add_enabled = true
+
+  cpu_reservation     = {{.CpuReservation}}
+  cpu_limit           = {{.CpuLimit}}
+  cpu_shares          = {{.CpuShares}}
+  memory_reservation  = {{.MemoryReservation}}
+  memory_limit        = {{.MemoryLimit}}
+  memory_shares       = {{.MemoryShares}}
+  latency_sensitivity = "{{.LatencySensitivity}}"
+}
+`
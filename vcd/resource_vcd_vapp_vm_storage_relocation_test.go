@@ -0,0 +1,171 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdVAppVmStorageRelocation moves a VM and one of its template disks to another storage profile, and checks
+// that the VM is not recreated
+func TestAccVcdVAppVmStorageRelocation(t *testing.T) {
+	if !usingSysAdmin() {
+		t.Skip(t.Name() + " requires system admin privileges to create a VDC with two storage profiles")
+	}
+	if testConfig.VCD.ProviderVdc.StorageProfile == "" || testConfig.VCD.ProviderVdc.StorageProfile2 == "" {
+		t.Skip("Both variables testConfig.VCD.ProviderVdc.StorageProfile and testConfig.VCD.ProviderVdc.StorageProfile2 must be set")
+	}
+
+	var params = StringMap{
+		"Org":             testConfig.VCD.Org,
+		"VdcName":         "ForStorageRelocationTest",
+		"ProviderVdc":     testConfig.VCD.ProviderVdc.Name,
+		"NetworkPool":     testConfig.VCD.ProviderVdc.NetworkPool,
+		"StorageProfile1": testConfig.VCD.ProviderVdc.StorageProfile,
+		"StorageProfile2": testConfig.VCD.ProviderVdc.StorageProfile2,
+		"Catalog":         testSuiteCatalogName,
+		"CatalogItem":     testSuiteCatalogOVAItem,
+		"VappName":        "TestStorageRelocationVapp",
+		"VmName":          "TestStorageRelocationVm",
+		"VmStorage":       testConfig.VCD.ProviderVdc.StorageProfile,
+		"DiskStorage":     testConfig.VCD.ProviderVdc.StorageProfile,
+	}
+
+	configText := templateFill(testAccCheckVcdVAppVmStorageRelocation, params)
+	params["FuncName"] = t.Name() + "-update"
+	params["VmStorage"] = testConfig.VCD.ProviderVdc.StorageProfile2
+	params["DiskStorage"] = testConfig.VCD.ProviderVdc.StorageProfile2
+	configTextUpdate := templateFill(testAccCheckVcdVAppVmStorageRelocation, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resourceName := "vcd_vapp_vm." + params["VmName"].(string)
+	var vmId string
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccStoreResourceId(resourceName, &vmId),
+					resource.TestCheckResourceAttr(resourceName, "storage_profile", testConfig.VCD.ProviderVdc.StorageProfile),
+					resource.TestCheckResourceAttr(resourceName, "internal_disk.0.storage_profile", testConfig.VCD.ProviderVdc.StorageProfile),
+				),
+			},
+			resource.TestStep{
+				Config: configTextUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckResourceIdUnchanged(resourceName, &vmId),
+					resource.TestCheckResourceAttr(resourceName, "storage_profile", testConfig.VCD.ProviderVdc.StorageProfile2),
+					resource.TestCheckResourceAttr(resourceName, "internal_disk.0.storage_profile", testConfig.VCD.ProviderVdc.StorageProfile2),
+				),
+			},
+		},
+	})
+}
+
+// testAccStoreResourceId saves the ID of a resource, to be compared by testAccCheckResourceIdUnchanged
+func testAccStoreResourceId(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckResourceIdUnchanged checks that a resource was updated in place
+func testAccCheckResourceIdUnchanged(resourceName string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		if rs.Primary.ID != *id {
+			return fmt.Errorf("resource %s was recreated: ID changed from %s to %s", resourceName, *id, rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+const testAccCheckVcdVAppVmStorageRelocation = `
+resource "vcd_org_vdc" "{{.VdcName}}" {
+  org  = "{{.Org}}"
+  name = "{{.VdcName}}"
+
+  allocation_model  = "ReservationPool"
+  network_pool_name = "{{.NetworkPool}}"
+  provider_vdc_name = "{{.ProviderVdc}}"
+
+  compute_capacity {
+    cpu {
+      allocated = 1024
+      limit     = 1024
+    }
+
+    memory {
+      allocated = 1024
+      limit     = 1024
+    }
+  }
+
+  storage_profile {
+    name    = "{{.StorageProfile1}}"
+    enabled = true
+    limit   = 102400
+    default = true
+  }
+
+  storage_profile {
+    name    = "{{.StorageProfile2}}"
+    enabled = true
+    limit   = 102400
+    default = false
+  }
+
+  enabled                  = true
+  enable_thin_provisioning = true
+  enable_fast_provisioning = false
+  delete_force             = true
+  delete_recursive         = true
+}
+
+resource "vcd_vapp" "{{.VappName}}" {
+  org  = "{{.Org}}"
+  vdc  = vcd_org_vdc.{{.VdcName}}.name
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org             = "{{.Org}}"
+  vdc             = vcd_org_vdc.{{.VdcName}}.name
+  vapp_name       = vcd_vapp.{{.VappName}}.name
+  name            = "{{.VmName}}"
+  catalog_name    = "{{.Catalog}}"
+  template_name   = "{{.CatalogItem}}"
+  memory          = 512
+  cpus            = 1
+  cpu_cores       = 1
+  storage_profile = "{{.VmStorage}}"
+
+  override_template_disk {
+    bus_type        = "paravirtual"
+    size_in_mb      = 20000
+    bus_number      = 0
+    unit_number     = 0
+    iops            = 0
+    storage_profile = "{{.DiskStorage}}"
+  }
+}
+`
@@ -0,0 +1,140 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestGetVmPowerState(t *testing.T) {
+	statuses := map[string]string{
+		"POWERED_ON":            vmPowerStateOn,
+		"POWERED_OFF":           vmPowerStateOff,
+		"SUSPENDED":             vmPowerStateSuspended,
+		"PARTIALLY_POWERED_OFF": "partially_powered_off",
+		"UNRESOLVED":            "unresolved",
+	}
+	for status, expected := range statuses {
+		powerState := getVmPowerState(status)
+		if powerState != expected {
+			t.Errorf("status %s: expected power state '%s', got '%s'", status, expected, powerState)
+		}
+	}
+}
+
+func TestGetVmDesiredPowerState(t *testing.T) {
+	type powerStateTest struct {
+		config   map[string]interface{}
+		expected string
+	}
+	tests := []powerStateTest{
+		{map[string]interface{}{}, vmPowerStateOn},
+		{map[string]interface{}{"power_on": false}, ""},
+		{map[string]interface{}{"power_on": true, "power_state": "off"}, vmPowerStateOff},
+		{map[string]interface{}{"power_on": false, "power_state": "suspended"}, vmPowerStateSuspended},
+		{map[string]interface{}{"power_on": false, "power_state": "on"}, vmPowerStateOn},
+	}
+	for _, test := range tests {
+		d := schema.TestResourceDataRaw(t, vappVmSchema, test.config)
+		powerState := getVmDesiredPowerState(d)
+		if powerState != test.expected {
+			t.Errorf("config %v: expected desired power state '%s', got '%s'", test.config, test.expected, powerState)
+		}
+	}
+}
+
+func TestIsVmToolsRunning(t *testing.T) {
+	statuses := map[string]bool{
+		"toolsOk":           true,
+		"toolsOld":          true,
+		"TOOLS_OK":          true,
+		"toolsNotRunning":   false,
+		"toolsNotInstalled": false,
+		"":                  false,
+	}
+	for status, expected := range statuses {
+		if isVmToolsRunning(status) != expected {
+			t.Errorf("tools status '%s': expected running=%t", status, expected)
+		}
+	}
+}
+
+func TestHasGuestPropertyValue(t *testing.T) {
+	properties := &types.ProductSectionList{
+		ProductSection: &types.ProductSection{
+			Property: []*types.Property{
+				{Key: "ready", Value: &types.Value{Value: "yes"}},
+				{Key: "empty", Value: &types.Value{Value: ""}},
+				{Key: "no-value"},
+			},
+		},
+	}
+	type propertyTest struct {
+		key      string
+		value    string
+		expected bool
+	}
+	tests := []propertyTest{
+		{"ready", "yes", true},
+		{"ready", "no", false},
+		{"ready", "", true},
+		{"empty", "", false},
+		{"no-value", "", false},
+		{"missing", "", false},
+	}
+	for _, test := range tests {
+		if hasGuestPropertyValue(properties, test.key, test.value) != test.expected {
+			t.Errorf("property '%s' with value '%s': expected %t", test.key, test.value, test.expected)
+		}
+	}
+	if hasGuestPropertyValue(nil, "ready", "") {
+		t.Errorf("expected no match on empty properties")
+	}
+}
+
+func TestWaitForVmCondition(t *testing.T) {
+	err := waitForVmCondition(time.Second, func() (bool, error) { return true, nil })
+	if err != nil {
+		t.Errorf("unexpected error for condition already met: %s", err)
+	}
+	err = waitForVmCondition(time.Second, func() (bool, error) { return false, fmt.Errorf("check failed") })
+	if err == nil {
+		t.Errorf("expected the error of the condition to be returned")
+	}
+	err = waitForVmCondition(0, func() (bool, error) { return false, nil })
+	if err == nil {
+		t.Errorf("expected timeout error")
+	}
+}
+
+func TestGetGeneratedAdminPassword(t *testing.T) {
+	tests := map[string]struct {
+		section  types.GuestCustomizationSection
+		expected string
+	}{
+		"generated": {
+			section:  types.GuestCustomizationSection{AdminPasswordAuto: takeBoolPointer(true), AdminPassword: "generated"},
+			expected: "generated",
+		},
+		"not-yet-generated": {
+			section: types.GuestCustomizationSection{AdminPasswordAuto: takeBoolPointer(true)},
+		},
+		"set-by-user": {
+			section: types.GuestCustomizationSection{AdminPasswordAuto: takeBoolPointer(false), AdminPassword: "manual"},
+		},
+		"auto-not-set": {
+			section: types.GuestCustomizationSection{AdminPassword: "manual"},
+		},
+	}
+	for name, test := range tests {
+		password := getGeneratedAdminPassword(&test.section)
+		if password != test.expected {
+			t.Errorf("%s: expected password '%s', got '%s'", name, test.expected, password)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	mimeInstantiateVmTemplateParams = "application/vnd.vmware.vcloud.instantiateVmTemplateParams+xml"
+	mimeCreateVmParams              = "application/vnd.vmware.vcloud.CreateVmParams+xml"
+)
+
+// instantiateVmTemplateParams is the payload for the VDC action that creates a standalone VM from a VM template
+// Available since API 32.0
+type instantiateVmTemplateParams struct {
+	XMLName               xml.Name                 `xml:"InstantiateVmTemplateParams"`
+	Xmlns                 string                   `xml:"xmlns,attr"`
+	XmlnsOvf              string                   `xml:"xmlns:ovf,attr"`
+	Name                  string                   `xml:"name,attr"`
+	PowerOn               bool                     `xml:"powerOn,attr"`
+	Description           string                   `xml:"Description,omitempty"`
+	SourcedVmTemplateItem *sourcedVmTemplateParams `xml:"SourcedVmTemplateItem"`
+	AllEULAsAccepted      bool                     `xml:"AllEULAsAccepted,omitempty"`
+	ComputePolicy         *types.ComputePolicy     `xml:"ComputePolicy,omitempty"`
+}
+
+// sourcedVmTemplateParams defines the VM template used by instantiateVmTemplateParams
+type sourcedVmTemplateParams struct {
+	Source          *types.Reference       `xml:"Source"`
+	VmGeneralParams *types.VMGeneralParams `xml:"VmGeneralParams,omitempty"`
+	StorageProfile  *types.Reference       `xml:"StorageProfile,omitempty"`
+}
+
+// createVmParams is the payload for the VDC action that creates an empty standalone VM
+// Available since API 32.0
+type createVmParams struct {
+	XMLName     xml.Name          `xml:"CreateVmParams"`
+	Xmlns       string            `xml:"xmlns,attr"`
+	XmlnsOvf    string            `xml:"xmlns:ovf,attr"`
+	Name        string            `xml:"name,attr"`
+	PowerOn     bool              `xml:"powerOn,attr"`
+	Description string            `xml:"Description,omitempty"`
+	CreateVm    *types.CreateItem `xml:"CreateVm"`
+}
+
+// standaloneVmSchema returns the schema of vcd_vm, which is the same as vcd_vapp_vm, except for "vapp_name" and "vdc".
+// The vApp of a standalone VM is created by VCD, and its name is only reported
+func standaloneVmSchema() map[string]*schema.Schema {
+	vmSchema := make(map[string]*schema.Schema, len(vappVmSchema))
+	for key, value := range vappVmSchema {
+		vmSchema[key] = value
+	}
+	vmSchema["vapp_name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The vApp created by VCD to hold this standalone VM",
+	}
+	// A standalone VM can't move to another VDC, as its vApp is created by VCD
+	vmSchema["vdc"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		ForceNew:    true,
+		Description: "The name of VDC to use, optional if defined at provider level",
+	}
+	// Copying a VM needs a vApp to recompose, while the vApp of a standalone VM is created by VCD
+	delete(vmSchema, "source_vm_id")
+	return vmSchema
+}
+
+func resourceVcdStandaloneVm() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdStandaloneVmCreate,
+		Update: resourceVcdStandaloneVmUpdate,
+		Read:   resourceVcdStandaloneVmRead,
+		Delete: resourceVcdStandaloneVmDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVcdStandaloneVmImport,
+		},
+		CustomizeDiff: resourceVcdVmCustomizeDiff(standaloneVmType),
+		Schema:        standaloneVmSchema(),
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			stateUpgrader(0, resourceVcdStandaloneVmV0(), upgradeVmStateV0),
+		},
+	}
+}
+
+func resourceVcdStandaloneVmCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+	if vcdClient.Client.APIVCDMaxVersionIs("< 32.0") {
+		return fmt.Errorf("standalone VMs are only available for VCD 9.7+")
+	}
+	return genericResourceVmCreate(d, meta, standaloneVmType)
+}
+
+func resourceVcdStandaloneVmUpdate(d *schema.ResourceData, meta interface{}) error {
+	return genericResourceVmUpdate(d, meta, standaloneVmType)
+}
+
+func resourceVcdStandaloneVmRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdVAppVmRead(d, meta, "resource", standaloneVmType)
+}
+
+func resourceVcdStandaloneVmDelete(d *schema.ResourceData, meta interface{}) error {
+	return genericResourceVmDelete(d, meta, standaloneVmType)
+}
+
+// getStandaloneVm retrieves a VM from a VDC by name or ID, without knowing its vApp.
+// Standalone VMs are usually found by ID. A search by name fails if more than one VM in the VDC has that name
+func getStandaloneVm(vcdClient *VCDClient, vdc *govcd.Vdc, identifier string) (*govcd.VM, error) {
+	if identifier == "" {
+		return nil, fmt.Errorf("neither name or ID were provided for the VM")
+	}
+	if uuid := extractUuid(identifier); uuid != "" {
+		vm, err := getStandaloneVmById(vcdClient, vdc, importUrnPrefix+"vm:"+uuid)
+		if err == nil {
+			return vm, nil
+		}
+		// A name can look like an ID
+		log.Printf("[DEBUG] VM %s not found by ID, searching by name: %s", identifier, err)
+	}
+
+	queryType := vcdClient.Client.GetQueryType(types.QtVm)
+	filter := fmt.Sprintf("name==%s;vdc==%s;%s", url.QueryEscape(identifier), url.QueryEscape(vdc.Vdc.HREF),
+		types.VmQueryFilterOnlyDeployed.String())
+	results, err := vcdClient.Client.QueryWithNotEncodedParams(nil, map[string]string{
+		"type":          queryType,
+		"filter":        filter,
+		"filterEncoded": "true",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error querying VM %s in VDC %s: %s", identifier, vdc.Vdc.Name, err)
+	}
+	vmList := results.Results.VMRecord
+	if vcdClient.Client.IsSysAdmin {
+		vmList = results.Results.AdminVMRecord
+	}
+	if len(vmList) == 0 {
+		return nil, govcd.ErrorEntityNotFound
+	}
+	if len(vmList) > 1 {
+		return nil, fmt.Errorf("more than one VM found with name '%s' in VDC %s. Use the ID instead", identifier, vdc.Vdc.Name)
+	}
+	return vcdClient.Client.GetVMByHref(vmList[0].HREF)
+}
+
+// getStandaloneVmById retrieves a VM through its URN, and checks that it belongs to the VDC
+func getStandaloneVmById(vcdClient *VCDClient, vdc *govcd.Vdc, urn string) (*govcd.VM, error) {
+	href, err := getEntityHrefByUrn(vcdClient, urn)
+	if err != nil {
+		return nil, err
+	}
+	vm, err := vcdClient.Client.GetVMByHref(href)
+	if err != nil {
+		return nil, err
+	}
+	vapp, err := vm.GetParentVApp()
+	if err != nil {
+		return nil, err
+	}
+	for _, link := range vapp.VApp.Link {
+		if link.Rel == "up" && link.Type == types.MimeVDC && haveSameUuid(link.HREF, vdc.Vdc.HREF) {
+			return vm, nil
+		}
+	}
+	return nil, fmt.Errorf("VM %s is not in VDC %s", urn, vdc.Vdc.Name)
+}
+
+// createStandaloneVmFromTemplate creates a VM in a new vApp managed by VCD, using a VM template (or the first VM of
+// a vApp template) as source
+func createStandaloneVmFromTemplate(d *schema.ResourceData, vcdClient *VCDClient, vdc *govcd.Vdc, vappTemplate govcd.VAppTemplate,
+	storageProfile *types.Reference, sizingPolicy *types.VdcComputePolicy, acceptEulas bool) (*govcd.VM, error) {
+
+	if vappTemplate.VAppTemplate == nil {
+		return nil, fmt.Errorf("vApp Template can not be empty")
+	}
+	templateHref := vappTemplate.VAppTemplate.HREF
+	if vappTemplate.VAppTemplate.Children != nil && len(vappTemplate.VAppTemplate.Children.VM) != 0 {
+		templateHref = vappTemplate.VAppTemplate.Children.VM[0].HREF
+	}
+
+	vmName := d.Get("name").(string)
+	params := &instantiateVmTemplateParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		XmlnsOvf:    types.XMLNamespaceOVF,
+		Name:        vmName,
+		PowerOn:     false,
+		Description: d.Get("description").(string),
+		SourcedVmTemplateItem: &sourcedVmTemplateParams{
+			Source: &types.Reference{HREF: templateHref},
+			VmGeneralParams: &types.VMGeneralParams{
+				Name:        vmName,
+				Description: d.Get("description").(string),
+			},
+			StorageProfile: storageProfile,
+		},
+		AllEULAsAccepted: acceptEulas,
+	}
+
+	if sizingPolicy != nil {
+		vdcComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies, sizingPolicy.ID)
+		if err != nil {
+			return nil, fmt.Errorf("error constructing HREF for compute policy")
+		}
+		params.ComputePolicy = &types.ComputePolicy{VmSizingPolicy: &types.Reference{HREF: vdcComputePolicyHref.String()}}
+	}
+
+	log.Printf("[TRACE] Creating standalone VM %s from template %s", vmName, templateHref)
+	return createStandaloneVm(vcdClient, vdc, "instantiateVmFromTemplate", mimeInstantiateVmTemplateParams, params)
+}
+
+// createStandaloneEmptyVm creates an empty VM in a new vApp managed by VCD
+func createStandaloneEmptyVm(vcdClient *VCDClient, vdc *govcd.Vdc, createItem *types.CreateItem) (*govcd.VM, error) {
+	params := &createVmParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		XmlnsOvf:    types.XMLNamespaceOVF,
+		Name:        createItem.Name,
+		PowerOn:     false,
+		Description: createItem.Description,
+		CreateVm:    createItem,
+	}
+
+	log.Printf("[TRACE] Creating empty standalone VM %s", createItem.Name)
+	return createStandaloneVm(vcdClient, vdc, "createVm", mimeCreateVmParams, params)
+}
+
+// createStandaloneVm runs one of the VDC actions that create a standalone VM, waits for the task embedded in the
+// returned VM, and retrieves the new VM
+func createStandaloneVm(vcdClient *VCDClient, vdc *govcd.Vdc, action, contentType string, params interface{}) (*govcd.VM, error) {
+	newVm := govcd.NewVM(&vcdClient.Client)
+	_, err := vcdClient.Client.ExecuteRequestWithApiVersion(vdc.Vdc.HREF+"/action/"+action, http.MethodPost, contentType,
+		"error creating standalone VM: %s", params, newVm.VM,
+		vcdClient.Client.GetSpecificApiVersionOnCondition(">= 33.0", "33.0"))
+	if err != nil {
+		return nil, err
+	}
+
+	if newVm.VM.Tasks != nil {
+		for _, taskInProgress := range newVm.VM.Tasks.Task {
+			task := govcd.NewTask(&vcdClient.Client)
+			task.Task = taskInProgress
+			err = task.WaitTaskCompletion()
+			if err != nil {
+				return nil, fmt.Errorf(errorCompletingTask, err)
+			}
+		}
+	}
+
+	return vcdClient.Client.GetVMByHref(newVm.VM.HREF)
+}
+
+// addStandaloneVmOrgNetworks adds to the vApp of a standalone VM the Org VDC networks that its NICs need.
+// Standalone VMs can only use Org VDC networks, as there is no user defined vApp where vApp networks could exist
+func addStandaloneVmOrgNetworks(d *schema.ResourceData, vdc *govcd.Vdc, vapp *govcd.VApp) error {
+	vAppNetworkConfig, err := vapp.GetNetworkConfig()
+	if err != nil {
+		return fmt.Errorf("error getting vApp networks: %s", err)
+	}
+	existingNetworks := make(map[string]bool)
+	for _, networkConfig := range vAppNetworkConfig.NetworkConfig {
+		existingNetworks[networkConfig.NetworkName] = true
+	}
+
+	for _, singleNetwork := range d.Get("network").([]interface{}) {
+		nic := singleNetwork.(map[string]interface{})
+		networkName := nic["name"].(string)
+		switch nic["type"].(string) {
+		case "vapp":
+			return fmt.Errorf("standalone VMs can't use vApp networks: use an 'org' network instead of '%s'", networkName)
+		case "org":
+			if existingNetworks[networkName] {
+				continue
+			}
+			orgNetwork, err := vdc.GetOrgVdcNetworkByName(networkName, false)
+			if err != nil {
+				return fmt.Errorf("error retrieving Org VDC network %s: %s", networkName, err)
+			}
+			_, err = vapp.AddOrgNetwork(&govcd.VappNetworkSettings{}, orgNetwork.OrgVDCNetwork, false)
+			if err != nil {
+				return fmt.Errorf("error adding Org VDC network %s to the vApp of the VM: %s", networkName, err)
+			}
+			existingNetworks[networkName] = true
+		}
+	}
+	return nil
+}
+
+// resourceVcdStandaloneVmImport is responsible for importing the resource.
+// The following steps happen as part of import
+// 1. The user supplies `terraform import _resource_name_ _the_id_string_` command
+// 2. `_the_id_string_` contains a dot formatted path to resource as in the example below
+// 3. The functions splits the dot-formatted path and tries to lookup the object
+// 4. If the lookup succeeds it sets the ID field for `_resource_name_` resource in statefile
+// (the resource must be already defined in .tf config otherwise `terraform import` will complain)
+// 5. `terraform refresh` is being implicitly launched. The Read method looks up all other fields
+// based on the known ID of object.
+//
+// Example resource name (_resource_name_): vcd_vm.VM_name
+// Example import path (_the_id_string_): org-name.vdc-name.vm-name
+// Example import path (_the_id_string_): urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3
+func resourceVcdStandaloneVmImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	// A URN is expanded to org.vdc.vapp.vm, where the vApp is the one managed by VCD
+	if len(resourceURI) == 4 && isImportUrn(d.Id()) {
+		resourceURI = []string{resourceURI[0], resourceURI[1], resourceURI[3]}
+	}
+	if len(resourceURI) != 3 {
+		return nil, fmt.Errorf("[VM import] resource name must be specified as org-name.vdc-name.vm-name")
+	}
+	orgName, vdcName, vmName := resourceURI[0], resourceURI[1], resourceURI[2]
+
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf("[VM import] unable to find VDC %s: %s ", vdcName, err)
+	}
+
+	vm, err := getStandaloneVm(vcdClient, vdc, vmName)
+	if err != nil {
+		return nil, fmt.Errorf("[VM import] error retrieving VM %s: %s", vmName, err)
+	}
+	_ = d.Set("name", vm.VM.Name)
+	_ = d.Set("org", orgName)
+	_ = d.Set("vdc", vdcName)
+	d.SetId(vm.VM.ID)
+	return []*schema.ResourceData{d}, nil
+}
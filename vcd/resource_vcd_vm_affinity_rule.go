@@ -312,8 +312,15 @@ func resourceVcdVmAffinityRuleDelete(d *schema.ResourceData, meta interface{}) e
 // (3)
 //  terraform import vcd_vm_affinity_rule.unknown list@my-org.my-vdc.any_string
 // Returns an error with all the VM affinity rules (name + ID for each)
+// (4)
+//  terraform import vcd_vm_affinity_rule.unknown urn:vcloud:vdc:8b4a1a5c-e5b5-4ec4-86c6-4a8b0e2fd0d3.my-afr
+// The org and VDC are retrieved from the VDC URN
 func resourceVcdVmAffinityRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
 		return nil, fmt.Errorf("[VM affinity rule import] resource identifier must be specified as org.vdc.my-affinity-rule")
 	}
@@ -338,7 +345,6 @@ func resourceVcdVmAffinityRuleImport(d *schema.ResourceData, meta interface{}) (
 	}
 
 	lookingForId := govcd.IsUuid(affinityRuleIdentifier)
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
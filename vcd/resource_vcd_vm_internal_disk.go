@@ -20,6 +20,7 @@ func resourceVmInternalDisk() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: resourceVcdVmInternalDiskImport,
 		},
+		CustomizeDiff: customizeVmInternalDiskDiff,
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -31,14 +32,12 @@ func resourceVmInternalDisk() *schema.Resource {
 			"vdc": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
-				Description: "The name of VDC to use, optional if defined at provider level",
+				Description: "The name of VDC to use, optional if defined at provider level. Changing it follows the VM moved to another VDC",
 			},
 			"vapp_name": &schema.Schema{
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "The vApp this VM internal disk belongs to",
+				Description: "The vApp this VM internal disk belongs to. Changing it follows the VM moved to another vApp",
 			},
 			"vm_name": &schema.Schema{
 				Type:        schema.TypeString,
@@ -274,8 +273,9 @@ func resourceVmInternalDiskUpdate(d *schema.ResourceData, meta interface{}) erro
 	vcdClient.lockParentVm(d)
 	defer vcdClient.unLockParentVm(d)
 
-	// ignore only allow_vm_reboot change, allows to avoid empty update
-	if d.HasChange("allow_vm_reboot") && !d.HasChange("iops") && !d.HasChange("size_in_mb") && !d.HasChange("storage_profile") {
+	// ignore only allow_vm_reboot change, allows to avoid empty update. A change of "vapp_name" or "vdc" follows
+	// the VM moved by vcd_vapp_vm, and the disk is found in its new place
+	if !d.HasChange("iops") && !d.HasChange("size_in_mb") && !d.HasChange("storage_profile") {
 		return nil
 	}
 	vm, vdc, err := getVm(vcdClient, d)
@@ -318,7 +318,8 @@ func resourceVmInternalDiskUpdate(d *schema.ResourceData, meta interface{}) erro
 	diskSettingsToUpdate.StorageProfile = storageProfilePrt
 	diskSettingsToUpdate.OverrideVmDefault = overrideVmDefault
 
-	_, err = vm.UpdateInternalDisks(vm.VM.VmSpecSection)
+	// A change of storage profile relocates the disk, which can take a while
+	err = updateVmDisks(vm, vm.VM.VmSpecSection)
 	if err != nil {
 		return err
 	}
@@ -337,6 +338,11 @@ func resourceVmInternalDiskRead(d *schema.ResourceData, m interface{}) error {
 	vcdClient := m.(*VCDClient)
 
 	vm, _, err := getVm(vcdClient, d)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find VM %s. Removing internal disk from tfstate", d.Get("vm_name").(string))
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -365,7 +371,8 @@ func resourceVmInternalDiskRead(d *schema.ResourceData, m interface{}) error {
 }
 
 var errHelpInternalDiskImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'org-name.vdc-name.vapp-name.vm-name.my-internal-disk-id' to import by rule id
+'org-name.vdc-name.vapp-name.vm-name.my-internal-disk-id' to import by disk id
+'vm-urn.my-internal-disk-id' to import by disk id, using the VM URN
 'list@org-name.vdc-name.vapp-name.vm-name' to get a list of internal disks with their IDs`)
 
 // resourceVcdVmInternalDiskImport is responsible for importing the resource.
@@ -383,11 +390,16 @@ var errHelpInternalDiskImport = fmt.Errorf(`resource id must be specified in one
 //
 // Example resource name (_resource_name_): vcd_vm_internal_disk.my-disk
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name.vm-name.my-internal-disk-id
+// Example import path (_the_id_string_): urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3.my-internal-disk-id
 // Example list path (_the_id_string_): list@org-name.vdc-name.vapp-name.vm-name
 func resourceVcdVmInternalDiskImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, vappName, vmName, diskId string
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Printf("[DEBUG] importing vcd_vm_internal_disk resource with provided id %s", d.Id())
 
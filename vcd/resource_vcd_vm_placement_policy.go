@@ -0,0 +1,400 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// computePolicyGroupReference is the type of the VM group references in types.VdcComputePolicy
+type computePolicyGroupReference = struct {
+	Name string `json:"name,omitempty"`
+	ID   string `json:"id,omitempty"`
+}
+
+func resourceVcdVmPlacementPolicy() *schema.Resource {
+
+	return &schema.Resource{
+		Create: resourceVmPlacementPolicyCreate,
+		Delete: resourceVmPlacementPolicyDelete,
+		Read:   resourceVmPlacementPolicyRead,
+		Update: resourceVmPlacementPolicyUpdate,
+		Importer: &schema.ResourceImporter{
+			State: resourceVmPlacementPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the VM placement policy",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Description of the VM placement policy",
+			},
+			"provider_vdc_id": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Provider VDC where the VM groups of the policy are",
+			},
+			"vm_group_ids": &schema.Schema{
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"vm_group_ids", "logical_vm_group_ids"},
+				Description:  "IDs of the VM groups, which pin the VMs to the hosts of a cluster",
+			},
+			"logical_vm_group_ids": &schema.Schema{
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"vm_group_ids", "logical_vm_group_ids"},
+				Description:  "IDs of the logical VM groups, which combine VM groups across clusters",
+			},
+		},
+	}
+}
+
+func resourceVmPlacementPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy creation initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	if !vcdClient.Client.IsSysAdmin {
+		return fmt.Errorf("functionality requires System administrator privileges")
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	params := &types.VdcComputePolicy{
+		Name:        policyName,
+		Description: d.Get("description").(string),
+		PvdcID:      d.Get("provider_vdc_id").(string),
+	}
+	setVmPlacementPolicyGroups(d, params)
+
+	log.Printf("[DEBUG] Creating VM placement policy: %#v", params)
+
+	createdVmPlacementPolicy, err := adminOrg.CreateVdcComputePolicy(params)
+	if err != nil {
+		log.Printf("[DEBUG] Error creating VM placement policy: %s", err)
+		return fmt.Errorf("error creating VM placement policy: %s", err)
+	}
+
+	d.SetId(createdVmPlacementPolicy.VdcComputePolicy.ID)
+	log.Printf("[TRACE] VM placement policy created: %#v", createdVmPlacementPolicy.VdcComputePolicy)
+
+	return resourceVmPlacementPolicyRead(d, meta)
+}
+
+func resourceVmPlacementPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	return genericVcdVmPlacementPolicyRead(d, meta)
+}
+
+// genericVcdVmPlacementPolicyRead reads a VM placement policy by ID, or by name for a data source
+func genericVcdVmPlacementPolicyRead(d *schema.ResourceData, meta interface{}) error {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy read initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	orgName := d.Get("org").(string)
+	if orgName == "" {
+		orgName = vcdClient.Org
+	}
+	if orgName == "" {
+		return fmt.Errorf("empty Org name provided")
+	}
+	org, err := vcdClient.VCDClient.GetOrgByName(orgName)
+	if err != nil {
+		return fmt.Errorf("error retrieving Org %s: %s", orgName, err)
+	}
+
+	var policy *govcd.VdcComputePolicy
+	if d.Id() != "" {
+		policy, err = org.GetVdcComputePolicyById(d.Id())
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Unable to find VM placement policy %s. Removing from tfstate.", policyName)
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to find VM placement policy %s, err: %s", policyName, err)
+		}
+	} else {
+		if policyName == "" {
+			return fmt.Errorf("both name and ID are empty")
+		}
+		policy, err = getVmPlacementPolicyByName(org, policyName)
+		if err != nil {
+			return err
+		}
+		d.SetId(policy.VdcComputePolicy.ID)
+	}
+
+	if !isVmPlacementPolicy(policy.VdcComputePolicy) {
+		return fmt.Errorf("compute policy %s is not a VM placement policy", policy.VdcComputePolicy.Name)
+	}
+
+	return setVmPlacementPolicyData(d, policy.VdcComputePolicy)
+}
+
+// getVmPlacementPolicyByName retrieves a VM placement policy by name. Sizing policies with the same name are ignored
+func getVmPlacementPolicyByName(org *govcd.Org, policyName string) (*govcd.VdcComputePolicy, error) {
+	queryParams := url.Values{}
+	queryParams.Add("filter", "name=="+policyName)
+	policies, err := org.GetAllVdcComputePolicies(queryParams)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find VM placement policy %s, err: %s", policyName, err)
+	}
+	var found []*govcd.VdcComputePolicy
+	for _, policy := range policies {
+		if isVmPlacementPolicy(policy.VdcComputePolicy) {
+			found = append(found, policy)
+		}
+	}
+	if len(found) != 1 {
+		return nil, fmt.Errorf("unable to find VM placement policy %s, err: %s. Found policies by name: %d",
+			policyName, govcd.ErrorEntityNotFound, len(found))
+	}
+	return found[0], nil
+}
+
+// isVmPlacementPolicy returns true if the compute policy places VMs through VM groups, as opposed to a sizing policy
+func isVmPlacementPolicy(policy *types.VdcComputePolicy) bool {
+	return len(policy.NamedVMGroups) > 0 || len(policy.LogicalVMGroupReferences) > 0
+}
+
+// setVmPlacementPolicyGroups sets the VM groups of the configuration into the policy
+func setVmPlacementPolicyGroups(d *schema.ResourceData, policy *types.VdcComputePolicy) {
+	policy.NamedVMGroups = nil
+	vmGroupIds := convertSchemaSetToSliceOfStrings(d.Get("vm_group_ids").(*schema.Set))
+	if len(vmGroupIds) > 0 {
+		var vmGroups []computePolicyGroupReference
+		for _, id := range vmGroupIds {
+			vmGroups = append(vmGroups, computePolicyGroupReference{ID: id})
+		}
+		policy.NamedVMGroups = [][]computePolicyGroupReference{vmGroups}
+	}
+
+	policy.LogicalVMGroupReferences = nil
+	for _, id := range convertSchemaSetToSliceOfStrings(d.Get("logical_vm_group_ids").(*schema.Set)) {
+		policy.LogicalVMGroupReferences = append(policy.LogicalVMGroupReferences, computePolicyGroupReference{ID: id})
+	}
+}
+
+// setVmPlacementPolicyData sets object state from a VM placement policy
+func setVmPlacementPolicyData(d *schema.ResourceData, policy *types.VdcComputePolicy) error {
+	_ = d.Set("name", policy.Name)
+	_ = d.Set("description", policy.Description)
+	_ = d.Set("provider_vdc_id", policy.PvdcID)
+
+	var vmGroupIds []string
+	for _, vmGroups := range policy.NamedVMGroups {
+		for _, vmGroup := range vmGroups {
+			vmGroupIds = append(vmGroupIds, vmGroup.ID)
+		}
+	}
+	err := d.Set("vm_group_ids", convertToTypeSet(vmGroupIds))
+	if err != nil {
+		return err
+	}
+
+	var logicalVmGroupIds []string
+	for _, logicalVmGroup := range policy.LogicalVMGroupReferences {
+		logicalVmGroupIds = append(logicalVmGroupIds, logicalVmGroup.ID)
+	}
+	err = d.Set("logical_vm_group_ids", convertToTypeSet(logicalVmGroupIds))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[TRACE] VM placement policy read completed: %s", policy.Name)
+	return nil
+}
+
+func resourceVmPlacementPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy update initiated: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	policy, err := adminOrg.GetVdcComputePolicyById(d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] Unable to find VM placement policy %s", policyName)
+		return fmt.Errorf("unable to find VM placement policy %s, error:  %s", policyName, err)
+	}
+
+	policy.VdcComputePolicy.Name = policyName
+	policy.VdcComputePolicy.Description = d.Get("description").(string)
+	if d.HasChanges("vm_group_ids", "logical_vm_group_ids") {
+		setVmPlacementPolicyGroups(d, policy.VdcComputePolicy)
+	}
+
+	_, err = policy.Update()
+	if err != nil {
+		log.Printf("[DEBUG] Error updating VM placement policy %s with error %s", policyName, err)
+		return fmt.Errorf("error updating VM placement policy %s, err: %s", policyName, err)
+	}
+
+	log.Printf("[TRACE] VM placement policy update completed: %s", policyName)
+	return resourceVmPlacementPolicyRead(d, meta)
+}
+
+func resourceVmPlacementPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+	policyName := d.Get("name").(string)
+	log.Printf("[TRACE] VM placement policy delete started: %s", policyName)
+
+	vcdClient := meta.(*VCDClient)
+
+	if !vcdClient.Client.IsSysAdmin {
+		return fmt.Errorf("functionality requires System administrator privileges")
+	}
+
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	policy, err := adminOrg.GetVdcComputePolicyById(d.Id())
+	if err != nil {
+		log.Printf("[DEBUG] Unable to find VM placement policy %s. Removing from tfstate", policyName)
+		d.SetId("")
+		return nil
+	}
+
+	err = policy.Delete()
+	if err != nil {
+		log.Printf("[DEBUG] Error removing VM placement policy %s, err: %s", policyName, err)
+		return fmt.Errorf("error removing VM placement policy %s, err: %s", policyName, err)
+	}
+
+	log.Printf("[TRACE] VM placement policy delete completed: %s", policyName)
+	return nil
+}
+
+var errHelpVmPlacementPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
+'org-name.vm-placement-policy-name', 'org-id.vm-placement-policy-id', 'vm-placement-policy-id' or 'list@org-name' to get a list of VM placement policies with their IDs`)
+
+// resourceVmPlacementPolicyImport is responsible for importing the resource.
+//
+// Example resource name (_resource_name_): vcd_vm_placement_policy.my_existing_policy_name
+// Example import path (_the_id_string_): org.my_existing_vm_placement_policy_name
+// Example import path (_the_id_string_): urn:vcloud:vdcComputePolicy:d7b6e1b4-5e3b-4b8c-9c3a-8f1e2d3c4b5a
+// Example list path (_the_id_string_): list@org-name
+// Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
+func resourceVmPlacementPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+
+	// As for VM sizing policies, a policy URN is looked up using the provider Org
+	if urnType, _ := getUrnEntityType(d.Id()); urnType == "vdcComputePolicy" {
+		return getVmPlacementPolicyForImport(d, meta, vcdClient.Org, d.Id())
+	}
+
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("[DEBUG] importing VM placement policy resource with provided id %s", d.Id())
+
+	if len(resourceURI) == 1 && strings.Contains(d.Id(), "list@") {
+		commandOrgNameSplit := strings.Split(resourceURI[0], "@")
+		if len(commandOrgNameSplit) != 2 {
+			return nil, errHelpVmPlacementPolicyImport
+		}
+		return listVmPlacementPoliciesForImport(meta, commandOrgNameSplit[1])
+	}
+	if len(resourceURI) != 2 {
+		return nil, errHelpVmPlacementPolicyImport
+	}
+	return getVmPlacementPolicyForImport(d, meta, resourceURI[0], resourceURI[1])
+}
+
+func getVmPlacementPolicyForImport(d *schema.ResourceData, meta interface{}, orgId, policyId string) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+
+	org, err := vcdClient.VCDClient.GetOrgByNameOrId(orgId)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrg, err)
+	}
+
+	policy, err := org.GetVdcComputePolicyById(policyId)
+	if err != nil {
+		policy, err = getVmPlacementPolicyByName(org, policyId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !isVmPlacementPolicy(policy.VdcComputePolicy) {
+		return nil, fmt.Errorf("compute policy %s is not a VM placement policy", policy.VdcComputePolicy.Name)
+	}
+
+	if vcdClient.Org != org.Org.Name && vcdClient.Org != org.Org.ID {
+		_ = d.Set("org", org.Org.Name)
+	}
+
+	_ = d.Set("name", policy.VdcComputePolicy.Name)
+	d.SetId(policy.VdcComputePolicy.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func listVmPlacementPoliciesForImport(meta interface{}, orgId string) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	org, err := vcdClient.VCDClient.GetOrgByNameOrId(orgId)
+	if err != nil {
+		return nil, fmt.Errorf("[listVmPlacementPoliciesForImport] unable to find Org %s: %s ", orgId, err)
+	}
+
+	stdout := getTerraformStdout()
+	_, _ = fmt.Fprintln(stdout, "Retrieving all VM placement policies")
+	policies, err := org.GetAllVdcComputePolicies(nil)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve VM placement policies: %s", err)
+	}
+
+	writer := tabwriter.NewWriter(stdout, 0, 8, 1, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintln(writer, "No\tID\tName\t")
+	fmt.Fprintln(writer, "--\t--\t----\t")
+
+	index := 0
+	for _, policy := range policies {
+		if !isVmPlacementPolicy(policy.VdcComputePolicy) {
+			continue
+		}
+		index++
+		fmt.Fprintf(writer, "%d\t%s\t%s\n", index, policy.VdcComputePolicy.ID, policy.VdcComputePolicy.Name)
+	}
+	err = writer.Flush()
+	if err != nil {
+		return nil, fmt.Errorf("unable to write to stdout: %s", err)
+	}
+
+	return nil, fmt.Errorf("resource was not imported! %s", errHelpVmPlacementPolicyImport)
+}
@@ -0,0 +1,143 @@
+// +build vdc ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+func TestAccVcdVmPlacementPolicy(t *testing.T) {
+	if !usingSysAdmin() {
+		t.Skip("TestAccVcdVmPlacementPolicy requires system admin privileges")
+	}
+
+	if testConfig.VCD.ProviderVdc.Name == "" || testConfig.VCD.ProviderVdc.VmGroupId == "" {
+		t.Skip("Variables providerVdc.Name and providerVdc.vmGroupId must be set to run VM placement policy tests")
+	}
+
+	var params = StringMap{
+		"OrgName":     testConfig.VCD.Org,
+		"PolicyName":  t.Name(),
+		"Description": t.Name() + "Description",
+		"VmGroupId":   testConfig.VCD.ProviderVdc.VmGroupId,
+		"FuncName":    t.Name(),
+	}
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	vcdClient, err := getTestVCDFromJson(testConfig)
+	if err != nil {
+		t.Fatalf("error getting client: %s", err)
+	}
+	err = ProviderAuthenticate(vcdClient, testConfig.Provider.User, testConfig.Provider.Password, testConfig.Provider.Token, testConfig.Provider.SysOrg)
+	if err != nil {
+		t.Fatalf("authentication error: %s", err)
+	}
+	providerVdcs, err := govcd.QueryProviderVdcByName(vcdClient, testConfig.VCD.ProviderVdc.Name)
+	if err != nil || len(providerVdcs) == 0 {
+		t.Fatalf("error retrieving provider VDC %s: %v", testConfig.VCD.ProviderVdc.Name, err)
+	}
+	providerVdcUuid, err := govcd.GetUuidFromHref(providerVdcs[0].HREF, true)
+	if err != nil {
+		t.Fatalf("error getting provider VDC ID: %s", err)
+	}
+	params["ProviderVdcId"] = "urn:vcloud:providervdc:" + providerVdcUuid
+
+	configText := templateFill(testAccCheckVmPlacementPolicy_basic, params)
+	params["FuncName"] = t.Name() + "-Update"
+	params["Description"] = t.Name() + "DescriptionUpdated"
+	updateText := templateFill(testAccCheckVmPlacementPolicy_basic, params)
+	params["FuncName"] = t.Name() + "-DataSource"
+	dataSourceText := templateFill(testAccCheckVmPlacementPolicy_basic+testAccVmPlacementPolicyDataSource, params)
+	debugPrintf("#[DEBUG] CONFIGURATION - creation: %s", configText)
+	debugPrintf("#[DEBUG] CONFIGURATION - update: %s", updateText)
+	debugPrintf("#[DEBUG] CONFIGURATION - data source: %s", dataSourceText)
+
+	resourceName := "vcd_vm_placement_policy." + params["PolicyName"].(string)
+	datasourceName := "data.vcd_vm_placement_policy.data-source"
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVmPlacementPolicyDestroyed(params["PolicyName"].(string)),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVmSizingPolicyExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "name", params["PolicyName"].(string)),
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"Description"),
+					resource.TestCheckResourceAttr(resourceName, "provider_vdc_id", params["ProviderVdcId"].(string)),
+					resource.TestCheckResourceAttr(resourceName, "vm_group_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr(resourceName, "vm_group_ids.*", params["VmGroupId"].(string)),
+				),
+			},
+			resource.TestStep{
+				Config: updateText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "description", t.Name()+"DescriptionUpdated"),
+				),
+			},
+			resource.TestStep{
+				Config: dataSourceText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(datasourceName, "id", resourceName, "id"),
+					resource.TestCheckResourceAttrPair(datasourceName, "description", resourceName, "description"),
+					resource.TestCheckResourceAttrPair(datasourceName, "provider_vdc_id", resourceName, "provider_vdc_id"),
+					resource.TestCheckResourceAttr(datasourceName, "vm_group_ids.#", "1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgObject(testConfig, params["PolicyName"].(string)),
+			},
+		},
+	})
+}
+
+func testAccCheckVmPlacementPolicyDestroyed(policyName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*VCDClient)
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "vcd_vm_placement_policy" || rs.Primary.Attributes["name"] != policyName {
+				continue
+			}
+
+			adminOrg, err := conn.GetAdminOrg(testConfig.VCD.Org)
+			if err != nil {
+				return fmt.Errorf(errorRetrievingOrg, testConfig.VCD.Org+" and error: "+err.Error())
+			}
+
+			_, err = adminOrg.GetVdcComputePolicyById(rs.Primary.ID)
+			if err == nil {
+				return fmt.Errorf("VM placement policy %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+const testAccCheckVmPlacementPolicy_basic = `
+resource "vcd_vm_placement_policy" "{{.PolicyName}}" {
+  org             = "{{.OrgName}}"
+  name            = "{{.PolicyName}}"
+  description     = "{{.Description}}"
+  provider_vdc_id = "{{.ProviderVdcId}}"
+  vm_group_ids    = ["{{.VmGroupId}}"]
+}
+`
+
+const testAccVmPlacementPolicyDataSource = `
+data "vcd_vm_placement_policy" "data-source" {
+  name = vcd_vm_placement_policy.{{.PolicyName}}.name
+}
+`
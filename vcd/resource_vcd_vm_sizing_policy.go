@@ -198,10 +198,13 @@ func genericVcdVmSizingPolicyRead(d *schema.ResourceData, meta interface{}) erro
 	var policy *govcd.VdcComputePolicy
 	if d.Id() != "" {
 		policy, err = org.GetVdcComputePolicyById(d.Id())
-		if err != nil {
+		if govcd.ContainsNotFound(err) {
 			log.Printf("[DEBUG] Unable to find VM sizing policy %s. Removing from tfstate.", policyName)
 			d.SetId("")
-			return fmt.Errorf("unable to find VM sizing policy %s, err: %s. Removing from tfstate", policyName, err)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to find VM sizing policy %s, err: %s", policyName, err)
 		}
 	}
 
@@ -516,7 +519,7 @@ func getMemoryInput(memoryPart []interface{}, params *types.VdcComputePolicy) (*
 }
 
 var errHelpVmSizingPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'org-name.vm-sizing-policy-name', 'org-id.vm-sizing-policy-id' or 'list@org-name' to get a list of VM sizing policies with their IDs`)
+'org-name.vm-sizing-policy-name', 'org-id.vm-sizing-policy-id', 'vm-sizing-policy-id' or 'list@org-name' to get a list of VM sizing policies with their IDs`)
 
 // resourceVmSizingPolicyImport is responsible for importing the resource.
 // The following steps happen as part of import
@@ -530,16 +533,31 @@ var errHelpVmSizingPolicyImport = fmt.Errorf(`resource id must be specified in o
 //
 // Example resource name (_resource_name_): vcd_vm_sizing_policy.my_existing_policy_name
 // Example import path (_the_id_string_): org.my_existing_vm_sizing_policy_id
+// Example import path (_the_id_string_): urn:vcloud:vdcComputePolicy:d7b6e1b4-5e3b-4b8c-9c3a-8f1e2d3c4b5a
 // Example list path (_the_id_string_): list@org-name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVmSizingPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+
+	// VM sizing policies are not contained in an Org: when only the policy URN is given,
+	// the policy is looked up using the provider Org
+	if urnType, _ := getUrnEntityType(d.Id()); urnType == "vdcComputePolicy" {
+		return getVmSizingPolicy(d, meta, vcdClient.Org, d.Id())
+	}
+
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Printf("[DEBUG] importing VM sizing policy resource with provided id %s", d.Id())
 
 	if len(resourceURI) != 1 && len(resourceURI) != 2 {
 		return nil, errHelpVmSizingPolicyImport
 	}
+	if len(resourceURI) == 1 && !strings.Contains(d.Id(), "list@") {
+		return nil, errHelpVmSizingPolicyImport
+	}
 	if strings.Contains(d.Id(), "list@") {
 		commandOrgName := resourceURI[0]
 		commandOrgNameSplit := strings.Split(commandOrgName, "@")
@@ -0,0 +1,314 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+const mimeCreateSnapshotParams = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+
+// createSnapshotParams is the payload for the VM action that creates a snapshot
+type createSnapshotParams struct {
+	XMLName     xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Name        string   `xml:"name,attr,omitempty"`
+	Memory      bool     `xml:"memory,attr"`
+	Quiesce     bool     `xml:"quiesce,attr"`
+	Description string   `xml:"Description,omitempty"`
+}
+
+func resourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVcdVmSnapshotCreate,
+		Read:   resourceVcdVmSnapshotRead,
+		Update: resourceVcdVmSnapshotUpdate,
+		Delete: resourceVcdVmSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVcdVmSnapshotImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The vApp the VM belongs to",
+			},
+			"vm_name": &schema.Schema{
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The VM to take the snapshot of",
+			},
+			"name": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Name of the snapshot",
+			},
+			"description": &schema.Schema{
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Description of the snapshot",
+			},
+			"memory": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Include the memory of a powered on VM in the snapshot",
+			},
+			"quiesce": &schema.Schema{
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Quiesce the file system of the VM before taking the snapshot. Requires VMware Tools",
+			},
+			"revert_trigger": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Any change to this value, except setting it to an empty string, reverts the VM " +
+					"to the snapshot",
+			},
+			"created": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation date of the snapshot",
+			},
+			"size": &schema.Schema{
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size of the snapshot in bytes",
+			},
+			"powered_on": &schema.Schema{
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the VM was powered on when the snapshot was taken",
+			},
+		},
+	}
+}
+
+// resourceVcdVmSnapshotCreate takes a snapshot of the VM.
+// VCD keeps only one snapshot per VM: taking a new one replaces the existing one
+func resourceVcdVmSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVm(d)
+	defer vcdClient.unLockParentVm(d)
+
+	vm, _, err := getVm(vcdClient, d)
+	if err != nil {
+		return err
+	}
+
+	if getVmSnapshot(vm) != nil {
+		return fmt.Errorf("VM %s already has a snapshot. Import it or remove it before creating a new one", vm.VM.Name)
+	}
+
+	params := &createSnapshotParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        d.Get("name").(string),
+		Memory:      d.Get("memory").(bool),
+		Quiesce:     d.Get("quiesce").(bool),
+		Description: d.Get("description").(string),
+	}
+	err = executeVmSnapshotAction(vcdClient, vm, "createSnapshot", mimeCreateSnapshotParams, params)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(vm.VM.ID)
+	log.Printf("[TRACE] snapshot of VM %s created", vm.VM.Name)
+	return resourceVcdVmSnapshotRead(d, meta)
+}
+
+func resourceVcdVmSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vm, _, err := getVm(vcdClient, d)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] Unable to find VM %s. Removing snapshot from tfstate", d.Get("vm_name").(string))
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	snapshot := getVmSnapshot(vm)
+	if snapshot == nil {
+		log.Printf("[DEBUG] VM %s has no snapshot. Removing from tfstate", vm.VM.Name)
+		d.SetId("")
+		return nil
+	}
+
+	setVmSnapshotData(d, snapshot)
+	d.SetId(vm.VM.ID)
+	return nil
+}
+
+// resourceVcdVmSnapshotUpdate reverts the VM to its snapshot when revert_trigger changes.
+// All the other fields force a new snapshot
+func resourceVcdVmSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	if d.HasChange("revert_trigger") && d.Get("revert_trigger").(string) != "" {
+		vcdClient.lockParentVm(d)
+		defer vcdClient.unLockParentVm(d)
+
+		vm, _, err := getVm(vcdClient, d)
+		if err != nil {
+			return err
+		}
+		if getVmSnapshot(vm) == nil {
+			return fmt.Errorf("VM %s has no snapshot to revert to", vm.VM.Name)
+		}
+		err = executeVmSnapshotAction(vcdClient, vm, "revertToCurrentSnapshot", "", nil)
+		if err != nil {
+			return err
+		}
+		log.Printf("[TRACE] VM %s reverted to its snapshot", vm.VM.Name)
+	}
+
+	return resourceVcdVmSnapshotRead(d, meta)
+}
+
+func resourceVcdVmSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVm(d)
+	defer vcdClient.unLockParentVm(d)
+
+	vm, _, err := getVm(vcdClient, d)
+	if govcd.ContainsNotFound(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if getVmSnapshot(vm) != nil {
+		err = executeVmSnapshotAction(vcdClient, vm, "removeAllSnapshots", "", nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[TRACE] snapshot of VM %s removed", vm.VM.Name)
+	d.SetId("")
+	return nil
+}
+
+// resourceVcdVmSnapshotImport imports the snapshot of a VM.
+// Example import path (_the_id_string_): org-name.vdc-name.vapp-name.vm-name
+// Example import path (_the_id_string_): urn:vcloud:vm:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3
+func resourceVcdVmSnapshotImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := splitImportPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified in such way org-name.vdc-name.vapp-name.vm-name")
+	}
+	orgName, vdcName, vappName, vmName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	vapp, err := vdc.GetVAppByNameOrId(vappName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp %s: %s", vappName, err)
+	}
+	vm, err := vapp.GetVMByNameOrId(vmName, false)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VM %s: %s", vmName, err)
+	}
+	if getVmSnapshot(vm) == nil {
+		return nil, fmt.Errorf("VM %s has no snapshot", vm.VM.Name)
+	}
+
+	_ = d.Set("org", orgName)
+	_ = d.Set("vdc", vdcName)
+	_ = d.Set("vapp_name", vapp.VApp.Name)
+	_ = d.Set("vm_name", vm.VM.Name)
+	_ = d.Set("memory", false)
+	_ = d.Set("quiesce", false)
+	d.SetId(vm.VM.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getVmSnapshot returns the snapshot of a VM, or nil if the VM has none
+func getVmSnapshot(vm *govcd.VM) *types.SnapshotItem {
+	if vm.VM.Snapshots == nil || len(vm.VM.Snapshots.Snapshot) == 0 {
+		return nil
+	}
+	return vm.VM.Snapshots.Snapshot[0]
+}
+
+func setVmSnapshotData(d *schema.ResourceData, snapshot *types.SnapshotItem) {
+	_ = d.Set("created", snapshot.Created)
+	_ = d.Set("size", snapshot.Size)
+	_ = d.Set("powered_on", snapshot.PoweredOn)
+}
+
+// executeVmSnapshotAction runs one of the snapshot actions of a VM, waits for its task and refreshes the VM
+func executeVmSnapshotAction(vcdClient *VCDClient, vm *govcd.VM, action, contentType string, payload interface{}) error {
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/"+action, http.MethodPost, contentType,
+		"error running "+action+" on VM "+vm.VM.Name+": %s", payload)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for %s on VM %s: %s", action, vm.VM.Name, err)
+	}
+	return vm.Refresh()
+}
+
+// vmSnapshotBlockedFields are the VM properties that VCD doesn't allow to change while the VM has a snapshot. A change
+// of "storage_profile" relocates the VM, and a change of "vapp_name" moves it to another vApp
+var vmSnapshotBlockedFields = []string{"disk", "override_template_disk", "hardware_version", "storage_profile",
+	"disk_controller", "vapp_name"}
+
+// checkVmSnapshotConstraints returns an error when the configuration changes VM properties that VCD doesn't allow
+// to change while the VM has a snapshot
+func checkVmSnapshotConstraints(d *schema.ResourceData, vm *govcd.VM) error {
+	if getVmSnapshot(vm) == nil {
+		return nil
+	}
+	var blocked []string
+	for _, field := range vmSnapshotBlockedFields {
+		if d.HasChange(field) {
+			blocked = append(blocked, field)
+		}
+	}
+	if len(blocked) > 0 {
+		return fmt.Errorf("VM %s has a snapshot and VCD does not allow changing %v while it exists. "+
+			"Remove the snapshot (vcd_vm_snapshot) before applying this change", vm.VM.Name, blocked)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+// +build vapp vm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestAccVcdVmSnapshot takes a snapshot of a VM, reads it with the data source, reverts the VM to it and imports it
+func TestAccVcdVmSnapshot(t *testing.T) {
+	var (
+		vappName = t.Name() + "-vapp"
+		vmName   = t.Name() + "-vm"
+	)
+
+	var params = StringMap{
+		"Org":           testConfig.VCD.Org,
+		"Vdc":           testConfig.VCD.Vdc,
+		"Catalog":       testSuiteCatalogName,
+		"CatalogItem":   testSuiteCatalogOVAItem,
+		"VappName":      vappName,
+		"VmName":        vmName,
+		"RevertTrigger": "",
+		"Tags":          "vapp vm",
+	}
+
+	configText := templateFill(testAccCheckVcdVmSnapshot, params)
+	params["FuncName"] = t.Name() + "-revert"
+	params["RevertTrigger"] = "revert-1"
+	configTextRevert := templateFill(testAccCheckVcdVmSnapshot, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdVAppVmDestroy(vappName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVmSnapshotExists("vcd_vm_snapshot.snap", vappName, vmName),
+					resource.TestCheckResourceAttr("vcd_vm_snapshot.snap", "name", "before-upgrade"),
+					resource.TestMatchResourceAttr("vcd_vm_snapshot.snap", "created", regexp.MustCompile(`.+`)),
+					resource.TestCheckResourceAttr("vcd_vm_snapshot.snap", "powered_on", "true"),
+					resource.TestCheckResourceAttrPair("data.vcd_vm_snapshot.snap", "created", "vcd_vm_snapshot.snap", "created"),
+					resource.TestCheckResourceAttrPair("data.vcd_vm_snapshot.snap", "size", "vcd_vm_snapshot.snap", "size"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextRevert,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdVmSnapshotExists("vcd_vm_snapshot.snap", vappName, vmName),
+					resource.TestCheckResourceAttr("vcd_vm_snapshot.snap", "revert_trigger", "revert-1"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "vcd_vm_snapshot.snap",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdVappObject(testConfig, vappName, vmName),
+				// These fields are not returned by VCD
+				ImportStateVerifyIgnore: []string{"name", "description", "memory", "quiesce", "revert_trigger"},
+			},
+		},
+	})
+}
+
+func testAccCheckVcdVmSnapshotExists(resourceName, vappName, vmName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no snapshot ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*VCDClient)
+		_, vdc, err := conn.GetOrgAndVdc(testConfig.VCD.Org, testConfig.VCD.Vdc)
+		if err != nil {
+			return fmt.Errorf(errorRetrievingVdcFromOrg, testConfig.VCD.Vdc, testConfig.VCD.Org, err)
+		}
+		vapp, err := vdc.GetVAppByName(vappName, false)
+		if err != nil {
+			return err
+		}
+		vm, err := vapp.GetVMByName(vmName, false)
+		if err != nil {
+			return err
+		}
+		if getVmSnapshot(vm) == nil {
+			return fmt.Errorf("VM %s has no snapshot", vmName)
+		}
+		return nil
+	}
+}
+
+const testAccCheckVcdVmSnapshot = `
+resource "vcd_vapp" "{{.VappName}}" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "{{.VmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.{{.VappName}}.name
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1024
+  cpus          = 1
+  cpu_cores     = 1
+}
+
+resource "vcd_vm_snapshot" "snap" {
+  org            = "{{.Org}}"
+  vdc            = "{{.Vdc}}"
+  vapp_name      = vcd_vapp_vm.{{.VmName}}.vapp_name
+  vm_name        = vcd_vapp_vm.{{.VmName}}.name
+  name           = "before-upgrade"
+  description    = "snapshot taken by {{.FuncName}}"
+  memory         = false
+  revert_trigger = "{{.RevertTrigger}}"
+}
+
+data "vcd_vm_snapshot" "snap" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vm_snapshot.snap.vapp_name
+  vm_name   = vcd_vm_snapshot.snap.vm_name
+}
+`
@@ -0,0 +1,233 @@
+// +build vm standaloneVm ALL functional
+
+package vcd
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+)
+
+// TestAccVcdStandaloneVm creates a standalone VM from a template and an empty one, both connected to an Org VDC
+// network, and checks that VCD creates a separate vApp for each of them
+func TestAccVcdStandaloneVm(t *testing.T) {
+	var (
+		templateVmName = t.Name() + "-template"
+		emptyVmName    = t.Name() + "-empty"
+		networkName    = t.Name() + "-net"
+	)
+
+	var params = StringMap{
+		"Org":            testConfig.VCD.Org,
+		"Vdc":            testConfig.VCD.Vdc,
+		"EdgeGateway":    testConfig.Networking.EdgeGateway,
+		"Catalog":        testSuiteCatalogName,
+		"CatalogItem":    testSuiteCatalogOVAItem,
+		"NetworkName":    networkName,
+		"TemplateVmName": templateVmName,
+		"EmptyVmName":    emptyVmName,
+		"Tags":           "vm standaloneVm",
+	}
+
+	configText := templateFill(testAccCheckVcdStandaloneVm, params)
+	params["FuncName"] = t.Name() + "-update"
+	configTextUpdate := templateFill(testAccCheckVcdStandaloneVmUpdate, params)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	vcdClient := createTemporaryVCDConnection()
+	if vcdClient.Client.APIVCDMaxVersionIs("< 32.0") {
+		t.Skip("TestAccVcdStandaloneVm requires VCD 9.7+")
+	}
+
+	debugPrintf("#[DEBUG] CONFIGURATION: %s\n", configText)
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdStandaloneVmDestroy(templateVmName, emptyVmName),
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdStandaloneVmExists("vcd_vm."+templateVmName),
+					testAccCheckVcdStandaloneVmExists("vcd_vm."+emptyVmName),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "name", templateVmName),
+					resource.TestMatchResourceAttr("vcd_vm."+templateVmName, "vapp_name", regexp.MustCompile(`.+`)),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "network.#", "1"),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "network.0.name", networkName),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "network.0.ip", "10.10.103.161"),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "power_on", "true"),
+					resource.TestCheckResourceAttr("vcd_vm."+emptyVmName, "name", emptyVmName),
+					resource.TestMatchResourceAttr("vcd_vm."+emptyVmName, "vapp_name", regexp.MustCompile(`.+`)),
+					resource.TestCheckResourceAttr("vcd_vm."+emptyVmName, "os_type", "sles11_64Guest"),
+					resource.TestCheckResourceAttr("vcd_vm."+emptyVmName, "network.0.name", networkName),
+					resource.TestCheckResourceAttrPair("data.vcd_vm."+templateVmName, "id", "vcd_vm."+templateVmName, "id"),
+					resource.TestCheckResourceAttrPair("data.vcd_vm."+templateVmName, "vapp_name", "vcd_vm."+templateVmName, "vapp_name"),
+					resource.TestCheckResourceAttrPair("data.vcd_vm."+templateVmName, "network.0.ip", "vcd_vm."+templateVmName, "network.0.ip"),
+				),
+			},
+			resource.TestStep{
+				Config: configTextUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckVcdStandaloneVmExists("vcd_vm."+templateVmName),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "memory", "1536"),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "network.#", "2"),
+					resource.TestCheckResourceAttr("vcd_vm."+templateVmName, "network.1.type", "none"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "vcd_vm." + templateVmName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdOrgVdcObject(testConfig, templateVmName),
+				// These fields can't be retrieved from VM data
+				ImportStateVerifyIgnore: []string{"template_name", "catalog_name", "network_dhcp_wait_seconds",
+					"accept_all_eulas", "power_on", "computer_name", "prevent_update_power_off"},
+			},
+		},
+	})
+}
+
+func testAccCheckVcdStandaloneVmExists(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("not found: %s", resourceName)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no VM ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*VCDClient)
+		_, vdc, err := conn.GetOrgAndVdc(testConfig.VCD.Org, testConfig.VCD.Vdc)
+		if err != nil {
+			return fmt.Errorf(errorRetrievingVdcFromOrg, testConfig.VCD.Vdc, testConfig.VCD.Org, err)
+		}
+
+		vm, err := getStandaloneVm(conn, vdc, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		vapp, err := vm.GetParentVApp()
+		if err != nil {
+			return err
+		}
+		if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) != 1 {
+			return fmt.Errorf("the vApp of standalone VM %s should contain only that VM", vm.VM.Name)
+		}
+		return nil
+	}
+}
+
+func testAccCheckVcdStandaloneVmDestroy(vmNames ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		conn := testAccProvider.Meta().(*VCDClient)
+		_, vdc, err := conn.GetOrgAndVdc(testConfig.VCD.Org, testConfig.VCD.Vdc)
+		if err != nil {
+			return fmt.Errorf(errorRetrievingVdcFromOrg, testConfig.VCD.Vdc, testConfig.VCD.Org, err)
+		}
+		for _, vmName := range vmNames {
+			_, err = getStandaloneVm(conn, vdc, vmName)
+			if err == nil {
+				return fmt.Errorf("VM %s still exists", vmName)
+			}
+			if !govcd.ContainsNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+const testAccCheckVcdStandaloneVmNetwork = `
+resource "vcd_network_routed" "{{.NetworkName}}" {
+  name         = "{{.NetworkName}}"
+  org          = "{{.Org}}"
+  vdc          = "{{.Vdc}}"
+  edge_gateway = "{{.EdgeGateway}}"
+  gateway      = "10.10.103.1"
+
+  static_ip_pool {
+    start_address = "10.10.103.2"
+    end_address   = "10.10.103.254"
+  }
+}
+
+resource "vcd_vm" "{{.EmptyVmName}}" {
+  org              = "{{.Org}}"
+  vdc              = "{{.Vdc}}"
+  name             = "{{.EmptyVmName}}"
+  computer_name    = "emptyVm"
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-13"
+  power_on         = false
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.{{.NetworkName}}.name
+    ip_allocation_mode = "POOL"
+  }
+}
+`
+
+const testAccCheckVcdStandaloneVm = testAccCheckVcdStandaloneVmNetwork + `
+resource "vcd_vm" "{{.TemplateVmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  name          = "{{.TemplateVmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1024
+  cpus          = 1
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.{{.NetworkName}}.name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "10.10.103.161"
+  }
+}
+
+data "vcd_vm" "{{.TemplateVmName}}" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = vcd_vm.{{.TemplateVmName}}.name
+}
+`
+
+const testAccCheckVcdStandaloneVmUpdate = testAccCheckVcdStandaloneVmNetwork + `
+# skip-binary-test: only for updates
+resource "vcd_vm" "{{.TemplateVmName}}" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  name          = "{{.TemplateVmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1536
+  cpus          = 1
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.{{.NetworkName}}.name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "10.10.103.161"
+  }
+
+  network {
+    type               = "none"
+    ip_allocation_mode = "NONE"
+  }
+}
+`
@@ -0,0 +1,111 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// This file contains the plumbing used by resources that change their schema in a way that is not compatible
+// with the state saved by previous versions of the provider.
+// See "Schema changes" in CODING_GUIDELINES.md for the procedure.
+
+// stateUpgrader builds the upgrader that converts the state of a resource from the given schema version to
+// the next one. previousResource is the resource definition at the given version. It is needed to decode states
+// that were saved in the legacy flatmap format.
+func stateUpgrader(version int, previousResource *schema.Resource, upgrade schema.StateUpgradeFunc) schema.StateUpgrader {
+	return schema.StateUpgrader{
+		Version: version,
+		Type:    previousResource.CoreConfigSchema().ImpliedType(),
+		Upgrade: upgrade,
+	}
+}
+
+// validateStateUpgraders checks that the state upgraders of a resource cover all the versions from 0 to
+// SchemaVersion - 1, in order
+func validateStateUpgraders(resource *schema.Resource) error {
+	if len(resource.StateUpgraders) != resource.SchemaVersion {
+		return fmt.Errorf("resource has schema version %d and %d state upgraders",
+			resource.SchemaVersion, len(resource.StateUpgraders))
+	}
+	for i, upgrader := range resource.StateUpgraders {
+		if upgrader.Version != i {
+			return fmt.Errorf("state upgrader %d handles version %d", i, upgrader.Version)
+		}
+		if upgrader.Upgrade == nil {
+			return fmt.Errorf("state upgrader for version %d has no upgrade function", upgrader.Version)
+		}
+		if !upgrader.Type.IsObjectType() {
+			return fmt.Errorf("state upgrader for version %d has no object type", upgrader.Version)
+		}
+	}
+	return nil
+}
+
+// upgradeResourceState runs the state upgraders of a resource on a raw state, starting from the given
+// schema version, the same way Terraform does when it finds a state saved with an older schema
+func upgradeResourceState(ctx context.Context, resource *schema.Resource, rawState map[string]interface{}, fromVersion int, meta interface{}) (map[string]interface{}, error) {
+	var err error
+	for _, upgrader := range resource.StateUpgraders {
+		if upgrader.Version < fromVersion {
+			continue
+		}
+		rawState, err = upgrader.Upgrade(ctx, rawState, meta)
+		if err != nil {
+			return nil, fmt.Errorf("error upgrading state from version %d: %s", upgrader.Version, err)
+		}
+	}
+	return rawState, nil
+}
+
+// renameStateAttribute moves the value of an attribute to a new name.
+// Nothing happens if the attribute is not in the state
+func renameStateAttribute(rawState map[string]interface{}, oldName, newName string) {
+	value, ok := rawState[oldName]
+	if !ok {
+		return
+	}
+	rawState[newName] = value
+	delete(rawState, oldName)
+}
+
+// removeStateAttributes removes attributes that are no longer in the schema
+func removeStateAttributes(rawState map[string]interface{}, names ...string) {
+	for _, name := range names {
+		delete(rawState, name)
+	}
+}
+
+// setStateAttributeDefault sets the value of an attribute that is missing or nil in the state.
+// A zero value, such as an empty string or 0, is a value stored by the previous version and is kept
+func setStateAttributeDefault(rawState map[string]interface{}, name string, value interface{}) {
+	if rawState[name] == nil {
+		rawState[name] = value
+	}
+}
+
+// forEachStateBlock runs a conversion function on each element of a nested block (TypeList or TypeSet
+// of resources) in the raw state.
+// Nothing happens if the block is not in the state
+func forEachStateBlock(rawState map[string]interface{}, blockName string, convert func(block map[string]interface{}) error) error {
+	rawBlocks, ok := rawState[blockName]
+	if !ok || rawBlocks == nil {
+		return nil
+	}
+	blocks, ok := rawBlocks.([]interface{})
+	if !ok {
+		return fmt.Errorf("attribute '%s' is not a block list: %T", blockName, rawBlocks)
+	}
+	for i, rawBlock := range blocks {
+		block, ok := rawBlock.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("element %d of '%s' is not a block: %T", i, blockName, rawBlock)
+		}
+		err := convert(block)
+		if err != nil {
+			return fmt.Errorf("error converting element %d of '%s': %s", i, blockName, err)
+		}
+	}
+	return nil
+}
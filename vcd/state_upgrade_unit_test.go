@@ -0,0 +1,245 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestResourcesStateUpgraders checks that all the resources with a schema version greater than 0
+// have a complete chain of state upgraders
+func TestResourcesStateUpgraders(t *testing.T) {
+	for name, resource := range globalResourceMap {
+		err := validateStateUpgraders(resource)
+		if err != nil {
+			t.Errorf("resource %s: %s", name, err)
+		}
+	}
+}
+
+// testStateUpgradeResourceV0 is the first version of a sample resource used to test the state upgrade helpers
+func testStateUpgradeResourceV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name":     {Type: schema.TypeString, Required: true},
+			"old_size": {Type: schema.TypeInt, Optional: true},
+			"obsolete": {Type: schema.TypeString, Optional: true},
+			"disk": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bus": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testStateUpgradeResourceV1 is the second version of the sample resource
+func testStateUpgradeResourceV1() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {Type: schema.TypeString, Required: true},
+			"size": {Type: schema.TypeInt, Optional: true},
+			"disk": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bus_number": {Type: schema.TypeString, Optional: true},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testStateUpgradeResource is the current version of the sample resource
+func testStateUpgradeResource() *schema.Resource {
+	resource := testStateUpgradeResourceV1()
+	resource.Schema["description"] = &schema.Schema{Type: schema.TypeString, Optional: true}
+	resource.SchemaVersion = 2
+	resource.StateUpgraders = []schema.StateUpgrader{
+		stateUpgrader(0, testStateUpgradeResourceV0(), func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			renameStateAttribute(rawState, "old_size", "size")
+			removeStateAttributes(rawState, "obsolete")
+			err := forEachStateBlock(rawState, "disk", func(block map[string]interface{}) error {
+				renameStateAttribute(block, "bus", "bus_number")
+				return nil
+			})
+			return rawState, err
+		}),
+		stateUpgrader(1, testStateUpgradeResourceV1(), func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+			setStateAttributeDefault(rawState, "description", "none")
+			return rawState, nil
+		}),
+	}
+	return resource
+}
+
+func TestUpgradeResourceState(t *testing.T) {
+	resource := testStateUpgradeResource()
+	err := validateStateUpgraders(resource)
+	if err != nil {
+		t.Fatalf("sample resource has invalid state upgraders: %s", err)
+	}
+	err = resource.InternalValidate(nil, true)
+	if err != nil {
+		t.Fatalf("sample resource is not valid: %s", err)
+	}
+
+	type upgradeTest struct {
+		name        string
+		fromVersion int
+		state       map[string]interface{}
+		expected    map[string]interface{}
+	}
+	tests := []upgradeTest{
+		{
+			name:        "from-v0",
+			fromVersion: 0,
+			state: map[string]interface{}{
+				"name":     "test",
+				"old_size": 10,
+				"obsolete": "value",
+				"disk": []interface{}{
+					map[string]interface{}{"bus": "0"},
+					map[string]interface{}{"bus": "1"},
+				},
+			},
+			expected: map[string]interface{}{
+				"name":        "test",
+				"size":        10,
+				"description": "none",
+				"disk": []interface{}{
+					map[string]interface{}{"bus_number": "0"},
+					map[string]interface{}{"bus_number": "1"},
+				},
+			},
+		},
+		{
+			name:        "from-v0-without-optional-fields",
+			fromVersion: 0,
+			state: map[string]interface{}{
+				"name": "test",
+			},
+			expected: map[string]interface{}{
+				"name":        "test",
+				"description": "none",
+			},
+		},
+		{
+			name:        "from-v1",
+			fromVersion: 1,
+			state: map[string]interface{}{
+				"name":        "test",
+				"description": "existing",
+			},
+			expected: map[string]interface{}{
+				"name":        "test",
+				"description": "existing",
+			},
+		},
+		{
+			name:        "from-v1-empty",
+			fromVersion: 1,
+			state: map[string]interface{}{
+				"name":        "test",
+				"description": "",
+			},
+			expected: map[string]interface{}{
+				"name":        "test",
+				"description": "",
+			},
+		},
+		{
+			name:        "current",
+			fromVersion: 2,
+			state: map[string]interface{}{
+				"name": "test",
+			},
+			expected: map[string]interface{}{
+				"name": "test",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			upgraded, err := upgradeResourceState(context.Background(), resource, test.state, test.fromVersion, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(upgraded, test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, upgraded)
+			}
+		})
+	}
+}
+
+func TestValidateStateUpgraders(t *testing.T) {
+	noop := func(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+		return rawState, nil
+	}
+	previous := testStateUpgradeResourceV0()
+
+	type validationTest struct {
+		name          string
+		schemaVersion int
+		upgraders     []schema.StateUpgrader
+		wantError     bool
+	}
+	tests := []validationTest{
+		{"no-version", 0, nil, false},
+		{"complete", 2, []schema.StateUpgrader{stateUpgrader(0, previous, noop), stateUpgrader(1, previous, noop)}, false},
+		{"missing-upgrader", 2, []schema.StateUpgrader{stateUpgrader(0, previous, noop)}, true},
+		{"wrong-order", 2, []schema.StateUpgrader{stateUpgrader(1, previous, noop), stateUpgrader(0, previous, noop)}, true},
+		{"no-function", 1, []schema.StateUpgrader{stateUpgrader(0, previous, nil)}, true},
+		{"no-type", 1, []schema.StateUpgrader{{Version: 0, Upgrade: noop}}, true},
+	}
+	for _, test := range tests {
+		resource := &schema.Resource{
+			SchemaVersion:  test.schemaVersion,
+			StateUpgraders: test.upgraders,
+		}
+		err := validateStateUpgraders(resource)
+		if test.wantError && err == nil {
+			t.Errorf("%s: expected error, got none", test.name)
+		}
+		if !test.wantError && err != nil {
+			t.Errorf("%s: unexpected error: %s", test.name, err)
+		}
+	}
+}
+
+func TestForEachStateBlock(t *testing.T) {
+	rawState := map[string]interface{}{
+		"not_a_block": "text",
+		"not_a_list":  []interface{}{"text"},
+	}
+	convert := func(block map[string]interface{}) error { return nil }
+
+	if err := forEachStateBlock(rawState, "missing", convert); err != nil {
+		t.Errorf("unexpected error for missing block: %s", err)
+	}
+	if err := forEachStateBlock(rawState, "not_a_block", convert); err == nil {
+		t.Errorf("expected error for attribute that is not a block list")
+	}
+	if err := forEachStateBlock(rawState, "not_a_list", convert); err == nil {
+		t.Errorf("expected error for list element that is not a block")
+	}
+
+	rawState["disk"] = []interface{}{map[string]interface{}{"bus": "0"}}
+	err := forEachStateBlock(rawState, "disk", func(block map[string]interface{}) error {
+		return fmt.Errorf("conversion failed")
+	})
+	if err == nil {
+		t.Errorf("expected conversion error to be returned")
+	}
+}
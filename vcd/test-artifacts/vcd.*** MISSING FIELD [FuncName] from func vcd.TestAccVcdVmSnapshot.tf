@@ -0,0 +1,36 @@
+
+resource "vcd_vapp" "TestAccVcdVmSnapshot-vapp" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVmSnapshot-vapp"
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVmSnapshot-vm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVmSnapshot-vapp.name
+  name          = "TestAccVcdVmSnapshot-vm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 1
+  cpu_cores     = 1
+}
+
+resource "vcd_vm_snapshot" "snap" {
+  org            = ""
+  vdc            = ""
+  vapp_name      = vcd_vapp_vm.TestAccVcdVmSnapshot-vm.vapp_name
+  vm_name        = vcd_vapp_vm.TestAccVcdVmSnapshot-vm.name
+  name           = "before-upgrade"
+  description    = "snapshot taken by *** MISSING FIELD [FuncName] from func vcd.TestAccVcdVmSnapshot"
+  memory         = false
+  revert_trigger = ""
+}
+
+data "vcd_vm_snapshot" "snap" {
+  org       = ""
+  vdc       = ""
+  vapp_name = vcd_vm_snapshot.snap.vapp_name
+  vm_name   = vcd_vm_snapshot.snap.vm_name
+}
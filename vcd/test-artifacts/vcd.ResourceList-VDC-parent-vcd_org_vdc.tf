@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "VDC-parent" {
+  name          = "VDC-parent"
+  resource_type = "vcd_org_vdc"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.VDC-parent.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "VDC" {
+  name          = "VDC"
+  resource_type = "vcd_org_vdc"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.VDC.list
+}
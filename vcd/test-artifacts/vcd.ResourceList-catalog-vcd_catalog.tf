@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "catalog" {
+  name          = "catalog"
+  resource_type = "vcd_catalog"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.catalog.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "catalog_item" {
+  name          = "catalog_item"
+  resource_type = "vcd_catalog_item"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.catalog_item.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "catalog_media" {
+  name          = "catalog_media"
+  resource_type = "vcd_catalog_media"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.catalog_media.list
+}
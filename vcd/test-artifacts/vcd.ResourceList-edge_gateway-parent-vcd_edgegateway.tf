@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "edge_gateway-parent" {
+  name          = "edge_gateway-parent"
+  resource_type = "vcd_edgegateway"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.edge_gateway-parent.list
+}
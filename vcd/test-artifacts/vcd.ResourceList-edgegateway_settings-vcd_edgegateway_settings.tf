@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "edgegateway_settings" {
+  name          = "edgegateway_settings"
+  resource_type = "vcd_edgegateway_settings"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.edgegateway_settings.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "edgegateway_vpn" {
+  name          = "edgegateway_vpn"
+  resource_type = "vcd_edgegateway_vpn"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.edgegateway_vpn.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "extnet-parent" {
+  name          = "extnet-parent"
+  resource_type = "vcd_external_network"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.extnet-parent.list
+}
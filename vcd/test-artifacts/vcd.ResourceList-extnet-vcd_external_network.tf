@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "extnet" {
+  name          = "extnet"
+  resource_type = "vcd_external_network"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.extnet.list
+}
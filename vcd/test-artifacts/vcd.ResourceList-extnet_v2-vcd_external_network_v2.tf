@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "extnet_v2" {
+  name          = "extnet_v2"
+  resource_type = "vcd_external_network_v2"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.extnet_v2.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "ipset" {
+  name          = "ipset"
+  resource_type = "vcd_ipset"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.ipset.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "lb_app_profile" {
+  name          = "lb_app_profile"
+  resource_type = "vcd_lb_app_profile"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.lb_app_profile.list
+}
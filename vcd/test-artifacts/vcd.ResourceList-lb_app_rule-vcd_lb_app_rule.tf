@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "lb_app_rule" {
+  name          = "lb_app_rule"
+  resource_type = "vcd_lb_app_rule"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.lb_app_rule.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "lb_server_pool" {
+  name          = "lb_server_pool"
+  resource_type = "vcd_lb_server_pool"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.lb_server_pool.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "lb_service_monitor" {
+  name          = "lb_service_monitor"
+  resource_type = "vcd_lb_service_monitor"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.lb_service_monitor.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "lb_virtual_server" {
+  name          = "lb_virtual_server"
+  resource_type = "vcd_lb_virtual_server"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.lb_virtual_server.list
+}
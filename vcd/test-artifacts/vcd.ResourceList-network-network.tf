@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network" {
+  name          = "network"
+  resource_type = "network"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network-parent" {
+  name          = "network-parent"
+  resource_type = "network"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network-parent.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network_direct-parent" {
+  name          = "network_direct-parent"
+  resource_type = "vcd_network_direct"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network_direct-parent.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network_isolated-parent" {
+  name          = "network_isolated-parent"
+  resource_type = "vcd_network_isolated"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network_isolated-parent.list
+}
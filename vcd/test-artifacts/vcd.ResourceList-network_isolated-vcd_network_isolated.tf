@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network_isolated" {
+  name          = "network_isolated"
+  resource_type = "vcd_network_isolated"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network_isolated.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network_routed-parent" {
+  name          = "network_routed-parent"
+  resource_type = "vcd_network_routed"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network_routed-parent.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "network_routed" {
+  name          = "network_routed"
+  resource_type = "vcd_network_routed"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.network_routed.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "nsxv_dnat" {
+  name          = "nsxv_dnat"
+  resource_type = "vcd_nsxv_dnat"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.nsxv_dnat.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "nsxv_snat" {
+  name          = "nsxv_snat"
+  resource_type = "vcd_nsxv_snat"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.nsxv_snat.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "org_group" {
+  name          = "org_group"
+  resource_type = "vcd_org_group"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.org_group.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "orgs" {
+  name          = "orgs"
+  resource_type = "vcd_org"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.orgs.list
+}
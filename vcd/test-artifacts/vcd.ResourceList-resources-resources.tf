@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "resources" {
+  name          = "resources"
+  resource_type = "resources"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.resources.list
+}
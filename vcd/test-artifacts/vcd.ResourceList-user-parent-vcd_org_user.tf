@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "user-parent" {
+  name          = "user-parent"
+  resource_type = "vcd_org_user"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.user-parent.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "vapp-parent" {
+  name          = "vapp-parent"
+  resource_type = "vcd_vapp"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.vapp-parent.list
+}
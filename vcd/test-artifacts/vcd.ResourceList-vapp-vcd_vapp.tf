@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "vapp" {
+  name          = "vapp"
+  resource_type = "vcd_vapp"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.vapp.list
+}
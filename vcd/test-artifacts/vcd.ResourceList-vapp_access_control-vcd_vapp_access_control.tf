@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "vapp_access_control" {
+  name          = "vapp_access_control"
+  resource_type = "vcd_vapp_access_control"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.vapp_access_control.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "vm_placement_policy" {
+  name          = "vm_placement_policy"
+  resource_type = "vcd_vm_placement_policy"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.vm_placement_policy.list
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_list" "vm_sizing_policy" {
+  name          = "vm_sizing_policy"
+  resource_type = "vcd_vm_sizing_policy"
+}
+
+output "resources" {
+  value = data.vcd_resource_list.vm_sizing_policy.list
+}
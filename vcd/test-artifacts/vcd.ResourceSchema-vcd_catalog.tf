@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_catalog" {
+  name          = "vcd_catalog"
+  resource_type = "vcd_catalog"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_catalog
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_catalog_item" {
+  name          = "vcd_catalog_item"
+  resource_type = "vcd_catalog_item"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_catalog_item
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_catalog_item_download" {
+  name          = "vcd_catalog_item_download"
+  resource_type = "vcd_catalog_item_download"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_catalog_item_download
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_catalog_media" {
+  name          = "vcd_catalog_media"
+  resource_type = "vcd_catalog_media"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_catalog_media
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_catalog_media_download" {
+  name          = "vcd_catalog_media_download"
+  resource_type = "vcd_catalog_media_download"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_catalog_media_download
+}
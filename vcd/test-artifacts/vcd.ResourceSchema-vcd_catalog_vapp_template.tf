@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_catalog_vapp_template" {
+  name          = "vcd_catalog_vapp_template"
+  resource_type = "vcd_catalog_vapp_template"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_catalog_vapp_template
+}
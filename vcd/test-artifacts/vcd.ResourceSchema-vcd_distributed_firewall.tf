@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_distributed_firewall" {
+  name          = "vcd_distributed_firewall"
+  resource_type = "vcd_distributed_firewall"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_distributed_firewall
+}
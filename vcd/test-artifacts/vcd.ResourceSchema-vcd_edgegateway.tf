@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_edgegateway" {
+  name          = "vcd_edgegateway"
+  resource_type = "vcd_edgegateway"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_edgegateway
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_edgegateway_vpn" {
+  name          = "vcd_edgegateway_vpn"
+  resource_type = "vcd_edgegateway_vpn"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_edgegateway_vpn
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_external_network" {
+  name          = "vcd_external_network"
+  resource_type = "vcd_external_network"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_external_network
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_independent_disk" {
+  name          = "vcd_independent_disk"
+  resource_type = "vcd_independent_disk"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_independent_disk
+}
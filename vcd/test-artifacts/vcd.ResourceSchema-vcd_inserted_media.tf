@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_inserted_media" {
+  name          = "vcd_inserted_media"
+  resource_type = "vcd_inserted_media"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_inserted_media
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_lb_virtual_server" {
+  name          = "vcd_lb_virtual_server"
+  resource_type = "vcd_lb_virtual_server"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_lb_virtual_server
+}
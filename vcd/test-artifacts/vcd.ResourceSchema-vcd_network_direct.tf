@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_network_direct" {
+  name          = "vcd_network_direct"
+  resource_type = "vcd_network_direct"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_network_direct
+}
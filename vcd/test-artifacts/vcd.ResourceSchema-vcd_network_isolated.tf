@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_network_isolated" {
+  name          = "vcd_network_isolated"
+  resource_type = "vcd_network_isolated"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_network_isolated
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_network_routed" {
+  name          = "vcd_network_routed"
+  resource_type = "vcd_network_routed"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_network_routed
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_nsxv_dhcp_relay" {
+  name          = "vcd_nsxv_dhcp_relay"
+  resource_type = "vcd_nsxv_dhcp_relay"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_nsxv_dhcp_relay
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_nsxv_dnat" {
+  name          = "vcd_nsxv_dnat"
+  resource_type = "vcd_nsxv_dnat"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_nsxv_dnat
+}
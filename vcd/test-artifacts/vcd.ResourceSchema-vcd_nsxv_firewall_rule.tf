@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_nsxv_firewall_rule" {
+  name          = "vcd_nsxv_firewall_rule"
+  resource_type = "vcd_nsxv_firewall_rule"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_nsxv_firewall_rule
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_nsxv_ip_set" {
+  name          = "vcd_nsxv_ip_set"
+  resource_type = "vcd_nsxv_ip_set"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_nsxv_ip_set
+}
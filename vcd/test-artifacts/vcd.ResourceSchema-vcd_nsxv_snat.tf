@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_nsxv_snat" {
+  name          = "vcd_nsxv_snat"
+  resource_type = "vcd_nsxv_snat"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_nsxv_snat
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_org" {
+  name          = "vcd_org"
+  resource_type = "vcd_org"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_org
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_org_group" {
+  name          = "vcd_org_group"
+  resource_type = "vcd_org_group"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_org_group
+}
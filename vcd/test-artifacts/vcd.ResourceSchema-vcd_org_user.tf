@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_org_user" {
+  name          = "vcd_org_user"
+  resource_type = "vcd_org_user"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_org_user
+}
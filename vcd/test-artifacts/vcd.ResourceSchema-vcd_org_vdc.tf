@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_org_vdc" {
+  name          = "vcd_org_vdc"
+  resource_type = "vcd_org_vdc"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_org_vdc
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp" {
+  name          = "vcd_vapp"
+  resource_type = "vcd_vapp"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp_access_control" {
+  name          = "vcd_vapp_access_control"
+  resource_type = "vcd_vapp_access_control"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp_access_control
+}
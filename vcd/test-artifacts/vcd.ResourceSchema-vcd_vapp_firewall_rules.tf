@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp_firewall_rules" {
+  name          = "vcd_vapp_firewall_rules"
+  resource_type = "vcd_vapp_firewall_rules"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp_firewall_rules
+}
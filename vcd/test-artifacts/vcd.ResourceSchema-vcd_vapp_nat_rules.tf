@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp_nat_rules" {
+  name          = "vcd_vapp_nat_rules"
+  resource_type = "vcd_vapp_nat_rules"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp_nat_rules
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp_org_network" {
+  name          = "vcd_vapp_org_network"
+  resource_type = "vcd_vapp_org_network"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp_org_network
+}
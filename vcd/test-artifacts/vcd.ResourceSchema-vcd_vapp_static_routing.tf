@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp_static_routing" {
+  name          = "vcd_vapp_static_routing"
+  resource_type = "vcd_vapp_static_routing"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp_static_routing
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vapp_vm" {
+  name          = "vcd_vapp_vm"
+  resource_type = "vcd_vapp_vm"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vapp_vm
+}
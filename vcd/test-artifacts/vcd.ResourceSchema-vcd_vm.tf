@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vm" {
+  name          = "vcd_vm"
+  resource_type = "vcd_vm"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vm
+}
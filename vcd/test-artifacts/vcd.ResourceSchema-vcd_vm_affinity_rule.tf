@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vm_affinity_rule" {
+  name          = "vcd_vm_affinity_rule"
+  resource_type = "vcd_vm_affinity_rule"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vm_affinity_rule
+}
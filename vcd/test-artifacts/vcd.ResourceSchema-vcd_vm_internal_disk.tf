@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vm_internal_disk" {
+  name          = "vcd_vm_internal_disk"
+  resource_type = "vcd_vm_internal_disk"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vm_internal_disk
+}
@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vm_sizing_policy" {
+  name          = "vcd_vm_sizing_policy"
+  resource_type = "vcd_vm_sizing_policy"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vm_sizing_policy
+}
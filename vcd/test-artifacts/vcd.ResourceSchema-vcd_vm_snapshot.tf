@@ -0,0 +1,9 @@
+
+data "vcd_resource_schema" "vcd_vm_snapshot" {
+  name          = "vcd_vm_snapshot"
+  resource_type = "vcd_vm_snapshot"
+}
+
+output "resources" {
+  value = data.vcd_resource_schema.vcd_vm_snapshot
+}
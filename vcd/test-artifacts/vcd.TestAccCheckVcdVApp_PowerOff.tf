@@ -0,0 +1,29 @@
+
+
+resource "vcd_vapp" "TestAccVcdVAppVapp" {
+  org           = ""
+  vdc           = ""
+  name          = "TestAccVcdVAppVapp"
+
+  metadata = {
+    vapp_metadata = "vApp Metadata."
+  }
+
+  guest_properties = {
+	"guest.hostname"       = "test-host"
+	"guest.another.subkey" = "another-value"
+  }
+}
+
+# needed to check power on on update in next step
+resource "vcd_vapp_vm" "test_vm1" {
+  vapp_name     = vcd_vapp.TestAccVcdVAppVapp.name
+  name          = "test_vm1"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1 
+
+  os_type                        = "rhel4Guest"
+  hardware_version               = "vmx-14"
+  computer_name                  = "compNameUp"
+}
@@ -0,0 +1,31 @@
+
+# skip-binary-test: only for updates
+resource "vcd_vapp" "TestAccVcdVAppVapp" {
+  org           = ""
+  vdc           = ""
+  name          = "TestAccVcdVAppVapp"
+
+  metadata = {
+    vapp_metadata = "vApp Metadata updated"
+  }
+
+  guest_properties = {
+	"guest.another.subkey" = "new-value"
+	"guest.third.subkey"   = "third-value"
+  }
+
+  power_on = true
+}
+
+# vApp power on won't work if vApp doesn't have VM
+resource "vcd_vapp_vm" "test_vm1" {
+  vapp_name     = vcd_vapp.TestAccVcdVAppVapp.name
+  name          = "test_vm1"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1 
+
+  os_type                        = "rhel4Guest"
+  hardware_version               = "vmx-14"
+  computer_name                  = "compNameUp"
+}
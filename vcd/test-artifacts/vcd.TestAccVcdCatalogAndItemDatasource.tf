@@ -0,0 +1,27 @@
+
+data "vcd_catalog" "TestSuiteCatalog" {
+  org  = ""
+  name = "TestSuiteCatalog"
+}
+
+data "vcd_catalog_item" "TestSuiteOVA" {
+  org     = ""
+  catalog = data.vcd_catalog.TestSuiteCatalog.name
+  name    = "TestSuiteOVA"
+}
+
+resource "vcd_catalog_item" "TestCatalogItemDS" {
+  org     = ""
+  catalog = data.vcd_catalog.TestSuiteCatalog.name
+
+  name                 = "TestCatalogItemDS"
+  description          = data.vcd_catalog_item.TestSuiteOVA.id
+  ova_path             = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+
+  metadata = {
+    catalogItem_metadata = "catalogItem Metadata"
+    catalogItem_metadata2 = "catalogItem Metadata2"
+  }
+}
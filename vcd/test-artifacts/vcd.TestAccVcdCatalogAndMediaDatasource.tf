@@ -0,0 +1,45 @@
+
+resource "vcd_catalog_media"  "TestAccVcdCatalogMediaBasic" {
+  org     = ""
+  catalog = ""
+
+  name                 = "TestAccVcdCatalogMediaBasic"
+  description          = "TestAccVcdCatalogMediaBasicDescription"
+  media_path           = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+
+  metadata = {
+    catalogMedia_metadata = "catalogMedia Metadata"
+    catalogMedia_metadata2 = "catalogMedia Metadata2"
+  }
+}
+
+data "vcd_catalog_media" "TestCatalogMediaDS" {
+  org        = ""
+  catalog    = ""
+  name       = vcd_catalog_media.TestAccVcdCatalogMediaBasic.name
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+
+output "size" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.size
+}
+output "creation_date" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.creation_date
+}
+output "is_iso" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.is_iso
+}
+output "owner_name" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.owner_name
+}
+output "is_published" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.is_published
+}
+output "status" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.status
+}
+output "storage_profile_name" {
+  value = data.vcd_catalog_media.TestCatalogMediaDS.storage_profile_name
+}
@@ -0,0 +1,10 @@
+
+resource "vcd_catalog" "TestAccVcdCatalogBasic" {
+  org = "" 
+  
+  name = "TestAccVcdCatalogBasic"
+  description = "TestAccVcdCatalogBasicDescription"
+
+  delete_force      = "true"
+  delete_recursive  = "true"
+}
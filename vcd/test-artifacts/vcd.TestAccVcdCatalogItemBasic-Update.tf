@@ -0,0 +1,17 @@
+
+  resource "vcd_catalog_item" "TestAccVcdCatalogItemBasic" {
+  org     = ""
+  catalog = "TestSuiteCatalog"
+
+  name                 = "TestAccVcdCatalogItemBasic"
+  description          = "TestAccVcdCatalogItemBasicDescription"
+  ova_path             = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+
+  metadata = {
+    catalogItem_metadata = "catalogItem Metadata v2"
+    catalogItem_metadata2 = "catalogItem Metadata2 v2"
+    catalogItem_metadata3 = "catalogItem Metadata3"
+  }
+}
@@ -0,0 +1,16 @@
+
+  resource "vcd_catalog_item" "TestAccVcdCatalogItemBasic" {
+  org     = ""
+  catalog = "TestSuiteCatalog"
+
+  name                 = "TestAccVcdCatalogItemBasic"
+  description          = "TestAccVcdCatalogItemBasicDescription"
+  ova_path             = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+
+  metadata = {
+    catalogItem_metadata = "catalogItem Metadata"
+    catalogItem_metadata2 = "catalogItem Metadata2"
+  }
+}
@@ -0,0 +1,17 @@
+
+  resource "vcd_catalog_media"  "TestAccVcdCatalogMediaBasic" {
+  org     = ""
+  catalog = ""
+
+  name                 = "TestAccVcdCatalogMediaBasic"
+  description          = "TestAccVcdCatalogMediaBasicDescription"
+  media_path           = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+
+  metadata = {
+    mediaItem_metadata = "mediaItem Metadata v2"
+    mediaItem_metadata2 = "mediaItem Metadata2 v2"
+    mediaItem_metadata3 = "mediaItem Metadata3"
+  }
+}
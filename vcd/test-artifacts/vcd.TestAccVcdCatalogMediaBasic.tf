@@ -0,0 +1,45 @@
+
+  resource "vcd_catalog_media"  "TestAccVcdCatalogMediaBasic" {
+  org     = ""
+  catalog = ""
+
+  name                 = "TestAccVcdCatalogMediaBasic"
+  description          = "TestAccVcdCatalogMediaBasicDescription"
+  media_path           = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+
+  metadata = {
+    mediaItem_metadata = "mediaItem Metadata"
+    mediaItem_metadata2 = "mediaItem Metadata2"
+  }
+}
+
+output "creation_date" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.creation_date
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+output "is_iso" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.is_iso
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+output "owner_name" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.owner_name
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+output "is_published" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.is_published
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+output "size" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.size
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+output "status" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.status
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
+output "storage_profile_name" {
+  value = vcd_catalog_media.TestAccVcdCatalogMediaBasic.storage_profile_name
+  depends_on = [vcd_catalog_media.TestAccVcdCatalogMediaBasic]
+}
\ No newline at end of file
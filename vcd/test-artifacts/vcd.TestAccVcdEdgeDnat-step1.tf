@@ -0,0 +1,21 @@
+
+resource "vcd_nsxv_dnat" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  network_type = "ext"
+  network_name = ""
+
+  enabled         = false
+  logging_enabled = true
+
+  original_address   = ""
+  translated_address = "1.1.1.1"
+
+  protocol        = "tcp"
+  original_port   = 443
+  translated_port = 8443
+
+  description = "sending quote \""
+}
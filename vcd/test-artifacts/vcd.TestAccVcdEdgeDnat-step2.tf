@@ -0,0 +1,12 @@
+
+resource "vcd_nsxv_dnat" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  network_type = "ext"
+  network_name = ""
+
+  original_address   = ""
+  translated_address = "1.1.1.1"
+}
@@ -0,0 +1,26 @@
+
+resource "vcd_network_routed" "net" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name         = "test-org-for-dnat"
+  gateway      = "10.10.0.1"
+
+  static_ip_pool {
+    start_address = "10.10.0.152"
+    end_address   = "10.10.0.254"
+  }
+}
+
+resource "vcd_nsxv_dnat" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  network_type = "org"
+  network_name = vcd_network_routed.net.name
+
+  original_address   = "10.10.0.180"
+  translated_address = "1.1.1.1"
+}
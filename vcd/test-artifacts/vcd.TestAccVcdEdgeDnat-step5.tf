@@ -0,0 +1,19 @@
+
+resource "vcd_nsxv_dnat" "test2" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  network_type = "ext"
+  network_name = ""
+
+  rule_tag = "70000"
+
+  logging_enabled = true
+
+  protocol  = "icmp"
+  icmp_type = "router-advertisement"
+
+  original_address   = ""
+  translated_address = "1.1.1.1"
+}
@@ -0,0 +1,19 @@
+
+resource "vcd_nsxv_dnat" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  
+  network_type = "ext"
+  network_name = ""
+
+  original_address   = ""
+  translated_address = ""
+}
+
+data "vcd_nsxv_dnat" "data-test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  rule_id      = vcd_nsxv_dnat.test.id
+}
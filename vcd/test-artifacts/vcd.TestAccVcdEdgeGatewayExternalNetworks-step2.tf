@@ -0,0 +1,56 @@
+
+resource "vcd_external_network" "TestExternalNetwork" {
+  name        = "test_external_network"
+  description = "Test External Network"
+
+  vsphere_network {
+    vcenter = ""
+    name    = ""
+    type    = ""
+  }
+
+  ip_scope {
+    gateway      = "192.168.30.49"
+    netmask      = "255.255.255.240"
+    dns1         = "192.168.0.164"
+    dns2         = "192.168.0.196"
+    dns_suffix   = "company.biz"
+
+    static_ip_pool {
+      start_address = "192.168.30.51"
+      end_address   = "192.168.30.62"
+    }
+  }
+  
+#  ip_scope {
+# 	gateway      = "192.168.40.149"
+# 	netmask      = "255.255.255.0"
+# 	dns1         = "192.168.0.164"
+# 	dns2         = "192.168.0.196"
+# 	dns_suffix   = "company.biz"
+
+# 	static_ip_pool {
+# 	  start_address = "192.168.40.151"
+# 	  end_address   = "192.168.40.162"
+# 	}
+#   }
+
+  retain_net_info_across_deployments = "false"
+}
+
+resource "vcd_edgegateway" "egw" {
+	org                     = ""
+	vdc                     = ""
+
+	name                    = "simple-edge-with-complex-networks"
+	configuration           = "compact"
+
+	external_network {
+	  name = vcd_external_network.TestExternalNetwork.name
+	  subnet {
+		gateway = "192.168.30.49"
+		netmask = "255.255.255.240"
+		use_for_default_route = true
+	  }
+	}
+}
@@ -0,0 +1,109 @@
+
+resource "vcd_external_network" "TestExternalNetwork" {
+  name        = "test_external_network"
+  description = "Test External Network"
+
+  vsphere_network {
+    vcenter = ""
+    name    = ""
+    type    = ""
+  }
+
+  ip_scope {
+    gateway      = "192.168.30.49"
+    netmask      = "255.255.255.240"
+    dns1         = "192.168.0.164"
+    dns2         = "192.168.0.196"
+    dns_suffix   = "company.biz"
+
+    static_ip_pool {
+      start_address = "192.168.30.51"
+      end_address   = "192.168.30.62"
+    }
+  }
+  
+#  ip_scope {
+# 	gateway      = "192.168.40.149"
+# 	netmask      = "255.255.255.0"
+# 	dns1         = "192.168.0.164"
+# 	dns2         = "192.168.0.196"
+# 	dns_suffix   = "company.biz"
+
+# 	static_ip_pool {
+# 	  start_address = "192.168.40.151"
+# 	  end_address   = "192.168.40.162"
+# 	}
+#   }
+
+  retain_net_info_across_deployments = "false"
+}
+
+resource "vcd_edgegateway" "egw" {
+	org                     = ""
+	vdc                     = ""
+
+	name                    = "edge-with-complex-networks"
+	description             = "new edge gateway"
+	configuration           = "compact"
+  
+    # can be only true when system setting Allow FIPS Mode is enabled
+	fips_mode_enabled               = false
+	use_default_route_for_dns_relay = true
+	distributed_routing             = false
+
+    lb_enabled              = "true"
+    lb_acceleration_enabled = "true"
+    lb_logging_enabled      = "true"
+    lb_loglevel             = "critical"
+
+    fw_enabled                      = "true"
+    fw_default_rule_logging_enabled = "true"
+    fw_default_rule_action          = "accept"
+
+	external_network {
+	  name = vcd_external_network.TestExternalNetwork.name
+  
+	  subnet {
+		ip_address = "192.168.30.51"
+		gateway = "192.168.30.49"
+		netmask = "255.255.255.240"
+		use_for_default_route = true
+
+		suballocate_pool {
+			start_address = "192.168.30.53"
+			end_address   = "192.168.30.55"
+		}
+
+		suballocate_pool {
+			start_address = "192.168.30.58"
+			end_address   = "192.168.30.60"
+		}
+	  }
+	}
+
+	# Attach to existing external network
+	external_network {
+	  name = data.vcd_external_network.ds-network.name
+
+		subnet {
+			# ip_address is skipped here on purpose to get dynamic IP
+			use_for_default_route = false
+			gateway = data.vcd_external_network.ds-network.ip_scope[0].gateway
+			netmask = data.vcd_external_network.ds-network.ip_scope[0].netmask
+	}
+  }
+}
+
+data "vcd_edgegateway" "egw" {
+  org = ""
+  vdc = ""
+	
+  name = vcd_edgegateway.egw.name
+  depends_on = [vcd_edgegateway.egw]
+}
+
+# Use data source of existing external network to get needed gateway and netmask
+# for subnet participation details
+data "vcd_external_network" "ds-network" {
+	name = ""
+}
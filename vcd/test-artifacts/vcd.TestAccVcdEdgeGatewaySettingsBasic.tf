@@ -0,0 +1,21 @@
+
+# skip-binary-test: would update existing Edge Gateway
+
+data "vcd_edgegateway" "egw" {
+  name = ""
+}
+
+resource "vcd_edgegateway_settings" "EdgeGatewaySettingsBasic" {
+  org = ""
+  vdc = ""
+
+  edge_gateway_id         = data.vcd_edgegateway.egw.id
+  lb_enabled              = true
+  lb_acceleration_enabled = true
+  lb_logging_enabled      = false       # only set if provider user is system administrator
+  lb_loglevel             = "info"           # only set if provider user is system administrator
+
+  fw_enabled                      = true
+  fw_default_rule_logging_enabled = true
+  fw_default_rule_action          = "accept"
+}
@@ -0,0 +1,31 @@
+
+resource "vcd_network_routed" "net" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name         = "test-org-for-snat"
+  gateway      = "4.4.4.1"
+
+  static_ip_pool {
+    start_address = "4.4.4.152"
+    end_address   = "4.4.4.254"
+  }
+}
+
+resource "vcd_nsxv_snat" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  description = "test suite snat rule"
+
+  enabled         = false
+  logging_enabled = true
+
+  network_type = "org"
+  network_name = vcd_network_routed.net.name
+
+  original_address   = "1.1.1.1"
+  translated_address = "4.4.4.170"
+}
@@ -0,0 +1,33 @@
+
+resource "vcd_network_routed" "net" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name         = "test-org-for-snat"
+  gateway      = "4.4.4.1"
+
+  static_ip_pool {
+    start_address = "4.4.4.152"
+    end_address   = "4.4.4.254"
+  }
+}
+
+resource "vcd_nsxv_snat" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  network_type = "org"
+  network_name = vcd_network_routed.net.name
+
+  original_address   = "4.4.4.160"
+  translated_address = ""
+}
+
+data "vcd_nsxv_snat" "data-test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  rule_id      = vcd_nsxv_snat.test.id
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_nsxv_ip_set" "test-ipset" {
+  org          = ""
+  vdc          = ""
+
+  name         = "TestAccVcdIpSet-changed"
+  description  = "test-ip-set-changed-description"
+  ip_addresses = ["10.10.10.1","11.11.11.1"]
+}
+
+data "vcd_nsxv_ip_set" "test-ipset" {
+	org          = ""
+	vdc          = ""
+  
+	name         = vcd_nsxv_ip_set.test-ipset.name
+	depends_on   = [vcd_nsxv_ip_set.test-ipset]
+}
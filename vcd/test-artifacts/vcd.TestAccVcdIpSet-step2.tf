@@ -0,0 +1,18 @@
+
+resource "vcd_nsxv_ip_set" "test-ipset" {
+  org          = ""
+  vdc          = ""
+
+  name                   = "TestAccVcdIpSet-changed2"
+  is_inheritance_allowed = false
+  description            = "test-ip-set-changed-description"
+  ip_addresses           = ["1.1.1.1/24","10.10.10.100-10.10.10.110"]
+}
+
+data "vcd_nsxv_ip_set" "test-ipset" {
+	org          = ""
+	vdc          = ""
+  
+	name         = vcd_nsxv_ip_set.test-ipset.name
+	depends_on   = [vcd_nsxv_ip_set.test-ipset]
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_nsxv_ip_set" "test-ipset" {
+  org          = ""
+  vdc          = ""
+
+  name         = "TestAccVcdIpSet"
+  description  = "test-ip-set-description"
+  ip_addresses = ["192.168.1.1","192.168.2.1"]
+}
+
+data "vcd_nsxv_ip_set" "test-ipset" {
+	org          = ""
+	vdc          = ""
+  
+	name         = vcd_nsxv_ip_set.test-ipset.name
+	depends_on   = [vcd_nsxv_ip_set.test-ipset]
+}
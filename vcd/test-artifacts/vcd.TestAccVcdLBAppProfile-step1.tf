@@ -0,0 +1,21 @@
+
+resource "vcd_lb_app_profile" "test" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+  
+	name           = "TestAccVcdLBAppProfile-step1"
+	type           = "udp"
+
+	enable_ssl_passthrough         = "true"
+	insert_x_forwarded_http_header = "true"
+	enable_pool_side_ssl           = "true"
+}
+
+data "vcd_lb_app_profile" "test" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name         = vcd_lb_app_profile.test.name
+	depends_on   = [vcd_lb_app_profile.test]
+}
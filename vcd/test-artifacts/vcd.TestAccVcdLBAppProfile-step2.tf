@@ -0,0 +1,23 @@
+
+resource "vcd_lb_app_profile" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name = "TestAccVcdLBAppProfile-step2"
+  type = "http"
+
+  http_redirect_url              = "/service-one"
+  persistence_mechanism          = "cookie"
+  cookie_name                    = "JSESSIONID"
+  cookie_mode                    = "insert"
+  insert_x_forwarded_http_header = "true"
+}
+
+data "vcd_lb_app_profile" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_app_profile.test.name
+  depends_on   = [vcd_lb_app_profile.test]
+}  
@@ -0,0 +1,21 @@
+
+resource "vcd_lb_app_profile" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name = "TestAccVcdLBAppProfile-step3"
+  type = "http"
+
+  http_redirect_url     = ""
+  persistence_mechanism = "sourceip"
+  expiration = "17"
+}
+
+data "vcd_lb_app_profile" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_app_profile.test.name
+  depends_on   = [vcd_lb_app_profile.test]
+}
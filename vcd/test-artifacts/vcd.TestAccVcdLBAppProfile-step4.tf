@@ -0,0 +1,23 @@
+
+resource "vcd_lb_app_profile" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name = "TestAccVcdLBAppProfile-step4"
+  type = "https"
+
+  persistence_mechanism = "sourceip"
+  expiration = 0
+  enable_ssl_passthrough         = "true"
+  enable_pool_side_ssl           = "true"
+  insert_x_forwarded_http_header = "true"
+}
+
+data "vcd_lb_app_profile" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_app_profile.test.name
+  depends_on   = [vcd_lb_app_profile.test]
+}
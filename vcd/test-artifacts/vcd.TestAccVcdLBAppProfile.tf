@@ -0,0 +1,21 @@
+
+resource "vcd_lb_app_profile" "test" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+  
+	name           = "TestAccVcdLBAppProfile"
+	type           = "tcp"
+
+	enable_ssl_passthrough         = "false"
+	insert_x_forwarded_http_header = "false"
+	enable_pool_side_ssl           = "false"
+}
+
+data "vcd_lb_app_profile" "test" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name         = vcd_lb_app_profile.test.name
+	depends_on   = [vcd_lb_app_profile.test]
+}
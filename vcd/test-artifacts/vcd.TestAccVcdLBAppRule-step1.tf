@@ -0,0 +1,20 @@
+
+resource "vcd_lb_app_rule" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name   = "TestAccVcdLBAppRule-step1"
+  script = <<-EOT
+acl vmware_page url_beg / vmware redirect location https://www.vmware.com/ ifvmware_page
+acl other_page2 url_beg / other2 redirect location https://www.other2.com/ ifother_page2
+EOT
+}
+  
+data "vcd_lb_app_rule" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_app_rule.test.name
+  depends_on   = [vcd_lb_app_rule.test]
+} 
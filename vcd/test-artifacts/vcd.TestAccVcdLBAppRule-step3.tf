@@ -0,0 +1,25 @@
+
+# skip-binary-test: it will fail on purpose
+
+resource "vcd_lb_app_rule" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name   = "TestAccVcdLBAppRule-step1"
+  script = <<-EOT
+			acl vmware_page url_beg / vmware redirect location https://www.vmware.com/ ifvmware_page
+			acl other_page2 url_beg / other2 redirect location https://www.other2.com/ ifother_page2
+			acl en req.fhdr(accept-language),language(es;fr;en) -m str en
+			use_backend english if en
+		EOT
+}
+
+data "vcd_lb_app_rule" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_app_rule.test.name
+  depends_on   = [vcd_lb_app_rule.test]
+}
+
@@ -0,0 +1,17 @@
+
+resource "vcd_lb_app_rule" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name   = "TestAccVcdLBAppRule"
+  script = "acl vmware_page url_beg / vmware redirect location https://www.vmware.com/ ifvmware_page"
+}
+  
+data "vcd_lb_app_rule" "test" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_app_rule.test.name
+  depends_on   = [vcd_lb_app_rule.test]
+}  
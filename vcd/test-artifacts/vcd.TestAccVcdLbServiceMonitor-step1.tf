@@ -0,0 +1,12 @@
+
+resource "vcd_lb_service_monitor" "lb-service-monitor" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name        = "TestAccVcdLbServiceMonitor-step1"
+  type        = "tcp"
+  interval    = 5
+  timeout     = 10
+  max_retries = 3
+}
@@ -0,0 +1,31 @@
+
+resource "vcd_lb_service_monitor" "lb-service-monitor" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name        = "TestAccVcdLbServiceMonitor"
+  interval    = 5
+  timeout     = 10
+  max_retries = 3
+  type        = "http"
+  method      = "POST"
+  send        = "{\"key\": \"value\"}"
+  expected    = "HTTP/1.1"
+  receive     = "OK"
+  url         = "/health"
+
+  extension = {
+    "content-type" = "application/json"
+    "no-body"      = ""
+  }
+}
+
+
+data "vcd_lb_service_monitor" "ds-lb-service-monitor" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_service_monitor.lb-service-monitor.name
+  depends_on   = [vcd_lb_service_monitor.lb-service-monitor]
+}
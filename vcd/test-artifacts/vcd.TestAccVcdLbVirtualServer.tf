@@ -0,0 +1,109 @@
+
+# Prerequisites to make a working load balancer
+resource "vcd_lb_service_monitor" "monitor" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name        = "http-monitor"
+  interval    = "5"
+  timeout     = "20"
+  max_retries = "3"
+  type        = "http"
+  method      = "GET"
+  url         = "/health"
+  send        = "{\"key\": \"value\"}"
+  extension = {
+    content-type = "application/json"
+    linespan     = ""
+  }
+}
+
+resource "vcd_lb_server_pool" "web-servers" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name                 = "web-servers"
+  description          = "description"
+  algorithm            = "httpheader"
+  algorithm_parameters = "headerName=host"
+  enable_transparency  = "true"
+
+  monitor_id = vcd_lb_service_monitor.monitor.id
+
+  member {
+    condition       = "enabled"
+    name            = "member1"
+    ip_address      = "1.1.1.1"
+    port            = 8443
+    monitor_port    = 9000
+    weight          = 1
+    min_connections = 0
+    max_connections = 100
+  }
+
+  member {
+    condition       = "enabled"
+    name            = "member2"
+    ip_address      = "2.2.2.2"
+    port            = 7000
+    monitor_port    = 4000
+    weight          = 2
+    min_connections = 6
+    max_connections = 8
+  }
+}
+
+resource "vcd_lb_app_profile" "http" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name = "http-app-profile"
+  type = "http"
+}
+
+resource "vcd_lb_app_rule" "redirect" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name   = "redirect"
+  script = "acl vmware_page url_beg / vmware redirect location https://www.vmware.com/ ifvmware_page"
+}
+
+resource "vcd_lb_app_rule" "language" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  name   = "language"
+  script = "acl hello payload(0,6) -m bin 48656c6c6f0a"
+}
+
+resource "vcd_lb_virtual_server" "http" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  enabled             = "false"
+  enable_acceleration = "false"
+
+  name       = "TestAccVcdLbVirtualServer"
+  ip_address = ""
+  protocol   = "http"
+  port       = 8888
+
+  app_profile_id = vcd_lb_app_profile.http.id
+  server_pool_id = vcd_lb_server_pool.web-servers.id
+  app_rule_ids   = [vcd_lb_app_rule.redirect.id, vcd_lb_app_rule.language.id]
+}
+
+data "vcd_lb_virtual_server" "http" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  name         = vcd_lb_virtual_server.http.name
+  depends_on   = [vcd_lb_virtual_server.http]
+}
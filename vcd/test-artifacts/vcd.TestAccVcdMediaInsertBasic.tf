@@ -0,0 +1,67 @@
+
+resource "vcd_network_routed" "TestAccVcdVAppVmNetForInsert" {
+  name         = "TestAccVcdVAppVmNetForInsert"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_vapp" "TestAccVcdVAppForInsert" {
+  name       = "TestAccVcdVAppForInsert"
+  org        = ""
+  vdc        = ""
+}
+
+resource "vcd_vapp_org_network" "vappNetwork1" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVAppForInsert.name
+  org_network_name   = vcd_network_routed.TestAccVcdVAppVmNetForInsert.name 
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmForInsert" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppForInsert.name
+  name          = "TestAccVcdVAppVmForInsert"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 1
+  power_on      = "false"
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappNetwork1.org_network_name
+    ip_allocation_mode = "POOL"
+  }
+}
+
+resource "vcd_catalog_media" "TestAccVcdCatalogMediaBasicForInsert" {
+  org     = ""
+  catalog = "TestSuiteCatalog"
+
+  name                 = "TestAccVcdCatalogMediaBasicForInsert"
+  description          = "TestAccVcdCatalogMediaBasicDescriptionForInsert"
+  media_path           = ""
+  upload_piece_size    = 0
+  show_upload_progress = "false"
+}
+
+resource "vcd_inserted_media" "TestAccVcdMediaInsertBasic" {
+  org     = ""
+  vdc     = ""
+  catalog = "TestSuiteCatalog"
+  name    = "TestAccVcdCatalogMediaBasicForInsert"
+
+  vapp_name  = vcd_vapp.TestAccVcdVAppForInsert.name
+  vm_name    = vcd_vapp_vm.TestAccVcdVAppVmForInsert.name
+  depends_on = ["vcd_vapp_vm.TestAccVcdVAppVmForInsert", "vcd_catalog_media.TestAccVcdCatalogMediaBasicForInsert"]
+
+  eject_force = "true"
+}
@@ -0,0 +1,17 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoDhcp" {
+  name        = "TestAccVcdNetworkIsoDhcp-update"
+  description = "TestAccVcdNetworkIsoDhcp updated description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  dhcp_pool {
+    start_address      = "192.168.2.53"
+    end_address        = "192.168.2.99"
+    default_lease_time = "8000"
+    max_lease_time     = "604800"
+  }
+}
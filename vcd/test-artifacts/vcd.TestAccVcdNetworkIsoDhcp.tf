@@ -0,0 +1,15 @@
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoDhcp" {
+  name        = "TestAccVcdNetworkIsoDhcp"
+  description = "TestAccVcdNetworkIsoDhcp description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  dhcp_pool {
+    start_address      = "192.168.2.51"
+    end_address        = "192.168.2.100"
+    default_lease_time = "4000"
+    max_lease_time     = "86400"
+  }
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoMixed1" {
+  name        = "TestAccVcdNetworkIsoMixed1"
+  description = "TestAccVcdNetworkIsoMixed1 description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.2"
+    end_address   = "192.168.2.50"
+  }
+  dhcp_pool {
+    start_address = "192.168.2.51"
+    end_address   = "192.168.2.100"
+  }
+}
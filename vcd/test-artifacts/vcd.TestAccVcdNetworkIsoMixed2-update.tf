@@ -0,0 +1,23 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoMixed2" {
+  name        = "TestAccVcdNetworkIsoMixed2-update"
+  description = "TestAccVcdNetworkIsoMixed2 updated description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.5"
+    end_address   = "192.168.2.45"
+  }
+  static_ip_pool {
+    start_address = "192.168.2.53"
+    end_address   = "192.168.2.99"
+  }
+  dhcp_pool {
+    start_address = "192.168.2.153"
+    end_address   = "192.168.2.198"
+  }
+}
@@ -0,0 +1,21 @@
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoMixed2" {
+  name        = "TestAccVcdNetworkIsoMixed2"
+  description = "TestAccVcdNetworkIsoMixed2 description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.2"
+    end_address   = "192.168.2.50"
+  }
+  static_ip_pool {
+    start_address = "192.168.2.52"
+    end_address   = "192.168.2.100"
+  }
+  dhcp_pool {
+    start_address = "192.168.2.151"
+    end_address   = "192.168.2.200"
+  }
+}
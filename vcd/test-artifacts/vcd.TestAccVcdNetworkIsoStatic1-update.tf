@@ -0,0 +1,15 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoStatic1" {
+  name        = "TestAccVcdNetworkIsoStatic1-update"
+  description = "TestAccVcdNetworkIsoStatic1 updated description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.5"
+    end_address   = "192.168.2.45"
+  }
+}
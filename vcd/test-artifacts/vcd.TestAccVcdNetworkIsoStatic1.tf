@@ -0,0 +1,13 @@
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoStatic1" {
+  name        = "TestAccVcdNetworkIsoStatic1"
+  description = "TestAccVcdNetworkIsoStatic1 description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.2"
+    end_address   = "192.168.2.50"
+  }
+}
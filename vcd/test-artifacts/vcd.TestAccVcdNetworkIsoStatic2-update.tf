@@ -0,0 +1,19 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoStatic2" {
+  name        = "TestAccVcdNetworkIsoStatic2-update"
+  description = "TestAccVcdNetworkIsoStatic2 updated description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.5"
+    end_address   = "192.168.2.45"
+  }
+  static_ip_pool {
+    start_address = "192.168.2.53"
+    end_address   = "192.168.2.99"
+  }
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_network_isolated" "TestAccVcdNetworkIsoStatic2" {
+  name        = "TestAccVcdNetworkIsoStatic2"
+  description = "TestAccVcdNetworkIsoStatic2 description"
+  org         = ""
+  vdc         = ""
+  gateway     = "192.168.2.1"
+  dns1        = "192.168.2.1"
+  static_ip_pool {
+    start_address = "192.168.2.2"
+    end_address   = "192.168.2.50"
+  }
+  static_ip_pool {
+    start_address = "192.168.2.52"
+    end_address   = "192.168.2.100"
+  }
+}
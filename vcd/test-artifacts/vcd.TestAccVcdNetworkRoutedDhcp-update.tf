@@ -0,0 +1,18 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedDhcp" {
+  name           = "TestAccVcdNetworkRoutedDhcp-update"
+  description    = "TestAccVcdNetworkRoutedDhcp updated description"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "10.10.102.1"
+  interface_type = "internal"
+
+  dhcp_pool {
+    start_address      = "10.10.102.52"
+    end_address        = "10.10.102.99"
+    max_lease_time     = "604800"
+  }
+}
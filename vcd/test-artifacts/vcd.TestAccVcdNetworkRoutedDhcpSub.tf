@@ -0,0 +1,16 @@
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedDhcpSub" {
+  name           = "TestAccVcdNetworkRoutedDhcpSub"
+  description    = "TestAccVcdNetworkRoutedDhcpSub description"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "10.10.102.1"
+  interface_type = "subinterface"
+
+  dhcp_pool {
+    start_address      = "10.10.102.51"
+    end_address        = "10.10.102.100"
+    max_lease_time     = "7200"
+  }
+}
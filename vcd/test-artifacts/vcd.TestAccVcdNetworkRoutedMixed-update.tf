@@ -0,0 +1,22 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedMixed" {
+  name           = "TestAccVcdNetworkRoutedMixed-update"
+  description    = "TestAccVcdNetworkRoutedMixed updated description"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "10.10.102.1"
+  interface_type = "internal"
+
+  static_ip_pool {
+    start_address = "10.10.102.5"
+    end_address   = "10.10.102.45"
+  }
+
+  dhcp_pool {
+    start_address = "10.10.102.52"
+    end_address   = "10.10.102.99"
+  }
+}
@@ -0,0 +1,20 @@
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedMixed" {
+  name           = "TestAccVcdNetworkRoutedMixed"
+  description    = "TestAccVcdNetworkRoutedMixed description"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "10.10.102.1"
+  interface_type = "internal"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.50"
+  }
+
+  dhcp_pool {
+    start_address = "10.10.102.51"
+    end_address   = "10.10.102.100"
+  }
+}
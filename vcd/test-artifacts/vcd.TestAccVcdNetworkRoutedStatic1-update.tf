@@ -0,0 +1,16 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedStatic1" {
+  name         = "TestAccVcdNetworkRoutedStatic1-update"
+  description  = "TestAccVcdNetworkRoutedStatic1 updated description"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.5"
+    end_address   = "10.10.102.45"
+  }
+}
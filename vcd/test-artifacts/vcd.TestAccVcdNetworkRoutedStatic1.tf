@@ -0,0 +1,14 @@
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedStatic1" {
+  name         = "TestAccVcdNetworkRoutedStatic1"
+  description  = "TestAccVcdNetworkRoutedStatic1 description"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.50"
+  }
+}
@@ -0,0 +1,21 @@
+
+# skip-binary-test only for updates
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedStaticSub2" {
+  name           = "TestAccVcdNetworkRoutedStaticSub2-update"
+  description    = "TestAccVcdNetworkRoutedStaticSub2 updated description"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "10.10.102.1"
+  interface_type = "subinterface"
+
+  static_ip_pool {
+    start_address = "10.10.102.5"
+    end_address   = "10.10.102.45"
+  }
+  static_ip_pool {
+    start_address = "10.10.102.53"
+    end_address   = "10.10.102.99"
+  }
+}
@@ -0,0 +1,19 @@
+
+resource "vcd_network_routed" "TestAccVcdNetworkRoutedStaticSub2" {
+  name           = "TestAccVcdNetworkRoutedStaticSub2"
+  description    = "TestAccVcdNetworkRoutedStaticSub2 description"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "10.10.102.1"
+  interface_type = "subinterface"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.50"
+  }
+  static_ip_pool {
+    start_address = "10.10.102.52"
+    end_address   = "10.10.102.100"
+  }
+}
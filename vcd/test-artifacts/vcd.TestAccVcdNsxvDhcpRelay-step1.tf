@@ -0,0 +1,43 @@
+
+variable "network_types" {
+  type    = list(string)
+  default = ["internal", "subinterface"]
+}
+
+resource "vcd_network_routed" "test-routed" {
+  count          = 2
+  name           = "dhcp-relay-${count.index}"
+  org            = ""
+  vdc            = ""
+  edge_gateway   = ""
+  gateway        = "210.201.${count.index}.1"
+  netmask        = "255.255.255.0"
+  interface_type = var.network_types[count.index]
+
+  static_ip_pool {
+    start_address = "210.201.${count.index}.10"
+    end_address   = "210.201.${count.index}.20"
+  }
+}
+
+resource "vcd_nsxv_dhcp_relay" "relay_config" {
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+
+  ip_sets = [vcd_nsxv_ip_set.myset1.name, vcd_nsxv_ip_set.myset2.name]
+
+  relay_agent {
+    network_name = vcd_network_routed.test-routed[0].name
+  }
+}
+
+resource "vcd_nsxv_ip_set" "myset1" {
+  name         = "test-set1"
+  ip_addresses = ["192.168.1.1"]
+}
+
+resource "vcd_nsxv_ip_set" "myset2" {
+  name         = "test-set2"
+  ip_addresses = ["192.168.1.1"]
+}
@@ -0,0 +1,20 @@
+
+resource "vcd_nsxv_firewall_rule" "rule1" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "test-rule-1"
+	action = "deny"
+
+	source {
+		gateway_interfaces = ["internal"]
+	}
+  
+	destination {
+		gateway_interfaces = ["external"]
+	}
+	service {
+		protocol = "tcp"
+		port     = "443"
+	}
+  }
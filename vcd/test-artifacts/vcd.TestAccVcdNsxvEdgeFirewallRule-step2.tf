@@ -0,0 +1,26 @@
+
+resource "vcd_nsxv_firewall_rule" "rule2" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "test-rule-2"
+	action = "deny"
+
+	source {
+		gateway_interfaces = ["vse"]
+	}
+  
+	destination {
+		gateway_interfaces = [""]
+	}
+
+	service {
+		protocol    = "TCP"
+		port        = "443-543"
+		source_port = "2000-4000"
+	}
+}
+
+output "destination_gateway_interface" {
+	value = tolist(vcd_nsxv_firewall_rule.rule2.destination[0].gateway_interfaces)[0]
+}
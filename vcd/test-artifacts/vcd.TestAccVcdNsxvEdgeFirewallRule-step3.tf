@@ -0,0 +1,36 @@
+
+resource "vcd_nsxv_firewall_rule" "rule3" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	action          = "deny"
+	enabled         = "false"
+	logging_enabled = "true"
+
+	source {
+		org_networks = [vcd_network_routed.test-routed[0].name]
+	}
+  
+	destination {
+		exclude      = "true"
+		org_networks = [vcd_network_routed.test-routed[1].name]
+	}
+	service {
+		protocol = "tcp"
+		port     = "443"
+	}
+}
+resource "vcd_network_routed" "test-routed" {
+  count        = 2
+  name         = "firewall-test-${count.index}"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.201.${count.index}.1"
+  netmask      = "255.255.255.0"
+
+  static_ip_pool {
+    start_address = "10.201.${count.index}.10"
+    end_address   = "10.201.${count.index}.20"
+  }
+}
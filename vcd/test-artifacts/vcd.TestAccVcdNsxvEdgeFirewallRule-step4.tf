@@ -0,0 +1,50 @@
+
+resource "vcd_nsxv_firewall_rule" "rule4" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "test-rule-4"
+	action = "deny"
+
+	source {
+		gateway_interfaces = ["internal"]
+	}
+  
+	destination {
+		gateway_interfaces = ["external"]
+	}
+
+	service {
+		protocol = "tcp"
+		port     = "443"
+	}
+	
+	service {
+		protocol = "tcp"
+		port     = "8443"
+		source_port = "20000-40000"
+	}
+
+	service {
+		protocol = "UDP"
+		port     = "10000"
+	}
+
+	service {
+		protocol    = "udp"
+		port        = "10000"
+		source_port = "20000"
+	}
+
+	service {
+		protocol = "ICMP"
+	}
+  }
+
+data "vcd_nsxv_firewall_rule" "rule4" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+
+	rule_id      = vcd_nsxv_firewall_rule.rule4.id
+}
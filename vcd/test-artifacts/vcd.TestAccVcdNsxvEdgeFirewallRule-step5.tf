@@ -0,0 +1,24 @@
+
+resource "vcd_nsxv_firewall_rule" "rule5" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "test-rule-5"
+
+	source {
+		gateway_interfaces = ["internal"]
+	}
+  
+	destination {
+		gateway_interfaces = ["external"]
+	}
+
+	service {
+		protocol = "tcp"
+	}
+
+	service {
+		protocol = "udp"
+	}
+
+  }
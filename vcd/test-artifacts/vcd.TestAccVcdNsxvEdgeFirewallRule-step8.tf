@@ -0,0 +1,44 @@
+
+resource "vcd_nsxv_firewall_rule" "rule6" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "below-rule"
+	action = "accept"
+
+	source {
+		ip_addresses = ["10.10.10.0/24", "11.10.10.0/24"]
+	}
+  
+	destination {
+		ip_addresses = ["20.10.10.0/24", "21.10.10.0/24"]
+	}
+
+	service {
+		protocol = "any"
+	}
+}
+
+resource "vcd_nsxv_firewall_rule" "rule6-6" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "above-rule"
+	action = "accept"
+	above_rule_id = vcd_nsxv_firewall_rule.rule6.id
+
+
+	source {
+		ip_addresses = ["30.10.10.0/24", "31.10.10.0/24"]
+	}
+  
+	destination {
+		ip_addresses = ["40.10.10.0/24", "41.10.10.0/24"]
+	}
+
+	service {
+		protocol = "ANY"
+	}
+
+	depends_on = ["vcd_nsxv_firewall_rule.rule6"]
+}
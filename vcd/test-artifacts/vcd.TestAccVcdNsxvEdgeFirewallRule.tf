@@ -0,0 +1,51 @@
+
+resource "vcd_nsxv_firewall_rule" "rule0" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "test-rule"
+	rule_tag = "30000"
+	action = "deny"
+
+	source {
+		ip_addresses = ["any"]
+	}
+  
+	destination {
+		ip_addresses = ["192.168.1.110"]
+	}
+  
+	service {
+		protocol = "any"
+	}
+}
+
+resource "vcd_nsxv_firewall_rule" "rule0-2" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "rule 123123"
+	action = "deny"
+
+	source {
+		ip_addresses = ["4.4.4.4"]
+	}
+  
+	destination {
+		ip_addresses = ["5.5.5.5"]
+	}
+  
+	service {
+		protocol = "any"
+	}
+	# Dependency helps to ensure provisioning order (which becomes rule processing order)
+	depends_on = ["vcd_nsxv_firewall_rule.rule0"]
+}
+
+data "vcd_nsxv_firewall_rule" "rule0" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+
+	rule_id      = vcd_nsxv_firewall_rule.rule0.id
+}
@@ -0,0 +1,30 @@
+
+resource "vcd_nsxv_firewall_rule" "ip_sets" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "rule-with-ip_sets"
+	action = "accept"
+
+	source {
+		ip_sets = [vcd_nsxv_ip_set.aceeptance-ipset-1.name]
+	}
+  
+	destination {
+		ip_sets = [vcd_nsxv_ip_set.aceeptance-ipset-2.name]
+	}
+
+	service {
+		protocol = "any"
+	}
+}
+
+resource "vcd_nsxv_ip_set" "aceeptance-ipset-1" {
+	name = "acceptance test IPset 1"
+	ip_addresses = ["222.222.222.1/24"]
+}
+
+resource "vcd_nsxv_ip_set" "aceeptance-ipset-2" {
+	name = "acceptance test IPset 2"
+	ip_addresses = ["11.11.11.1-11.11.11.100", "12.12.12.1"]
+}
@@ -0,0 +1,64 @@
+
+
+resource "vcd_network_routed" "net" {
+	org = ""
+	vdc = ""
+  
+	name         = "fw-routed-net"
+	edge_gateway = ""
+	gateway      = "47.10.0.1"
+
+	static_ip_pool {
+	  start_address = "47.10.0.152"
+	  end_address   = "47.10.0.254"
+	}
+}
+resource "vcd_vapp" "fw-test" {
+  name = "fw-test"
+}
+
+resource "vcd_vapp_org_network" "vappNetwork1" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.fw-test.name
+  org_network_name   = vcd_network_routed.net.name 
+}
+
+resource "vcd_vapp_vm" "fw-vm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.fw-test.name
+  name          = "fw-test"
+  computer_name = "fw-test"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 2
+  cpu_cores     = 1
+
+  network {
+    name               = vcd_vapp_org_network.vappNetwork1.org_network_name
+    type               = "org"
+    ip_allocation_mode = "POOL"
+  }
+}
+
+resource "vcd_nsxv_firewall_rule" "vms" {
+	org          = ""
+	vdc          = ""
+	edge_gateway = ""
+	name = "rule-with-ip_sets"
+	action = "accept"
+
+	source {
+		vm_ids = [vcd_vapp_vm.fw-vm.id]
+	}
+  
+	destination {
+		ip_addresses = ["any"]
+	}
+
+	service {
+		protocol = "any"
+	}
+}
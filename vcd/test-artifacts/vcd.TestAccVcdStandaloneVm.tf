@@ -0,0 +1,56 @@
+
+resource "vcd_network_routed" "TestAccVcdStandaloneVm-net" {
+  name         = "TestAccVcdStandaloneVm-net"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.103.1"
+
+  static_ip_pool {
+    start_address = "10.10.103.2"
+    end_address   = "10.10.103.254"
+  }
+}
+
+resource "vcd_vm" "TestAccVcdStandaloneVm-empty" {
+  org              = ""
+  vdc              = ""
+  name             = "TestAccVcdStandaloneVm-empty"
+  computer_name    = "emptyVm"
+  memory           = 512
+  cpus             = 1
+  cpu_cores        = 1
+  os_type          = "sles11_64Guest"
+  hardware_version = "vmx-13"
+  power_on         = false
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.TestAccVcdStandaloneVm-net.name
+    ip_allocation_mode = "POOL"
+  }
+}
+
+resource "vcd_vm" "TestAccVcdStandaloneVm-template" {
+  org           = ""
+  vdc           = ""
+  name          = "TestAccVcdStandaloneVm-template"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 1
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.TestAccVcdStandaloneVm-net.name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "10.10.103.161"
+  }
+}
+
+data "vcd_vm" "TestAccVcdStandaloneVm-template" {
+  org  = ""
+  vdc  = ""
+  name = vcd_vm.TestAccVcdStandaloneVm-template.name
+}
@@ -0,0 +1,11 @@
+
+resource "vcd_vapp" "TestAccVcdVAppProperties" {
+  name = "TestAccVcdVAppProperties"
+  org  = ""
+  vdc  = ""
+
+  guest_properties = {
+	"guest.another.subkey" = "new-value"
+	"guest.third.subkey"   = "third-value"
+  }
+}
@@ -0,0 +1,6 @@
+
+resource "vcd_vapp" "TestAccVcdVAppProperties" {
+  name = "TestAccVcdVAppProperties"
+  org  = ""
+  vdc  = ""
+}
@@ -0,0 +1,11 @@
+
+resource "vcd_vapp" "TestAccVcdVAppProperties" {
+  name = "TestAccVcdVAppProperties"
+  org  = ""
+  vdc  = ""
+
+  guest_properties = {
+	"guest.hostname"       = "test-host"
+	"guest.another.subkey" = "another-value"
+  }
+}
@@ -0,0 +1,44 @@
+
+resource "vcd_network_routed" "TestAccVcdVAppRawNet" {
+  name         = "TestAccVcdVAppRawNet"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_vapp" "TestAccVcdVAppRawVapp" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppRawVapp"
+  depends_on   = ["vcd_network_routed.TestAccVcdVAppRawNet"]
+}
+
+resource "vcd_vapp_org_network" "vappNetwork1" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVAppRawVapp.name
+  org_network_name   = vcd_network_routed.TestAccVcdVAppRawNet.name 
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppRawVm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppRawVapp.name
+  name          = "TestAccVcdVAppRawVm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 1
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappNetwork1.org_network_name
+    ip_allocation_mode = "POOL"
+  }
+}
@@ -0,0 +1,19 @@
+
+# skip-binary-test: only for updates
+resource "vcd_vapp" "TestAccVcdVAppVmVapp" {
+  name = "TestAccVcdVAppVmVapp"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmVm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmVapp.name
+  name          = "TestAccVcdVAppVmVm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+}
@@ -0,0 +1,49 @@
+
+resource "vcd_vapp" "test-vapp" {
+  org = ""
+  vdc = ""
+
+  name       = "TestAccVcdVAppVmCreateCustomizationFalse"
+}
+
+resource "vcd_vapp_network" "vappNet" {
+  org = ""
+  vdc = ""
+
+  name       = "vapp-net"
+  vapp_name  = vcd_vapp.test-vapp.name
+  gateway    = "192.168.2.1"
+  netmask    = "255.255.255.0"
+  dns1       = "192.168.2.1"
+  dns2       = "192.168.2.2"
+  dns_suffix = "mybiz.biz"
+
+  static_ip_pool {
+    start_address = "192.168.2.51"
+    end_address   = "192.168.2.100"
+  }
+}
+
+
+resource "vcd_vapp_vm" "test-vm" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "TestAccVcdVAppVmCreateCustomizationFalseVM"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  customization {
+    force = false
+  }
+
+  network {
+    type               = "vapp"
+    name               = vcd_vapp_network.vappNet.name
+    ip_allocation_mode = "POOL"
+  }
+}
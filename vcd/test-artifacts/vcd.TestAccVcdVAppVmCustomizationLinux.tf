@@ -0,0 +1,54 @@
+
+resource "vcd_vapp" "test-vapp" {
+  org = ""
+  vdc = ""
+
+  name       = "TestAccVcdVAppVmCustomizationLinux"
+}
+
+resource "vcd_vapp_network" "vappNet" {
+  org = ""
+  vdc = ""
+
+  name       = "vapp-net"
+  vapp_name  = vcd_vapp.test-vapp.name
+  gateway    = "192.168.2.1"
+  netmask    = "255.255.255.0"
+  dns1       = "192.168.2.1"
+  dns2       = "192.168.2.2"
+  dns_suffix = "mybiz.biz"
+
+  static_ip_pool {
+    start_address = "192.168.2.51"
+    end_address   = "192.168.2.100"
+  }
+}
+
+# skip-binary-test: the customization script is written by the test
+resource "vcd_vapp_vm" "test-vm" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "TestAccVcdVAppVmCustomizationLinuxVM"
+  computer_name = "linux-vm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  customization {
+    linux_hostname  = "linux-vm"
+    linux_domain    = "example.com"
+    linux_timezone  = "Europe/Rome"
+    initscript_file = "/tmp/TestAccVcdVAppVmCustomizationLinux367834513/001/customization.sh"
+  }
+}
+
+data "vcd_vapp_vm" "test-vm" {
+  org       = ""
+  vdc       = ""
+  vapp_name = vcd_vapp.test-vapp.name
+  name      = vcd_vapp_vm.test-vm.name
+}
@@ -0,0 +1,48 @@
+
+resource "vcd_vapp" "test-vapp" {
+  org = ""
+  vdc = ""
+
+  name       = "TestAccVcdVAppVmCustomizationSettings"
+}
+
+resource "vcd_vapp_network" "vappNet" {
+  org = ""
+  vdc = ""
+
+  name       = "vapp-net"
+  vapp_name  = vcd_vapp.test-vapp.name
+  gateway    = "192.168.2.1"
+  netmask    = "255.255.255.0"
+  dns1       = "192.168.2.1"
+  dns2       = "192.168.2.2"
+  dns_suffix = "mybiz.biz"
+
+  static_ip_pool {
+    start_address = "192.168.2.51"
+    end_address   = "192.168.2.100"
+  }
+}
+
+# skip-binary-test: it will fail on purpose
+resource "vcd_vapp_vm" "test-vm-step3" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "TestAccVcdVAppVmCustomizationSettingsVM"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  customization {
+	enabled                = true
+	join_domain            = true
+	join_domain_name       = "UnrealDomain"
+	join_domain_user       = "NoUser"
+	join_domain_password   = "NoPass"
+	join_domain_account_ou = "ou=IT,dc=some,dc=com"
+  }
+}
@@ -0,0 +1,31 @@
+
+resource "vcd_vapp" "test-vapp" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmCustomizationSysprep"
+}
+
+resource "vcd_vapp_vm" "test-vm" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "TestAccVcdVAppVmCustomizationSysprepVM"
+  computer_name = "sysprep1"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  guest_properties = {
+    "guest.role" = "windows"
+  }
+
+  customization {
+    enabled             = false
+    sysprep_answer_file = <<-EOT
+      <unattend xmlns="urn:schemas-microsoft-com:unattend"><ComputerName>sysprep1</ComputerName></unattend>
+    EOT
+  }
+}
@@ -0,0 +1,72 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmDhcpWait" {
+  org = ""
+  vdc = ""
+
+  name       = "TestAccVcdVAppVmDhcpWait" 
+}
+
+resource "vcd_network_routed" "net" {
+  org = ""
+  vdc = ""
+
+  name         = "multinic-net"
+  edge_gateway = ""
+  gateway      = "11.10.0.1"
+
+  dhcp_pool {
+    start_address = "11.10.0.2"
+    end_address   = "11.10.0.100"
+  }
+
+  static_ip_pool {
+    start_address = "11.10.0.152"
+    end_address   = "11.10.0.254"
+  }
+}
+
+resource "vcd_vapp_org_network" "vappNetwork1" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVAppVmDhcpWait.name
+  org_network_name   = vcd_network_routed.net.name 
+}
+
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmDhcpWaitVM" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmDhcpWait.name
+  name          = "TestAccVcdVAppVmDhcpWaitVM"
+  computer_name = "dhcp-vm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  network_dhcp_wait_seconds = 310
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappNetwork1.org_network_name
+    ip_allocation_mode = "DHCP"
+    is_primary         = true
+  }
+ 
+  network {
+    type               = "none"
+    ip_allocation_mode = "NONE"
+    connected          = "false"
+  }
+}
+
+data "vcd_vapp_vm" "ds" {
+  org = ""
+  vdc = ""
+
+  vapp_name                 = vcd_vapp.TestAccVcdVAppVmDhcpWait.name
+  name                      = vcd_vapp_vm.TestAccVcdVAppVmDhcpWaitVM.name
+  network_dhcp_wait_seconds = 310
+  depends_on                = [vcd_vapp_vm.TestAccVcdVAppVmDhcpWaitVM]
+}
@@ -0,0 +1,32 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmDiskController" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmDiskController"
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmDiskControllerVM" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmDiskController.name
+  name          = "TestAccVcdVAppVmDiskControllerVM"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = false
+
+  disk_controller {
+    bus_type   = "paravirtual"
+    bus_number = 0
+    sharing    = "virtual"
+  }
+
+  disk_controller {
+    bus_type   = "paravirtual"
+    bus_number = 1
+    sharing    = "physical"
+  }
+}
@@ -0,0 +1,29 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmVapp" {
+  name = "TestAccVcdVAppVmVapp"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmVm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmVapp.name
+  name          = "TestAccVcdVAppVmVm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  extra_config = {
+    "disk.EnableUUID" = "FALSE"
+  }
+}
+
+data "vcd_vapp_vm" "vm" {
+  org       = ""
+  vdc       = ""
+  vapp_name = vcd_vapp_vm.TestAccVcdVAppVmVm.vapp_name
+  name      = vcd_vapp_vm.TestAccVcdVAppVmVm.name
+}
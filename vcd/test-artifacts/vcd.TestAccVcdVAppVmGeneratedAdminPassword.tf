@@ -0,0 +1,38 @@
+
+resource "vcd_vapp" "test-vapp" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmGeneratedAdminPassword"
+}
+
+resource "vcd_vapp_vm" "test-vm" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.test-vapp.name
+  name          = "TestAccVcdVAppVmGeneratedAdminPasswordVM"
+  computer_name = "generated-pwd"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  customization {
+    enabled                    = true
+    allow_local_admin_password = true
+    auto_generate_password     = true
+  }
+
+  readiness_check {
+    type    = "customization_done"
+    timeout = 600
+  }
+}
+
+data "vcd_vapp_vm" "test-vm" {
+  org       = ""
+  vdc       = ""
+  vapp_name = vcd_vapp.test-vapp.name
+  name      = vcd_vapp_vm.test-vm.name
+}
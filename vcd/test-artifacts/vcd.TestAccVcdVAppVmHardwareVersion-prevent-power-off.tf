@@ -0,0 +1,28 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmHardwareVersion" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmHardwareVersion"
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmHardwareVersionVM" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmHardwareVersion.name
+  name          = "TestAccVcdVAppVmHardwareVersionVM"
+  computer_name = "hw-version"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+  power_on      = true
+
+  os_type                  = "sles15_64Guest"
+  hardware_version         = "vmx-14"
+  prevent_update_power_off = true
+}
+
+data "vcd_vm_guest_os" "hw" {
+  hardware_version = "vmx-14"
+  family           = "Linux"
+}
@@ -0,0 +1,28 @@
+
+resource "vcd_vapp" "first" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmMove"
+}
+
+resource "vcd_vapp" "second" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmMoveOther"
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmMoveVM" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.second.name
+  name          = "TestAccVcdVAppVmMoveVM"
+  computer_name = "move-vm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  prevent_update_power_off = false
+}
@@ -0,0 +1,152 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmMultiNIC" {
+  org = ""
+  vdc = ""
+
+  name       = "TestAccVcdVAppVmMultiNIC"
+  depends_on = ["vcd_network_routed.net", "vcd_network_routed.net2"]
+}
+
+resource "vcd_vapp_network" "vappIsolatedNet" {
+  org = ""
+  vdc = ""
+
+  name       = "vapp-net"
+  vapp_name  = vcd_vapp.TestAccVcdVAppVmMultiNIC.name
+  gateway    = "192.168.2.1"
+  netmask    = "255.255.255.0"
+  dns1       = "192.168.2.1"
+  dns2       = "192.168.2.2"
+  dns_suffix = "mybiz.biz"
+
+  static_ip_pool {
+    start_address = "192.168.2.51"
+    end_address   = "192.168.2.100"
+  }
+}
+
+resource "vcd_network_routed" "net" {
+  org = ""
+  vdc = ""
+
+  name         = "multinic-net"
+  edge_gateway = ""
+  gateway      = "11.10.0.1"
+
+  dhcp_pool {
+    start_address = "11.10.0.2"
+    end_address   = "11.10.0.100"
+  }
+
+  static_ip_pool {
+    start_address = "11.10.0.152"
+    end_address   = "11.10.0.254"
+  }
+}
+
+resource "vcd_vapp_org_network" "vappAttachedNet" {
+  org = ""
+  vdc = ""
+
+  vapp_name        = vcd_vapp.TestAccVcdVAppVmMultiNIC.name
+  org_network_name = vcd_network_routed.net.name
+}
+
+resource "vcd_vapp_org_network" "vappAttachedRoutedNet2" {
+  org = ""
+  vdc = ""
+
+  vapp_name        = vcd_vapp.TestAccVcdVAppVmMultiNIC.name
+  org_network_name = vcd_network_routed.net2.name
+  is_fenced        = true
+}
+
+resource "vcd_vapp_network" "vappRoutedNet" {
+  org = ""
+  vdc = ""
+
+  name             = "vapp-routed-net"
+  vapp_name        = vcd_vapp.TestAccVcdVAppVmMultiNIC.name
+  gateway          = "192.168.2.1"
+  netmask          = "255.255.255.0"
+  org_network_name = vcd_network_routed.net.name
+}
+
+
+resource "vcd_network_routed" "net2" {
+  org = ""
+  vdc = ""
+
+  name         = "multinic-net2"
+  edge_gateway = ""
+  gateway      = "12.10.0.1"
+
+  static_ip_pool {
+    start_address = "12.10.0.152"
+    end_address   = "12.10.0.254"
+  }
+}
+
+# skip-binary-test: only for updates
+resource "vcd_vapp_vm" "TestAccVcdVAppVmMultiNICVM" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmMultiNIC.name
+  name          = "TestAccVcdVAppVmMultiNICVM"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 2
+  cpu_cores     = 1
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.net.name
+    ip_allocation_mode = "POOL"
+    is_primary         = true
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.net.name
+    ip_allocation_mode = "DHCP"
+    is_primary         = false
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.net.name
+    ip                 = "11.10.0.170"
+    ip_allocation_mode = "MANUAL"
+    is_primary         = false
+    connected          = true
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_network_routed.net2.name
+    ip_allocation_mode = "POOL"
+    is_primary         = false
+	mac                = "00:00:00:11:11:11"
+  }
+
+  network {
+    type               = "vapp"
+    name               = vcd_vapp_network.vappRoutedNet.name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "192.168.2.2"
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappAttachedNet.org_network_name
+    ip_allocation_mode = "POOL"
+  }
+
+  network {
+    type               = "org"
+    name              = vcd_vapp_org_network.vappAttachedRoutedNet2.org_network_name
+    ip_allocation_mode = "POOL"
+  }
+}
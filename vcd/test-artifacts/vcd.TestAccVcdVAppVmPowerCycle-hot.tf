@@ -0,0 +1,23 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmPowerCycle" {
+  org  = ""
+  vdc  = ""
+  name = "TestAccVcdVAppVmPowerCycle"
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmPowerCycleVM" {
+  org = ""
+  vdc = ""
+
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmPowerCycle.name
+  name          = "TestAccVcdVAppVmPowerCycleVM"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 2048
+  cpus          = 1
+  cpu_cores     = 1
+
+  memory_hot_add_enabled   = true
+  cpu_hot_add_enabled      = false
+  prevent_update_power_off = true
+}
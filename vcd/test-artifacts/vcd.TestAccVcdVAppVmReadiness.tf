@@ -0,0 +1,39 @@
+
+resource "vcd_vapp" "TestAccVcdVAppVmVapp" {
+  name = "TestAccVcdVAppVmVapp"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmVm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmVapp.name
+  name          = "TestAccVcdVAppVmVm"
+  computer_name = "ready-vm"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 512
+  cpus          = 1
+  cpu_cores     = 1
+
+  guest_properties = {
+    "ready" = "yes"
+  }
+
+  readiness_check {
+    type    = "tools_running"
+    timeout = 300
+  }
+
+  readiness_check {
+    type    = "customization_done"
+    timeout = 600
+  }
+
+  readiness_check {
+    type                 = "guest_property"
+    guest_property_key   = "ready"
+    guest_property_value = "yes"
+  }
+}
@@ -0,0 +1,76 @@
+
+resource "vcd_network_routed" "TestAccVcdVAppVmNet" {
+  name         = "TestAccVcdVAppVmNet"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_independent_disk" "TestAccVcdVAppVm_Basic_1" {
+  org             = ""
+  vdc             = ""
+  name            = "TestAccVcdIndependentDiskBasic"
+  size_in_mb      = "5"
+  bus_type        = "SCSI"
+  bus_sub_type    = "lsilogicsas"
+  storage_profile = "*"
+}
+
+resource "vcd_vapp" "TestAccVcdVAppVmVapp" {
+  name = "TestAccVcdVAppVmVapp"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_vapp_org_network" "vappNetwork1" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVAppVmVapp.name
+  org_network_name   = vcd_network_routed.TestAccVcdVAppVmNet.name 
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmVm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmVapp.name
+  name          = "TestAccVcdVAppVmVm"
+  computer_name = "TestAccVcdVAppVmVm-unique"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 2
+  cpu_cores     = 1
+
+  metadata = {
+    vm_metadata = "VM Metadata."
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappNetwork1.org_network_name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "10.10.102.161"
+  }
+
+  disk {
+    name        = vcd_independent_disk.TestAccVcdVAppVm_Basic_1.name
+    bus_number  = 1
+    unit_number = 0
+  }
+}
+
+output "disk" {
+  value = tolist(vcd_vapp_vm.TestAccVcdVAppVmVm.disk)[0].name
+}
+output "disk_bus_number" {
+  value = tolist(vcd_vapp_vm.TestAccVcdVAppVmVm.disk)[0].bus_number
+}
+output "disk_unit_number" {
+  value = tolist(vcd_vapp_vm.TestAccVcdVAppVmVm.disk)[0].unit_number
+}
@@ -0,0 +1,73 @@
+
+resource "vcd_network_routed" "TestAccVcdVAppVmNet" {
+  name         = "TestAccVcdVAppVmNet"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+
+resource "vcd_vapp" "TestAccVcdVAppVmVapp" {
+  name = "TestAccVcdVAppVmVapp"
+  org  = ""
+  vdc  = "" 
+}
+
+resource "vcd_vapp_org_network" "vappNetwork1" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVAppVmVapp.name
+  org_network_name   = vcd_network_routed.TestAccVcdVAppVmNet.name 
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmVm" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp.TestAccVcdVAppVmVapp.name
+  name          = "TestAccVcdVAppVmVm"
+  computer_name = "TestAccVcdVAppVmVm-unique"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 2
+  cpu_cores     = 1
+
+  metadata = {
+    vm_metadata = "VM Metadata."
+  }
+
+  network {
+    type               = "org"
+    name               = vcd_vapp_org_network.vappNetwork1.org_network_name
+    ip_allocation_mode = "MANUAL"
+    ip                 = "10.10.102.161"
+  }
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppVmVm-clone" {
+  org           = ""
+  vdc           = ""
+  vapp_name     = vcd_vapp_vm.TestAccVcdVAppVmVm.vapp_name
+  name          = "TestAccVcdVAppVmVm-clone"
+  computer_name = vcd_vapp_vm.TestAccVcdVAppVmVm.computer_name
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 2
+  cpu_cores     = 1
+
+  metadata = vcd_vapp_vm.TestAccVcdVAppVmVm.metadata
+
+  network {
+    name               = vcd_vapp_vm.TestAccVcdVAppVmVm.network.0.name
+    ip                 = "10.10.102.162"
+    type               = vcd_vapp_vm.TestAccVcdVAppVmVm.network.0.type
+    ip_allocation_mode = vcd_vapp_vm.TestAccVcdVAppVmVm.network.0.ip_allocation_mode
+  }
+}
@@ -0,0 +1,19 @@
+
+resource "vcd_vapp" "TestAccVcdVAppHwVirt" {
+  name = "TestAccVcdVAppHwVirt"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_vapp_vm" "TestAccVcdVAppHwVirt" {
+  org                            = ""
+  vdc                            = ""
+  vapp_name                      = vcd_vapp.TestAccVcdVAppHwVirt.name
+  name                           = "TestAccVcdVAppHwVirt"
+  catalog_name                   = "TestSuiteCatalog"
+  template_name                  = "TestSuiteOVA"
+  memory                         = 384
+  cpus                           = 2
+  cpu_cores                      = 1
+  expose_hardware_virtualization = false
+}
@@ -0,0 +1,96 @@
+
+resource "vcd_vapp" "TestAccVcdVappNetworkDS" {
+  name = "TestAccVcdVappNetworkDS"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_network_routed" "TestAccVcdVappNetworkDSOrgNetwork" {
+  name         = "TestAccVcdVappNetworkDSOrgNetwork"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_vapp_network" "createdVappNetwork" {
+  org                = ""
+  vdc                = ""
+  name               = "TestAccVcdVappNetworkDS"
+  description        = "Created in test"
+  vapp_name          = vcd_vapp.TestAccVcdVappNetworkDS.name
+  gateway            = "192.168.0.1"
+  netmask            = "255.255.255.0"
+  dns1               = "8.8.8.8"
+  dns2               = "1.1.1.1"
+  dns_suffix         = "biz.biz"
+  guest_vlan_allowed = true
+
+  static_ip_pool {
+    start_address = "192.168.0.10"
+    end_address   = "192.168.0.20"
+  }
+
+  dhcp_pool {
+    max_lease_time     = "3500"
+    default_lease_time = "2400"
+    start_address      = "192.168.0.30"
+    end_address        = "192.168.0.40"
+    enabled            = "true"
+  }
+
+  org_network_name      = vcd_network_routed.TestAccVcdVappNetworkDSOrgNetwork.name
+  retain_ip_mac_enabled = "true"
+}
+ 
+
+data "vcd_vapp_network" "network-ds" {
+  name       =  vcd_vapp_network.createdVappNetwork.name
+  vapp_name  = "TestAccVcdVappNetworkDS"
+  depends_on =  [vcd_vapp_network.createdVappNetwork]
+}
+
+output "netmask" {
+  value = data.vcd_vapp_network.network-ds.netmask 
+} 
+output "description" {
+  value = data.vcd_vapp_network.network-ds.description 
+} 
+output "gateway" {
+  value = data.vcd_vapp_network.network-ds.gateway 
+} 
+output "dns1" {
+  value = data.vcd_vapp_network.network-ds.dns1 
+} 
+output "dns2" {
+  value = data.vcd_vapp_network.network-ds.dns2 
+} 
+output "dnsSuffix" {
+  value = data.vcd_vapp_network.network-ds.dns_suffix 
+} 
+output "guestVlanAllowed" {
+  value = data.vcd_vapp_network.network-ds.guest_vlan_allowed
+} 
+output "dhcpStartAddress" {
+  value  = tolist(data.vcd_vapp_network.network-ds.dhcp_pool)[0].start_address
+}
+output "dhcpEndAddress" {
+  value  = tolist(data.vcd_vapp_network.network-ds.dhcp_pool)[0].end_address
+}
+output "staticIpPoolStartAddress" {
+  value  = tolist(data.vcd_vapp_network.network-ds.static_ip_pool)[0].start_address
+}
+output "staticIpPoolEndAddress" {
+  value  = tolist(data.vcd_vapp_network.network-ds.static_ip_pool)[0].end_address
+}
+output "orgNetwork" {
+  value = data.vcd_vapp_network.network-ds.org_network_name
+} 
+output "retain_ip_mac_enabled" {
+  value = data.vcd_vapp_network.network-ds.retain_ip_mac_enabled
+}
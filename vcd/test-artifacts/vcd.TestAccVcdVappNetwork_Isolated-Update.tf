@@ -0,0 +1,63 @@
+
+resource "vcd_vapp" "TestAccVappForNetworkTest" {
+  name = "TestAccVappForNetworkTest"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_network_routed" "TestAccVcdVAppNet" {
+  name         = "TestAccVcdVAppNet"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_network_routed" "TestAccVcdVAppNet2" {
+  name         = "TestAccVcdVAppNet2"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.103.1"
+
+  static_ip_pool {
+    start_address = "10.10.103.2"
+    end_address   = "10.10.103.254"
+  }
+}
+
+resource "vcd_vapp_network" "TestAccVcdVappNetwork_Isolated" {
+  org                = ""
+  vdc                = ""
+  name               = "TestAccVcdVappNetwork_Isolated"
+  description        = "update"
+  vapp_name          = "TestAccVappForNetworkTest"
+  gateway            = "192.168.1.1"
+  netmask            = "255.255.255.0"
+  dns1               = "8.8.8.7"
+  dns2               = "1.1.1.2"
+  dns_suffix         = "updated"
+  guest_vlan_allowed = false
+  static_ip_pool {
+    start_address = "192.168.1.11"
+    end_address   = "192.168.1.21"
+  }
+
+  dhcp_pool {
+    max_lease_time     = "7300"
+    default_lease_time = "3500"
+    start_address      = "192.168.1.22"
+    end_address        = "192.168.1.23"
+    enabled            = "false"
+  }
+
+  org_network_name      = ""
+  retain_ip_mac_enabled = "false"
+
+  depends_on = ["vcd_vapp.TestAccVappForNetworkTest", "vcd_network_routed.TestAccVcdVAppNet", "vcd_network_routed.TestAccVcdVAppNet2"]
+}
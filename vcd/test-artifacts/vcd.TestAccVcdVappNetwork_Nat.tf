@@ -0,0 +1,51 @@
+
+resource "vcd_vapp" "TestAccVappForNetworkTest" {
+  name = "TestAccVappForNetworkTest"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_network_routed" "TestAccVcdVAppNet" {
+  name         = "TestAccVcdVAppNet"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_vapp_network" "TestAccVcdVappNetwork_Nat" {
+  org                = ""
+  vdc                = ""
+  name               = "TestAccVcdVappNetwork_Nat"
+  description        = "network description"
+  vapp_name          = "TestAccVappForNetworkTest"
+  gateway            = "192.168.1.1"
+  netmask            = "255.255.255.0"
+  dns1               = "8.8.8.8"
+  dns2               = "1.1.1.1"
+  dns_suffix         = "biz.biz"
+  guest_vlan_allowed = true
+
+  static_ip_pool {
+    start_address = "192.168.1.10"
+    end_address   = "192.168.1.20"
+  }
+
+  dhcp_pool {
+    max_lease_time     = "7200"
+    default_lease_time = "3600"
+    start_address      = "192.168.1.21"
+    end_address        = "192.168.1.22"
+    enabled            = "true"
+  }
+
+  org_network_name      = "TestAccVcdVAppNet"
+  retain_ip_mac_enabled = "false"
+
+  depends_on = ["vcd_vapp.TestAccVappForNetworkTest", "vcd_network_routed.TestAccVcdVAppNet"]
+}
@@ -0,0 +1,40 @@
+
+resource "vcd_vapp" "TestAccVcdVappOrgNetworkDS" {
+  name = "TestAccVcdVappOrgNetworkDS"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_network_routed" "TestAccVcdVappOrgNetworkDSOrgNetwork" {
+  name         = "TestAccVcdVappOrgNetworkDSOrgNetwork"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_vapp_org_network" "createVappOrgNetwork" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVappOrgNetworkDS.name
+  org_network_name   = vcd_network_routed.TestAccVcdVappOrgNetworkDSOrgNetwork.name
+  
+  is_fenced = "true"
+
+  retain_ip_mac_enabled = "true"
+}
+
+data "vcd_vapp_org_network" "network-ds" {
+  vapp_name        = "TestAccVcdVappOrgNetworkDS"
+  org_network_name = vcd_vapp_org_network.createVappOrgNetwork.org_network_name
+  depends_on 	   = [vcd_vapp_org_network.createVappOrgNetwork]
+}
+
+output "retain_ip_mac_enabled" {
+  value = data.vcd_vapp_org_network.network-ds.retain_ip_mac_enabled
+}  
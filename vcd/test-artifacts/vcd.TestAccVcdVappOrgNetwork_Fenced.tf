@@ -0,0 +1,30 @@
+
+resource "vcd_vapp" "TestAccVcdVappOrgNetwork_Fenced" {
+  name = "TestAccVcdVappOrgNetwork_Fenced"
+  org  = ""
+  vdc  = ""
+}
+
+resource "vcd_network_routed" "TestAccVcdVAppNetFenced" {
+  name         = "TestAccVcdVAppNetFenced"
+  org          = ""
+  vdc          = ""
+  edge_gateway = ""
+  gateway      = "10.10.102.1"
+
+  static_ip_pool {
+    start_address = "10.10.102.2"
+    end_address   = "10.10.102.254"
+  }
+}
+
+resource "vcd_vapp_org_network" "TestAccVcdVappOrgNetwork_Fenced" {
+  org                = ""
+  vdc                = ""
+  vapp_name          = vcd_vapp.TestAccVcdVappOrgNetwork_Fenced.name
+  org_network_name   = vcd_network_routed.TestAccVcdVAppNetFenced.name
+  
+  is_fenced = "true"
+
+  retain_ip_mac_enabled = "true"
+}
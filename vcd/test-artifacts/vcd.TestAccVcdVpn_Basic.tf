@@ -0,0 +1,39 @@
+
+resource "vcd_edgegateway_vpn" "TestAccVcdVpnVpn" {
+  org                 = ""
+  vdc                 = ""
+  edge_gateway        = ""
+  name                = "TestAccVcdVpnSite"
+  description         = "Description"
+  encryption_protocol = "AES256"
+  mtu                 = 1400
+  peer_id             = ""
+  peer_ip_address     = ""
+  local_id            = ""
+  local_ip_address    = ""
+  shared_secret       = ""
+
+  peer_subnets {
+    peer_subnet_name    = "192.168.5.0/24"
+    peer_subnet_gateway = "192.168.5.0"
+    peer_subnet_mask    = "255.255.255.0"
+  }
+
+  peer_subnets {
+    peer_subnet_name    = "192.168.6.0/24"
+    peer_subnet_gateway = "192.168.6.0"
+    peer_subnet_mask    = "255.255.255.0"
+  }
+
+  local_subnets {
+    local_subnet_name    = "10.150.192.0/24"
+    local_subnet_gateway = "10.150.192.0"
+    local_subnet_mask    = "255.255.255.0"
+  }
+
+  local_subnets {
+    local_subnet_name    = "10.150.193.0/24"
+    local_subnet_gateway = "10.150.193.0"
+    local_subnet_mask    = "255.255.255.0"
+  }
+}
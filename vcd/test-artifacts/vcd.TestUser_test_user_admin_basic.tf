@@ -0,0 +1,9 @@
+
+# skip-binary-test: depends on external file
+resource "vcd_org_user" "test_user_admin" {
+  org            = ""
+  name           = "test_user_admin"
+  password_file  = "org_user_pwd.txt"
+  role           = "Organization Administrator"
+  take_ownership = true
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_admin" {
+  org               = ""
+  name              = "test_user_admin"
+  password          = "CHANGE-ME"
+  full_name         = "test user admin"
+  description       = "Org user test_user_admin"
+  role              = "Organization Administrator"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 12
+  deployed_vm_quota = 13
+  instant_messaging = "@test_user_admin"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_admin@test.company.org"
+}
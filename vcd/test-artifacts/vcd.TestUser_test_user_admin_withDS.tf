@@ -0,0 +1,14 @@
+
+data "vcd_org" "" {
+  name = ""
+}
+
+resource "vcd_org_user" "test_user_admin" {
+  org               = data.vcd_org..name
+  name              = "test_user_admin"
+  password          = "CHANGE-ME"
+  role              = "Organization Administrator"
+  deployed_vm_quota = data.vcd_org..deployed_vm_quota
+  stored_vm_quota   = data.vcd_org..stored_vm_quota
+  take_ownership    = true
+}
@@ -0,0 +1,9 @@
+
+# skip-binary-test: depends on external file
+resource "vcd_org_user" "test_user_catalog_author" {
+  org            = ""
+  name           = "test_user_catalog_author"
+  password_file  = "org_user_pwd.txt"
+  role           = "Catalog Author"
+  take_ownership = true
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_catalog_author" {
+  org               = ""
+  name              = "test_user_catalog_author"
+  password          = "CHANGE-ME"
+  full_name         = "test user catalog author"
+  description       = "Org user test_user_catalog_author"
+  role              = "Catalog Author"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 20
+  deployed_vm_quota = 25
+  instant_messaging = "@test_user_catalog_author"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_catalog_author@test.company.org"
+}
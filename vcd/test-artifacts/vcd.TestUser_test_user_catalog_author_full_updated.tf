@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_catalog_author" {
+  org               = ""
+  name              = "test_user_catalog_author"
+  password          = "CHANGE-ME"
+  full_name         = "test==user==catalog==author"
+  description       = "Org User updated test_user_catalog_author"
+  role              = "Organization Administrator"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 21
+  deployed_vm_quota = 26
+  instant_messaging = "@test_user_catalog_author"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_catalog_author@test.company.org"
+}
@@ -0,0 +1,9 @@
+
+# skip-binary-test: depends on external file
+resource "vcd_org_user" "test_user_console_access" {
+  org            = ""
+  name           = "test_user_console_access"
+  password_file  = "org_user_pwd.txt"
+  role           = "Console Access Only"
+  take_ownership = true
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_console_access" {
+  org               = ""
+  name              = "test_user_console_access"
+  password          = "CHANGE-ME"
+  full_name         = "test==user==console==access"
+  description       = "Org User updated test_user_console_access"
+  role              = "Catalog Author"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 19
+  deployed_vm_quota = 23
+  instant_messaging = "@test_user_console_access"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_console_access@test.company.org"
+}
@@ -0,0 +1,9 @@
+
+# skip-binary-test: depends on external file
+resource "vcd_org_user" "test_user_vapp_author" {
+  org            = ""
+  name           = "test_user_vapp_author"
+  password_file  = "org_user_pwd.txt"
+  role           = "vApp Author"
+  take_ownership = true
+}
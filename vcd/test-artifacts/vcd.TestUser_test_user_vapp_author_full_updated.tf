@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_vapp_author" {
+  org               = ""
+  name              = "test_user_vapp_author"
+  password          = "CHANGE-ME"
+  full_name         = "test==user==vapp==author"
+  description       = "Org User updated test_user_vapp_author"
+  role              = "vApp User"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 15
+  deployed_vm_quota = 17
+  instant_messaging = "@test_user_vapp_author"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_vapp_author@test.company.org"
+}
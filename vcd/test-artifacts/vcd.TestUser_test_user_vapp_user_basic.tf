@@ -0,0 +1,9 @@
+
+# skip-binary-test: depends on external file
+resource "vcd_org_user" "test_user_vapp_user" {
+  org            = ""
+  name           = "test_user_vapp_user"
+  password_file  = "org_user_pwd.txt"
+  role           = "vApp User"
+  take_ownership = true
+}
@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_vapp_user" {
+  org               = ""
+  name              = "test_user_vapp_user"
+  password          = "CHANGE-ME"
+  full_name         = "test user vapp user"
+  description       = "Org user test_user_vapp_user"
+  role              = "vApp User"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 16
+  deployed_vm_quota = 19
+  instant_messaging = "@test_user_vapp_user"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_vapp_user@test.company.org"
+}
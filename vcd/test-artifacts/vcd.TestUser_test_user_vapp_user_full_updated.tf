@@ -0,0 +1,17 @@
+
+resource "vcd_org_user" "test_user_vapp_user" {
+  org               = ""
+  name              = "test_user_vapp_user"
+  password          = "CHANGE-ME"
+  full_name         = "test==user==vapp==user"
+  description       = "Org User updated test_user_vapp_user"
+  role              = "Console Access Only"
+  enabled           = true
+  take_ownership    = true
+  provider_type     = "INTEGRATED"
+  stored_vm_quota   = 17
+  deployed_vm_quota = 20
+  instant_messaging = "@test_user_vapp_user"
+  telephone         = "999 888-7777"
+  email_address     = "test_user_vapp_user@test.company.org"
+}
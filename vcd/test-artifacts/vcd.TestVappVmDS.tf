@@ -0,0 +1,32 @@
+
+resource "vcd_vapp" "web" {
+  name = "web"
+}
+
+resource "vcd_vapp_vm" "web1" {
+  vapp_name     = vcd_vapp.web.name
+  name          = "web1"
+  catalog_name  = "TestSuiteCatalog"
+  template_name = "TestSuiteOVA"
+  memory        = 1024
+  cpus          = 2
+  cpu_cores     = 1
+  power_on      = false
+
+  storage_profile = "*"
+
+  customization {
+    enabled               = true
+    change_sid            = false
+    join_domain           = false
+    number_of_auto_logons = 0
+  }
+}
+
+data "vcd_vapp_vm" "vm-ds" {
+  name             = vcd_vapp_vm.web1.name
+  vapp_name        = vcd_vapp.web.name
+  org              = ""
+  vdc              = ""
+  depends_on       = [vcd_vapp_vm.web1]
+}
@@ -0,0 +1,212 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// Firmware and boot options are available in VCD 10.3+ and are not handled by govcd. They are read and updated
+// with a VM payload that includes only the needed sections.
+
+const (
+	vmFirmwareBios = "bios"
+	vmFirmwareEfi  = "efi"
+
+	// vmBootOptionsApiVersion is the API version that returns the firmware and EFI secure boot
+	vmBootOptionsApiVersion = "36.0"
+
+	// vmSecureBootMinHardwareVersion is the first virtual hardware version that supports EFI secure boot
+	vmSecureBootMinHardwareVersion = 13
+)
+
+// vmFirmwareFields are the fields that need the VM to be powered off
+var vmFirmwareFields = []string{"firmware", "secure_boot"}
+
+// vmBootOptionsFields are the fields that can be changed while the VM is powered on
+var vmBootOptionsFields = []string{"boot_delay", "enter_bios_setup_on_next_boot", "boot_retry_enabled", "boot_retry_delay"}
+
+type vmBootOptions struct {
+	BootDelay            *int  `xml:"BootDelay,omitempty"`
+	EnterBiosSetup       *bool `xml:"EnterBIOSSetup,omitempty"`
+	BootRetryEnabled     *bool `xml:"BootRetryEnabled,omitempty"`
+	BootRetryDelay       *int  `xml:"BootRetryDelay,omitempty"`
+	EfiSecureBootEnabled *bool `xml:"EfiSecureBootEnabled,omitempty"`
+}
+
+// vmBootSettingsRead is used to read the firmware and the boot options of a VM
+type vmBootSettingsRead struct {
+	XMLName       xml.Name `xml:"Vm"`
+	VmSpecSection *struct {
+		Firmware string `xml:"Firmware"`
+	} `xml:"VmSpecSection"`
+	BootOptions *vmBootOptions `xml:"BootOptions"`
+}
+
+// vmSpecSectionWithFirmware adds the firmware to the VM spec section known by govcd
+type vmSpecSectionWithFirmware struct {
+	*types.VmSpecSection
+	Firmware string `xml:"Firmware,omitempty"`
+}
+
+// vmBootSettingsUpdate is the payload of reconfigureVm that changes the firmware and the boot options
+type vmBootSettingsUpdate struct {
+	XMLName       xml.Name                   `xml:"Vm"`
+	Xmlns         string                     `xml:"xmlns,attr"`
+	Ovf           string                     `xml:"xmlns:ovf,attr"`
+	Name          string                     `xml:"name,attr"`
+	Description   string                     `xml:"Description,omitempty"`
+	VmSpecSection *vmSpecSectionWithFirmware `xml:"VmSpecSection,omitempty"`
+	BootOptions   *vmBootOptions             `xml:"BootOptions,omitempty"`
+}
+
+// hasVmBootSettings returns true if any of the firmware and boot option fields is set in the configuration
+func hasVmBootSettings(d *schema.ResourceData) bool {
+	for _, field := range append(vmFirmwareFields, vmBootOptionsFields...) {
+		if isVmFieldSet(d, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// getVmBootSettings retrieves the firmware and the boot options of a VM
+func getVmBootSettings(vcdClient *VCDClient, vm *govcd.VM) (*vmBootSettingsRead, error) {
+	bootSettings := &vmBootSettingsRead{}
+	_, err := vcdClient.Client.ExecuteRequestWithApiVersion(vm.VM.HREF, http.MethodGet, types.MimeVM,
+		"error retrieving VM boot options: %s", nil, bootSettings, vmBootOptionsApiVersion)
+	if err != nil {
+		return nil, err
+	}
+	return bootSettings, nil
+}
+
+// updateVmBootSettings sends to VCD the firmware and boot option fields that are set.
+// A change of firmware or secure boot requires the VM to be powered off
+func updateVmBootSettings(d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) error {
+	if vcdClient.Client.APIVCDMaxVersionIs("< " + vmBootOptionsApiVersion) {
+		return fmt.Errorf("firmware and boot options are only available for VCD 10.3+")
+	}
+
+	payload := &vmBootSettingsUpdate{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		Name:        vm.VM.Name,
+		Description: vm.VM.Description,
+	}
+
+	if isVmFieldSet(d, "firmware") && vm.VM.VmSpecSection != nil {
+		vmSpecSectionModified := true
+		vmSpecSection := *vm.VM.VmSpecSection
+		vmSpecSection.Modified = &vmSpecSectionModified
+		payload.VmSpecSection = &vmSpecSectionWithFirmware{
+			VmSpecSection: &vmSpecSection,
+			Firmware:      d.Get("firmware").(string),
+		}
+	}
+
+	bootOptions := &vmBootOptions{}
+	hasBootOptions := false
+	if isVmFieldSet(d, "secure_boot") {
+		bootOptions.EfiSecureBootEnabled = takeBoolPointer(d.Get("secure_boot").(bool))
+		hasBootOptions = true
+	}
+	if isVmFieldSet(d, "boot_delay") {
+		bootOptions.BootDelay = takeIntPointer(d.Get("boot_delay").(int))
+		hasBootOptions = true
+	}
+	if isVmFieldSet(d, "enter_bios_setup_on_next_boot") {
+		bootOptions.EnterBiosSetup = takeBoolPointer(d.Get("enter_bios_setup_on_next_boot").(bool))
+		hasBootOptions = true
+	}
+	if isVmFieldSet(d, "boot_retry_enabled") {
+		bootOptions.BootRetryEnabled = takeBoolPointer(d.Get("boot_retry_enabled").(bool))
+		hasBootOptions = true
+	}
+	if isVmFieldSet(d, "boot_retry_delay") {
+		bootOptions.BootRetryDelay = takeIntPointer(d.Get("boot_retry_delay").(int))
+		hasBootOptions = true
+	}
+	if hasBootOptions {
+		payload.BootOptions = bootOptions
+	}
+
+	if payload.VmSpecSection == nil && payload.BootOptions == nil {
+		return nil
+	}
+
+	log.Printf("[TRACE] updating firmware and boot options of VM %s", vm.VM.Name)
+	task, err := vcdClient.Client.ExecuteTaskRequestWithApiVersion(vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error updating VM boot options: %s", payload, vmBootOptionsApiVersion)
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for the boot options update of VM %s: %s", vm.VM.Name, err)
+	}
+	return vm.Refresh()
+}
+
+// setVmBootSettings stores the firmware and the boot options of a VM into the state.
+// "enter_bios_setup_on_next_boot" is not read, as VCD resets it after the VM boots
+func setVmBootSettings(d *schema.ResourceData, bootSettings *vmBootSettingsRead) {
+	if bootSettings.VmSpecSection != nil && bootSettings.VmSpecSection.Firmware != "" {
+		_ = d.Set("firmware", bootSettings.VmSpecSection.Firmware)
+	}
+	bootOptions := bootSettings.BootOptions
+	if bootOptions == nil {
+		return
+	}
+	if bootOptions.EfiSecureBootEnabled != nil {
+		_ = d.Set("secure_boot", *bootOptions.EfiSecureBootEnabled)
+	}
+	if bootOptions.BootDelay != nil {
+		_ = d.Set("boot_delay", *bootOptions.BootDelay)
+	}
+	if bootOptions.BootRetryEnabled != nil {
+		_ = d.Set("boot_retry_enabled", *bootOptions.BootRetryEnabled)
+	}
+	if bootOptions.BootRetryDelay != nil {
+		_ = d.Set("boot_retry_delay", *bootOptions.BootRetryDelay)
+	}
+}
+
+// validateVmBootSettings checks the boot settings against the firmware and the hardware version.
+// Values that are not known at plan time, such as the ones coming from a template, are not checked
+func validateVmBootSettings(diff vmDiffGetter) error {
+	if diff.NewValueKnown("boot_retry_delay") && diff.NewValueKnown("boot_retry_enabled") &&
+		diff.Get("boot_retry_delay").(int) > 0 && !diff.Get("boot_retry_enabled").(bool) {
+		return fmt.Errorf("'boot_retry_delay' requires 'boot_retry_enabled' to be true")
+	}
+
+	if !diff.NewValueKnown("secure_boot") || !diff.Get("secure_boot").(bool) {
+		return nil
+	}
+	if diff.NewValueKnown("firmware") {
+		firmware := diff.Get("firmware").(string)
+		if firmware != "" && firmware != vmFirmwareEfi {
+			return fmt.Errorf("'secure_boot' requires 'firmware' to be '%s', got '%s'", vmFirmwareEfi, firmware)
+		}
+	}
+	if diff.NewValueKnown("hardware_version") {
+		hardwareVersion := diff.Get("hardware_version").(string)
+		if hardwareVersion == "" {
+			return nil
+		}
+		number, err := getHardwareVersionNumber(hardwareVersion)
+		if err != nil {
+			return err
+		}
+		if number < vmSecureBootMinHardwareVersion {
+			return fmt.Errorf("'secure_boot' requires hardware version vmx-%d or later, got '%s'",
+				vmSecureBootMinHardwareVersion, hardwareVersion)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// testVmDiff is a vmDiffGetter where the values that are not in the map are unknown
+type testVmDiff map[string]interface{}
+
+func (diff testVmDiff) Get(key string) interface{} {
+	if value, ok := diff[key]; ok {
+		return value
+	}
+	return vappVmSchema[key].ZeroValue()
+}
+
+func (diff testVmDiff) NewValueKnown(key string) bool {
+	_, ok := diff[key]
+	return ok
+}
+
+func TestValidateVmBootSettings(t *testing.T) {
+	tests := []struct {
+		name        string
+		diff        testVmDiff
+		expectError bool
+	}{
+		{"nothing-known", testVmDiff{}, false},
+		{"secure-boot-efi", testVmDiff{"secure_boot": true, "firmware": "efi", "hardware_version": "vmx-14"}, false},
+		{"secure-boot-unknown-firmware", testVmDiff{"secure_boot": true}, false},
+		{"secure-boot-bios", testVmDiff{"secure_boot": true, "firmware": "bios"}, true},
+		{"secure-boot-old-hardware", testVmDiff{"secure_boot": true, "firmware": "efi", "hardware_version": "vmx-11"}, true},
+		{"secure-boot-invalid-hardware", testVmDiff{"secure_boot": true, "hardware_version": "14"}, true},
+		{"no-secure-boot-old-hardware", testVmDiff{"secure_boot": false, "firmware": "bios", "hardware_version": "vmx-11"}, false},
+		{"retry-delay-without-retry", testVmDiff{"boot_retry_delay": 1000, "boot_retry_enabled": false}, true},
+		{"retry-delay-with-retry", testVmDiff{"boot_retry_delay": 1000, "boot_retry_enabled": true}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateVmBootSettings(test.diff)
+			if test.expectError && err == nil {
+				t.Errorf("expected error, got none")
+			}
+			if !test.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestVmBootSettingsXml(t *testing.T) {
+	vmXml := `<Vm xmlns="` + types.XMLNamespaceVCloud + `" xmlns:ovf="` + types.XMLNamespaceOVF + `" name="vm1">
+  <VmSpecSection Modified="false">
+    <ovf:Info>Virtual Machine specification</ovf:Info>
+    <OsType>ubuntu64Guest</OsType>
+    <Firmware>efi</Firmware>
+  </VmSpecSection>
+  <BootOptions>
+    <BootDelay>2000</BootDelay>
+    <EnterBIOSSetup>false</EnterBIOSSetup>
+    <BootRetryEnabled>true</BootRetryEnabled>
+    <BootRetryDelay>10000</BootRetryDelay>
+    <EfiSecureBootEnabled>true</EfiSecureBootEnabled>
+  </BootOptions>
+</Vm>`
+	read := &vmBootSettingsRead{}
+	err := xml.Unmarshal([]byte(vmXml), read)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+	if read.VmSpecSection == nil || read.VmSpecSection.Firmware != "efi" {
+		t.Errorf("expected firmware 'efi', got %+v", read.VmSpecSection)
+	}
+	if read.BootOptions == nil || *read.BootOptions.BootDelay != 2000 || !*read.BootOptions.EfiSecureBootEnabled ||
+		*read.BootOptions.BootRetryDelay != 10000 {
+		t.Errorf("unexpected boot options: %+v", read.BootOptions)
+	}
+
+	modified := true
+	payload, err := xml.Marshal(&vmBootSettingsUpdate{
+		Xmlns: types.XMLNamespaceVCloud,
+		Ovf:   types.XMLNamespaceOVF,
+		Name:  "vm1",
+		VmSpecSection: &vmSpecSectionWithFirmware{
+			VmSpecSection: &types.VmSpecSection{Modified: &modified, Info: "Virtual Machine specification", OsType: "ubuntu64Guest"},
+			Firmware:      "efi",
+		},
+		BootOptions: &vmBootOptions{EfiSecureBootEnabled: takeBoolPointer(true)},
+	})
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+	for _, expected := range []string{
+		`<VmSpecSection Modified="true"><ovf:Info>Virtual Machine specification</ovf:Info><OsType>ubuntu64Guest</OsType>`,
+		`<Firmware>efi</Firmware></VmSpecSection>`,
+		`<BootOptions><EfiSecureBootEnabled>true</EfiSecureBootEnabled></BootOptions>`,
+	} {
+		if !strings.Contains(string(payload), expected) {
+			t.Errorf("payload %s doesn't contain %s", payload, expected)
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// A VM is copied by recomposing the target vApp with the source VM as sourced item. A vApp is copied with the
+// cloneVApp action of the target VDC. In both cases the source can be in another VDC of the Org, and can be running.
+
+const mimeCloneVAppParams = "application/vnd.vmware.vcloud.cloneVAppParams+xml"
+
+// cloneVAppParams is the payload for the VDC action that copies a vApp
+type cloneVAppParams struct {
+	XMLName        xml.Name         `xml:"CloneVAppParams"`
+	Xmlns          string           `xml:"xmlns,attr"`
+	Name           string           `xml:"name,attr"`
+	Description    string           `xml:"Description,omitempty"`
+	Source         *types.Reference `xml:"Source"`
+	IsSourceDelete bool             `xml:"IsSourceDelete"`
+}
+
+// getCloneSourceHref resolves the ID of the VM or vApp to copy, checking that it is of the expected type
+func getCloneSourceHref(vcdClient *VCDClient, sourceId, expectedType string) (string, error) {
+	urnType, err := getUrnEntityType(sourceId)
+	if err != nil {
+		return "", err
+	}
+	if urnType != expectedType {
+		return "", fmt.Errorf("'%s' is not the ID of a %s", sourceId, expectedType)
+	}
+	return getEntityHrefByUrn(vcdClient, sourceId)
+}
+
+// cloneVmIntoVApp copies the VM identified by "source_vm_id" into a vApp, with the name, network, storage profile and
+// sizing policy of the new VM. The other settings of the resource are applied after the copy, as for a template
+func cloneVmIntoVApp(d *schema.ResourceData, vcdClient *VCDClient, vapp *govcd.VApp, network *types.NetworkConnectionSection,
+	storageProfile *types.Reference, sizingPolicy *types.VdcComputePolicy) (*govcd.VM, error) {
+
+	sourceVmId := d.Get("source_vm_id").(string)
+	sourceHref, err := getCloneSourceHref(vcdClient, sourceVmId, "vm")
+	if err != nil {
+		return nil, fmt.Errorf("[VM creation] error retrieving source VM: %s", err)
+	}
+
+	vmName := d.Get("name").(string)
+	params := &types.ReComposeVAppParams{
+		Ovf:         types.XMLNamespaceOVF,
+		Xsi:         types.XMLNamespaceXSI,
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        vapp.VApp.Name,
+		Description: vapp.VApp.Description,
+		SourcedItem: &types.SourcedCompositionItemParam{
+			Source: &types.Reference{HREF: sourceHref},
+			VMGeneralParams: &types.VMGeneralParams{
+				Name:               vmName,
+				Description:        d.Get("description").(string),
+				RegenerateBiosUuid: true,
+			},
+			InstantiationParams: &types.InstantiationParams{NetworkConnectionSection: network},
+			StorageProfile:      storageProfile,
+		},
+		AllEULAsAccepted: d.Get("accept_all_eulas").(bool),
+	}
+
+	if sizingPolicy != nil {
+		vmSizingPolicy, err := getVdcComputePolicyReference(vcdClient, sizingPolicy.ID)
+		if err != nil {
+			return nil, err
+		}
+		params.SourcedItem.ComputePolicy = &types.ComputePolicy{VmSizingPolicy: vmSizingPolicy}
+	}
+
+	log.Printf("[TRACE] Copying VM %s into vApp %s as %s", sourceVmId, vapp.VApp.Name, vmName)
+	task, err := vcdClient.Client.ExecuteTaskRequestWithApiVersion(vapp.VApp.HREF+"/action/recomposeVApp", http.MethodPost,
+		types.MimeRecomposeVappParams, "error copying VM: %s", params,
+		vcdClient.Client.GetSpecificApiVersionOnCondition(">= 33.0", "33.0"))
+	if err != nil {
+		return nil, fmt.Errorf("[VM creation] error copying VM %s: %s", sourceVmId, err)
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return nil, fmt.Errorf(errorCompletingTask, err)
+	}
+
+	vm, err := vapp.GetVMByName(vmName, true)
+	if err != nil {
+		return nil, fmt.Errorf("[VM creation] error getting VM %s : %s", vmName, err)
+	}
+	return vm, nil
+}
+
+// getVdcComputePolicyReference returns the reference to a VDC compute policy used in a recompose request
+func getVdcComputePolicyReference(vcdClient *VCDClient, policyId string) (*types.Reference, error) {
+	vdcComputePolicyHref, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0, types.OpenApiEndpointVdcComputePolicies, policyId)
+	if err != nil {
+		return nil, fmt.Errorf("error constructing HREF for compute policy")
+	}
+	return &types.Reference{HREF: vdcComputePolicyHref.String()}, nil
+}
+
+// cloneVApp copies the vApp identified by "source_vapp_id", with all its VMs and networks, into the VDC
+func cloneVApp(d *schema.ResourceData, vcdClient *VCDClient, vdc *govcd.Vdc) (*govcd.VApp, error) {
+	sourceVAppId := d.Get("source_vapp_id").(string)
+	sourceHref, err := getCloneSourceHref(vcdClient, sourceVAppId, "vapp")
+	if err != nil {
+		return nil, fmt.Errorf("[vApp creation] error retrieving source vApp: %s", err)
+	}
+
+	vappName := d.Get("name").(string)
+	params := &cloneVAppParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        vappName,
+		Description: d.Get("description").(string),
+		Source:      &types.Reference{HREF: sourceHref},
+	}
+
+	log.Printf("[TRACE] Copying vApp %s into VDC %s as %s", sourceVAppId, vdc.Vdc.Name, vappName)
+	newVApp := govcd.NewVApp(&vcdClient.Client)
+	_, err = vcdClient.Client.ExecuteRequest(vdc.Vdc.HREF+"/action/cloneVApp", http.MethodPost, mimeCloneVAppParams,
+		"error copying vApp: %s", params, newVApp.VApp)
+	if err != nil {
+		return nil, err
+	}
+
+	if newVApp.VApp.Tasks != nil {
+		for _, taskInProgress := range newVApp.VApp.Tasks.Task {
+			task := govcd.NewTask(&vcdClient.Client)
+			task.Task = taskInProgress
+			err = task.WaitTaskCompletion()
+			if err != nil {
+				return nil, fmt.Errorf(errorCompletingTask, err)
+			}
+		}
+	}
+
+	return vdc.GetVAppByHref(newVApp.VApp.HREF)
+}
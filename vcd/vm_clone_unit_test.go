@@ -0,0 +1,50 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestGetCloneSourceHrefType(t *testing.T) {
+	// The type is checked before contacting VCD, so no client is needed
+	for _, sourceId := range []string{
+		"",
+		"da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3",
+		"urn:vcloud:vapp:da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3",
+		"urn:vcloud:vm:not-a-uuid",
+	} {
+		_, err := getCloneSourceHref(nil, sourceId, "vm")
+		if err == nil {
+			t.Errorf("expected error for source '%s'", sourceId)
+		}
+	}
+}
+
+func TestCloneVAppParamsXml(t *testing.T) {
+	params := &cloneVAppParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        "copy",
+		Description: "copied vApp",
+		Source:      &types.Reference{HREF: "https://vcd.example.com/api/vApp/vapp-da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3"},
+	}
+	out, err := xml.Marshal(params)
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+	payload := string(out)
+	for _, expected := range []string{
+		`<CloneVAppParams xmlns="` + types.XMLNamespaceVCloud + `" name="copy">`,
+		`<Description>copied vApp</Description>`,
+		`<Source href="https://vcd.example.com/api/vApp/vapp-da2e8c37-8ad1-4fc2-ae4c-20e2f4d2f0a3"`,
+		`<IsSourceDelete>false</IsSourceDelete>`,
+	} {
+		if !strings.Contains(payload, expected) {
+			t.Errorf("expected '%s' in payload %s", expected, payload)
+		}
+	}
+}
@@ -0,0 +1,240 @@
+package vcd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// The guest customization of VCD has no settings for the domain and the time zone of Linux guests. They are applied
+// by a block of shell commands that the provider adds at the beginning of the customization script, which VCD runs
+// with the "postcustomization" argument after it has set the computer name. The block is delimited by markers, so that
+// it is removed from the script read from VCD, and "initscript" only holds the script of the user.
+
+// VCD customizes Windows guests with a sysprep answer file that it generates from the customization settings, and its
+// API can't receive another one. "sysprep_answer_file" is stored base64 encoded in a guest property instead, which the
+// guest reads from the OVF environment to run sysprep with it.
+
+const (
+	vmSysprepAnswerFileKey = "guestinfo.sysprep.answerfile"
+
+	vmCustomizationScriptBegin = "# BEGIN terraform-provider-vcd Linux customization"
+	vmCustomizationScriptEnd   = "# END terraform-provider-vcd Linux customization"
+	// vmCustomizationDefaultShebang is the interpreter of the script when the script of the user doesn't set one
+	vmCustomizationDefaultShebang = "#!/bin/sh"
+)
+
+var (
+	vmLinuxHostnameRegexp = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+	vmLinuxDomainRegexp   = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)*[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?$`)
+	vmLinuxTimezoneRegexp = regexp.MustCompile(`^[A-Za-z0-9_+-]+(/[A-Za-z0-9_+-]+)*$`)
+)
+
+// vmLinuxCustomization holds the Linux settings applied by the customization script
+type vmLinuxCustomization struct {
+	hostname string
+	domain   string
+	timezone string
+}
+
+func (c vmLinuxCustomization) isEmpty() bool {
+	return c.hostname == "" && c.domain == "" && c.timezone == ""
+}
+
+// buildCustomizationScript returns the customization script sent to VCD: the script of the user, preceded by the
+// commands that apply the Linux settings. computerName is the hostname when the settings don't set one
+func buildCustomizationScript(userScript string, linux vmLinuxCustomization, computerName string) string {
+	if linux.isEmpty() {
+		return userScript
+	}
+
+	// The interpreter of the user script must stay on the first line
+	shebang := vmCustomizationDefaultShebang
+	shebangAdded := true
+	if strings.HasPrefix(userScript, "#!") {
+		lines := strings.SplitN(userScript, "\n", 2)
+		shebang = strings.TrimRight(lines[0], "\r")
+		shebangAdded = false
+		userScript = ""
+		if len(lines) > 1 {
+			userScript = lines[1]
+		}
+	}
+
+	hostname := linux.hostname
+	if hostname == "" {
+		hostname = computerName
+	}
+	fqdn := hostname
+	if linux.domain != "" {
+		fqdn = hostname + "." + linux.domain
+	}
+
+	var block strings.Builder
+	block.WriteString(vmCustomizationScriptBegin + "\n")
+	fmt.Fprintf(&block, "# hostname=%s domain=%s timezone=%s shebang_added=%t\n",
+		linux.hostname, linux.domain, linux.timezone, shebangAdded)
+	block.WriteString("if [ \"$1\" = \"postcustomization\" ]; then\n")
+	if linux.hostname != "" || linux.domain != "" {
+		fmt.Fprintf(&block, "  hostnamectl set-hostname '%s' 2>/dev/null || { hostname '%s'; echo '%s' > /etc/hostname; }\n",
+			fqdn, fqdn, fqdn)
+	}
+	if linux.domain != "" {
+		fmt.Fprintf(&block, "  grep -q '%s' /etc/hosts || echo \"127.0.1.1 %s %s\" >> /etc/hosts\n", fqdn, fqdn, hostname)
+	}
+	if linux.timezone != "" {
+		fmt.Fprintf(&block, "  timedatectl set-timezone '%s' 2>/dev/null || ln -sf '/usr/share/zoneinfo/%s' /etc/localtime\n",
+			linux.timezone, linux.timezone)
+	}
+	block.WriteString("fi\n")
+	block.WriteString(vmCustomizationScriptEnd + "\n")
+
+	return shebang + "\n" + block.String() + userScript
+}
+
+// parseCustomizationScript splits a customization script read from VCD into the script of the user and the Linux
+// settings applied by the provider
+func parseCustomizationScript(script string) (string, vmLinuxCustomization) {
+	linux := vmLinuxCustomization{}
+	beginIndex := strings.Index(script, vmCustomizationScriptBegin)
+	if beginIndex < 0 {
+		return script, linux
+	}
+	endIndex := strings.Index(script[beginIndex:], vmCustomizationScriptEnd)
+	if endIndex < 0 {
+		return script, linux
+	}
+	endIndex += beginIndex + len(vmCustomizationScriptEnd)
+	// The end marker is followed by a newline, which is not part of the user script
+	if strings.HasPrefix(script[endIndex:], "\r\n") {
+		endIndex += 2
+	} else if strings.HasPrefix(script[endIndex:], "\n") {
+		endIndex++
+	}
+
+	shebangAdded := true
+	block := script[beginIndex:endIndex]
+	for _, line := range strings.Split(block, "\n") {
+		line = strings.TrimRight(line, "\r")
+		if !strings.HasPrefix(line, "# hostname=") {
+			continue
+		}
+		for _, field := range strings.Fields(strings.TrimPrefix(line, "# ")) {
+			keyValue := strings.SplitN(field, "=", 2)
+			if len(keyValue) != 2 {
+				continue
+			}
+			switch keyValue[0] {
+			case "hostname":
+				linux.hostname = keyValue[1]
+			case "domain":
+				linux.domain = keyValue[1]
+			case "timezone":
+				linux.timezone = keyValue[1]
+			case "shebang_added":
+				shebangAdded = keyValue[1] == "true"
+			}
+		}
+	}
+
+	userScript := script[endIndex:]
+	if !shebangAdded {
+		userScript = script[:beginIndex] + userScript
+	}
+	return userScript, linux
+}
+
+// getVmLinuxCustomization returns the Linux settings of the 'customization' block
+func getVmLinuxCustomization(customization map[string]interface{}) vmLinuxCustomization {
+	linux := vmLinuxCustomization{}
+	if value, ok := customization["linux_hostname"].(string); ok {
+		linux.hostname = value
+	}
+	if value, ok := customization["linux_domain"].(string); ok {
+		linux.domain = value
+	}
+	if value, ok := customization["linux_timezone"].(string); ok {
+		linux.timezone = value
+	}
+	return linux
+}
+
+// readCustomizationScriptFile returns the content of the file set in "initscript_file"
+func readCustomizationScriptFile(fileName string) (string, error) {
+	content, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("error reading customization script file %s: %s", fileName, err)
+	}
+	return string(content), nil
+}
+
+// customizeVmCustomizationDiff puts the content of "initscript_file" in "initscript", so that the plan shows the
+// changes of the file. The file is read again during apply, as its name may not be known at plan time
+func customizeVmCustomizationDiff(diff *schema.ResourceDiff) error {
+	if !diff.NewValueKnown("customization") {
+		return nil
+	}
+	customizationList := diff.Get("customization").([]interface{})
+	if len(customizationList) != 1 || customizationList[0] == nil {
+		return nil
+	}
+	customization := customizationList[0].(map[string]interface{})
+	fileName := customization["initscript_file"].(string)
+	if fileName == "" {
+		return nil
+	}
+	script, err := readCustomizationScriptFile(fileName)
+	if err != nil {
+		return err
+	}
+	if customization["initscript"].(string) == script {
+		return nil
+	}
+	customization["initscript"] = script
+	return diff.SetNew("customization", []interface{}{customization})
+}
+
+// isSysprepAnswerFileSet returns true when the resource has a "customization.0.sysprep_answer_file"
+func isSysprepAnswerFileSet(d *schema.ResourceData) bool {
+	answerFile, ok := d.Get("customization.0.sysprep_answer_file").(string)
+	return ok && answerFile != ""
+}
+
+// getSysprepAnswerFileProperties converts "customization.0.sysprep_answer_file" into guest properties.
+// It returns an empty map when the field is not set, or when the resource doesn't have it
+func getSysprepAnswerFileProperties(d *schema.ResourceData) (map[string]string, error) {
+	properties := make(map[string]string)
+	if !isSysprepAnswerFileSet(d) {
+		return properties, nil
+	}
+	encoded, err := encodeCloudInitData(d.Get("customization.0.sysprep_answer_file").(string), cloudInitEncodingBase64)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding sysprep answer file: %s", err)
+	}
+	properties[vmSysprepAnswerFileKey] = encoded
+	return properties, nil
+}
+
+// getSysprepAnswerFile returns the decoded sysprep answer file found in the guest properties. A value that is not
+// base64 encoded is returned as is
+func getSysprepAnswerFile(properties *types.ProductSectionList) string {
+	if properties == nil || properties.ProductSection == nil {
+		return ""
+	}
+	for _, property := range properties.ProductSection.Property {
+		if property.Key != vmSysprepAnswerFileKey || property.Value == nil {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(property.Value.Value)
+		if err != nil {
+			return property.Value.Value
+		}
+		return string(decoded)
+	}
+	return ""
+}
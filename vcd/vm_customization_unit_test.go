@@ -0,0 +1,165 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestBuildAndParseCustomizationScript(t *testing.T) {
+	tests := map[string]struct {
+		userScript string
+		linux      vmLinuxCustomization
+		contains   []string
+	}{
+		"no-linux-settings": {
+			userScript: "#!/bin/bash\necho hello\n",
+		},
+		"empty-script": {
+			linux:    vmLinuxCustomization{timezone: "Europe/Rome"},
+			contains: []string{vmCustomizationDefaultShebang + "\n", "timedatectl set-timezone 'Europe/Rome'"},
+		},
+		"script-without-shebang": {
+			userScript: "echo hello\n",
+			linux:      vmLinuxCustomization{hostname: "web01", domain: "example.com"},
+			contains:   []string{vmCustomizationDefaultShebang + "\n", "hostnamectl set-hostname 'web01.example.com'"},
+		},
+		"script-with-shebang": {
+			userScript: "#!/bin/bash\necho hello\n",
+			linux:      vmLinuxCustomization{domain: "example.com"},
+			contains:   []string{"#!/bin/bash\n" + vmCustomizationScriptBegin, "hostnamectl set-hostname 'computer.example.com'"},
+		},
+		"windows-line-endings": {
+			userScript: "#!/bin/bash\r\necho hello\r\n",
+			linux:      vmLinuxCustomization{hostname: "web01"},
+			contains:   []string{"hostnamectl set-hostname 'web01'"},
+		},
+	}
+	for name, test := range tests {
+		script := buildCustomizationScript(test.userScript, test.linux, "computer")
+		for _, expected := range test.contains {
+			if !strings.Contains(script, expected) {
+				t.Errorf("%s: expected script to contain %q, got:\n%s", name, expected, script)
+			}
+		}
+		if test.linux.isEmpty() && script != test.userScript {
+			t.Errorf("%s: expected script to be unchanged, got:\n%s", name, script)
+		}
+
+		userScript, linux := parseCustomizationScript(script)
+		if userScript != strings.Replace(test.userScript, "#!/bin/bash\r\n", "#!/bin/bash\n", 1) {
+			t.Errorf("%s: expected user script %q, got %q", name, test.userScript, userScript)
+		}
+		if linux != test.linux {
+			t.Errorf("%s: expected Linux settings %+v, got %+v", name, test.linux, linux)
+		}
+	}
+}
+
+func TestParseCustomizationScriptFromVcd(t *testing.T) {
+	// VCD may return the script with Windows line endings
+	script := strings.Join([]string{
+		vmCustomizationDefaultShebang,
+		vmCustomizationScriptBegin,
+		"# hostname=web01 domain=example.com timezone=UTC shebang_added=true",
+		"fi",
+		vmCustomizationScriptEnd,
+		"echo hello",
+	}, "\r\n")
+	userScript, linux := parseCustomizationScript(script)
+	if userScript != "echo hello" {
+		t.Errorf("expected user script %q, got %q", "echo hello", userScript)
+	}
+	expected := vmLinuxCustomization{hostname: "web01", domain: "example.com", timezone: "UTC"}
+	if linux != expected {
+		t.Errorf("expected Linux settings %+v, got %+v", expected, linux)
+	}
+
+	// A script without end marker is returned as is
+	script = vmCustomizationScriptBegin + "\necho hello\n"
+	userScript, linux = parseCustomizationScript(script)
+	if userScript != script || !linux.isEmpty() {
+		t.Errorf("expected unchanged script and no Linux settings, got %q and %+v", userScript, linux)
+	}
+}
+
+func TestVmLinuxCustomizationValidation(t *testing.T) {
+	tests := []struct {
+		value    string
+		regexp   string
+		expected bool
+	}{
+		{"web01", "hostname", true},
+		{"web_01", "hostname", false},
+		{"-web", "hostname", false},
+		{"web01.example.com", "hostname", false},
+		{"example.com", "domain", true},
+		{"example..com", "domain", false},
+		{"example.com; reboot", "domain", false},
+		{"Europe/Rome", "timezone", true},
+		{"America/Argentina/Buenos_Aires", "timezone", true},
+		{"UTC", "timezone", true},
+		{"Europe/Rome'", "timezone", false},
+		{"../etc", "timezone", false},
+	}
+	for _, test := range tests {
+		var matched bool
+		switch test.regexp {
+		case "hostname":
+			matched = vmLinuxHostnameRegexp.MatchString(test.value)
+		case "domain":
+			matched = vmLinuxDomainRegexp.MatchString(test.value)
+		case "timezone":
+			matched = vmLinuxTimezoneRegexp.MatchString(test.value)
+		}
+		if matched != test.expected {
+			t.Errorf("%s %q: expected valid=%t, got %t", test.regexp, test.value, test.expected, matched)
+		}
+	}
+}
+
+func TestSysprepAnswerFileProperties(t *testing.T) {
+	answerFile := "<?xml version=\"1.0\" encoding=\"utf-8\"?>\n<unattend xmlns=\"urn:schemas-microsoft-com:unattend\"/>\n"
+	d := schema.TestResourceDataRaw(t, vappVmSchema, map[string]interface{}{
+		"customization": []interface{}{map[string]interface{}{"sysprep_answer_file": answerFile}},
+	})
+	properties, err := getSysprepAnswerFileProperties(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(properties) != 1 || properties[vmSysprepAnswerFileKey] == "" {
+		t.Fatalf("expected property %s, got %v", vmSysprepAnswerFileKey, properties)
+	}
+
+	productSection := &types.ProductSectionList{
+		ProductSection: &types.ProductSection{
+			Property: []*types.Property{
+				{Key: "other", Value: &types.Value{Value: "value"}},
+				{Key: vmSysprepAnswerFileKey, Value: &types.Value{Value: properties[vmSysprepAnswerFileKey]}},
+			},
+		},
+	}
+	if decoded := getSysprepAnswerFile(productSection); decoded != answerFile {
+		t.Errorf("expected answer file %q, got %q", answerFile, decoded)
+	}
+
+	// A value changed outside of Terraform is returned as is, so that the plan shows the difference
+	productSection.ProductSection.Property[1].Value.Value = "not base64"
+	if decoded := getSysprepAnswerFile(productSection); decoded != "not base64" {
+		t.Errorf("expected raw value, got %q", decoded)
+	}
+	if decoded := getSysprepAnswerFile(nil); decoded != "" {
+		t.Errorf("expected no answer file, got %q", decoded)
+	}
+
+	// Resources without the field, such as vcd_vapp, have no property
+	d = schema.TestResourceDataRaw(t, resourceVcdVApp().Schema, map[string]interface{}{"name": "vapp"})
+	properties, err = getSysprepAnswerFileProperties(d)
+	if err != nil || len(properties) != 0 {
+		t.Errorf("expected no property, got %v (error: %v)", properties, err)
+	}
+}
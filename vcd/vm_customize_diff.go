@@ -0,0 +1,73 @@
+package vcd
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The plan time checks of vcd_vapp_vm and vcd_vm are shared by the features of the VM. Each check reads the plan
+// through vmDiffGetter, so that it can be unit tested without a schema.ResourceDiff.
+
+// vmDiffGetter is the part of schema.ResourceDiff used by the plan time checks
+type vmDiffGetter interface {
+	Get(key string) interface{}
+	NewValueKnown(key string) bool
+}
+
+// resourceVcdVmCustomizeDiff returns the plan time checks of vcd_vapp_vm or vcd_vm, according to vmType
+func resourceVcdVmCustomizeDiff(vmType typeOfVm) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+		return customizeVmDiff(ctx, diff, meta, vmType)
+	}
+}
+
+// customizeVmDiff runs the plan time checks of a VM of the given type
+func customizeVmDiff(ctx context.Context, diff *schema.ResourceDiff, meta interface{}, vmType typeOfVm) error {
+	err := validateVmBootSettings(diff)
+	if err != nil {
+		return err
+	}
+	err = validateVmResourceAllocation(diff)
+	if err != nil {
+		return err
+	}
+	err = validateVmResourceAllocationWithSizingPolicy(diff, meta)
+	if err != nil {
+		return err
+	}
+	err = validateVmPlacementPolicy(diff)
+	if err != nil {
+		return err
+	}
+	err = validateVmDiskControllers(diff)
+	if err != nil {
+		return err
+	}
+	err = validateVmGuestOs(diff, meta)
+	if err != nil {
+		return err
+	}
+	err = customizeOverrideTemplateDiskDiff(diff)
+	if err != nil {
+		return err
+	}
+	err = customizeVmCustomizationDiff(diff)
+	if err != nil {
+		return err
+	}
+	return customizeVmPowerCycleDiff(ctx, diff, meta, vmType)
+}
+
+// isVmFieldSet returns true when the field needs to be sent to VCD: when it changes, or when it is set in the
+// configuration of a new VM, even with a zero value, as the template may have a different one
+func isVmFieldSet(d *schema.ResourceData, field string) bool {
+	if d.HasChange(field) {
+		return true
+	}
+	if d.IsNewResource() {
+		_, ok := d.GetOkExists(field)
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,319 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// VCD has no entity for disk controllers: a controller is created when a disk is placed on a bus that doesn't have
+// one, with the type of the disk adapter. The type of an existing controller changes with the adapter type of its
+// disks, which requires the VM to be powered off. The bus sharing of SCSI controllers is a VMX setting, which is
+// changed through the extra configuration of the VM.
+
+const (
+	vmDiskControllerSharingNone     = "none"
+	vmDiskControllerSharingVirtual  = "virtual"
+	vmDiskControllerSharingPhysical = "physical"
+)
+
+// vmDiskControllerLimits are the highest bus and unit numbers of a kind of controller. reservedUnit is a unit number
+// that can't hold a disk, or -1
+type vmDiskControllerLimits struct {
+	maxBus       int
+	maxUnit      int
+	reservedUnit int
+}
+
+var vmDiskControllerKindLimits = map[string]vmDiskControllerLimits{
+	// Unit 7 of a SCSI bus is the controller itself
+	"scsi": {maxBus: 3, maxUnit: 15, reservedUnit: 7},
+	"ide":  {maxBus: 1, maxUnit: 1, reservedUnit: -1},
+	"sata": {maxBus: 3, maxUnit: 29, reservedUnit: -1},
+}
+
+// getDiskControllerKind returns the kind of controller of a bus type. The SCSI bus types share the same buses
+func getDiskControllerKind(busType string) string {
+	switch busType {
+	case "ide", "sata":
+		return busType
+	default:
+		return "scsi"
+	}
+}
+
+// diskControllerKey identifies a controller by its kind and bus number
+func diskControllerKey(busType string, busNumber int) string {
+	return fmt.Sprintf("%s:%d", getDiskControllerKind(busType), busNumber)
+}
+
+// vmDiskControllerSharingKey returns the VMX key with the bus sharing of a SCSI controller
+func vmDiskControllerSharingKey(busNumber int) string {
+	return fmt.Sprintf("scsi%d.sharedBus", busNumber)
+}
+
+// validateDiskPlacement checks that a bus and unit number are valid for the given bus type
+func validateDiskPlacement(busType string, busNumber, unitNumber int) error {
+	limits := vmDiskControllerKindLimits[getDiskControllerKind(busType)]
+	if busNumber < 0 || busNumber > limits.maxBus {
+		return fmt.Errorf("bus number %d is not valid for bus type %s. Expected a value between 0 and %d",
+			busNumber, busType, limits.maxBus)
+	}
+	if unitNumber < 0 || unitNumber > limits.maxUnit {
+		return fmt.Errorf("unit number %d is not valid for bus type %s. Expected a value between 0 and %d",
+			unitNumber, busType, limits.maxUnit)
+	}
+	if unitNumber == limits.reservedUnit {
+		return fmt.Errorf("unit number %d is reserved for the controller on bus type %s", unitNumber, busType)
+	}
+	return nil
+}
+
+// getDiskControllersByKey returns the 'disk_controller' blocks by controller key, and checks that they are valid
+func getDiskControllersByKey(controllers []interface{}) (map[string]map[string]interface{}, error) {
+	controllersByKey := make(map[string]map[string]interface{})
+	for _, rawController := range controllers {
+		controller := rawController.(map[string]interface{})
+		busType := controller["bus_type"].(string)
+		busNumber := controller["bus_number"].(int)
+		key := diskControllerKey(busType, busNumber)
+		if _, ok := controllersByKey[key]; ok {
+			return nil, fmt.Errorf("more than one %s disk controller with bus number %d", getDiskControllerKind(busType), busNumber)
+		}
+		maxBus := vmDiskControllerKindLimits[getDiskControllerKind(busType)].maxBus
+		if busNumber < 0 || busNumber > maxBus {
+			return nil, fmt.Errorf("bus number %d is not valid for disk controller %s. Expected a value between 0 and %d",
+				busNumber, busType, maxBus)
+		}
+		sharing := controller["sharing"].(string)
+		if sharing != vmDiskControllerSharingNone && getDiskControllerKind(busType) != "scsi" {
+			return nil, fmt.Errorf("disk controller %s with bus number %d can't have sharing '%s'. Only SCSI controllers can be shared",
+				busType, busNumber, sharing)
+		}
+		controllersByKey[key] = controller
+	}
+	return controllersByKey, nil
+}
+
+// validateDiskOnControllers checks that a disk is placed on a controller of the same type, and on a valid unit
+func validateDiskOnControllers(busType string, busNumber, unitNumber int, controllersByKey map[string]map[string]interface{}) error {
+	err := validateDiskPlacement(busType, busNumber, unitNumber)
+	if err != nil {
+		return err
+	}
+	if len(controllersByKey) == 0 {
+		return nil
+	}
+	controller, ok := controllersByKey[diskControllerKey(busType, busNumber)]
+	if !ok {
+		return fmt.Errorf("disk with bus type %s and bus number %d has no matching 'disk_controller'", busType, busNumber)
+	}
+	if controller["bus_type"].(string) != busType {
+		return fmt.Errorf("disk with bus type %s and bus number %d is on a %s disk controller",
+			busType, busNumber, controller["bus_type"].(string))
+	}
+	return nil
+}
+
+// validateVmDiskControllers checks the disk controllers, and the placement of "override_template_disk" against them.
+// Values that are not known at plan time are not checked
+func validateVmDiskControllers(diff vmDiffGetter) error {
+	if !diff.NewValueKnown("disk_controller") || !diff.NewValueKnown("override_template_disk") {
+		return nil
+	}
+	controllersByKey, err := getDiskControllersByKey(diff.Get("disk_controller").(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	for _, rawDisk := range diff.Get("override_template_disk").(*schema.Set).List() {
+		disk := rawDisk.(map[string]interface{})
+		err = validateDiskOnControllers(disk["bus_type"].(string), disk["bus_number"].(int), disk["unit_number"].(int), controllersByKey)
+		if err != nil {
+			return fmt.Errorf("override_template_disk: %s", err)
+		}
+	}
+	return nil
+}
+
+// getVmDiskControllers returns the controllers of the disks of a VM, by controller key. The controllers that only hold
+// media, such as the IDE controller of the CD-ROM, are left out. The sharing of SCSI controllers comes from the extra
+// configuration, when given
+func getVmDiskControllers(vmSpecSection *types.VmSpecSection, extraConfig map[string]string) map[string]map[string]interface{} {
+	controllers := make(map[string]map[string]interface{})
+	addController := func(adapterType string, busNumber int) {
+		busType, ok := internalDiskBusTypesFromValues[strings.ToLower(adapterType)]
+		if !ok {
+			return
+		}
+		key := diskControllerKey(busType, busNumber)
+		if _, ok := controllers[key]; ok {
+			return
+		}
+		sharing := vmDiskControllerSharingNone
+		if getDiskControllerKind(busType) == "scsi" && extraConfig[vmDiskControllerSharingKey(busNumber)] != "" {
+			sharing = extraConfig[vmDiskControllerSharingKey(busNumber)]
+		}
+		controllers[key] = map[string]interface{}{
+			"bus_type":   busType,
+			"bus_number": busNumber,
+			"sharing":    sharing,
+		}
+	}
+	if vmSpecSection == nil {
+		return controllers
+	}
+	if vmSpecSection.DiskSection != nil {
+		for _, disk := range vmSpecSection.DiskSection.DiskSettings {
+			addController(disk.AdapterType, disk.BusNumber)
+		}
+	}
+	return controllers
+}
+
+// hasVmDiskControllerSharing returns true if a SCSI controller in "disk_controller" is shared. The extra
+// configuration is only read in that case
+func hasVmDiskControllerSharing(d *schema.ResourceData) bool {
+	for _, rawController := range d.Get("disk_controller").(*schema.Set).List() {
+		if rawController.(map[string]interface{})["sharing"].(string) != vmDiskControllerSharingNone {
+			return true
+		}
+	}
+	return false
+}
+
+// setVmDiskControllers stores the disk controllers of a VM into "disk_controller". When controllers are declared,
+// only those are stored, so that the other controllers of the VM don't make a diff. A declared controller that has no
+// disk yet doesn't exist in VCD, and is kept as it is
+func setVmDiskControllers(d *schema.ResourceData, vmSpecSection *types.VmSpecSection, extraConfig map[string]string) error {
+	controllers := getVmDiskControllers(vmSpecSection, extraConfig)
+	declaredControllers := d.Get("disk_controller").(*schema.Set).List()
+	if len(declaredControllers) > 0 {
+		vmControllers := controllers
+		controllers = make(map[string]map[string]interface{})
+		for _, rawController := range declaredControllers {
+			controller := rawController.(map[string]interface{})
+			key := diskControllerKey(controller["bus_type"].(string), controller["bus_number"].(int))
+			if vmController, ok := vmControllers[key]; ok {
+				controllers[key] = vmController
+			} else {
+				controllers[key] = controller
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(controllers))
+	for key := range controllers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	controllerList := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		controllerList = append(controllerList, controllers[key])
+	}
+	return d.Set("disk_controller", controllerList)
+}
+
+// updateVmDiskControllers changes the type of the existing controllers to the one in "disk_controller", by changing
+// the adapter type of their disks, and applies the bus sharing of the SCSI controllers. The VM must be powered off
+func updateVmDiskControllers(d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) error {
+	oldValue, newValue := d.GetChange("disk_controller")
+	oldControllers, err := getDiskControllersByKey(oldValue.(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+	newControllers, err := getDiskControllersByKey(newValue.(*schema.Set).List())
+	if err != nil {
+		return err
+	}
+
+	err = vm.Refresh()
+	if err != nil {
+		return fmt.Errorf("error refreshing VM %s: %s", vm.VM.Name, err)
+	}
+	typeChanged := false
+	sharingChanges := make(map[string]string)
+	for key, controller := range newControllers {
+		busType := controller["bus_type"].(string)
+		busNumber := controller["bus_number"].(int)
+		if vm.VM.VmSpecSection != nil && vm.VM.VmSpecSection.DiskSection != nil {
+			for _, disk := range vm.VM.VmSpecSection.DiskSection.DiskSettings {
+				diskBusType := internalDiskBusTypesFromValues[strings.ToLower(disk.AdapterType)]
+				if diskBusType == "" || diskControllerKey(diskBusType, disk.BusNumber) != key || diskBusType == busType {
+					continue
+				}
+				log.Printf("[TRACE] changing disk controller %d of VM %s from %s to %s", busNumber, vm.VM.Name, diskBusType, busType)
+				disk.AdapterType = internalDiskBusTypes[busType]
+				typeChanged = true
+			}
+		}
+
+		if getDiskControllerKind(busType) != "scsi" {
+			continue
+		}
+		oldSharing := vmDiskControllerSharingNone
+		if oldController, ok := oldControllers[key]; ok {
+			oldSharing = oldController["sharing"].(string)
+		}
+		if sharing := controller["sharing"].(string); sharing != oldSharing {
+			sharingChanges[vmDiskControllerSharingKey(busNumber)] = sharing
+		}
+	}
+
+	if typeChanged {
+		err = updateVmDisks(vm, vm.VM.VmSpecSection)
+		if err != nil {
+			return fmt.Errorf("error changing disk controllers of VM %s: %s", vm.VM.Name, err)
+		}
+	}
+	return updateVmExtraConfig(vcdClient, vm, sharingChanges)
+}
+
+// customizeVmInternalDiskDiff checks the placement of an internal disk at plan time, against the controllers that
+// the VM already has in VCD. A VM that doesn't exist yet is not checked
+func customizeVmInternalDiskDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("bus_type") || !diff.NewValueKnown("bus_number") || !diff.NewValueKnown("unit_number") {
+		return nil
+	}
+	busType := diff.Get("bus_type").(string)
+	busNumber := diff.Get("bus_number").(int)
+	unitNumber := diff.Get("unit_number").(int)
+	err := validateDiskPlacement(busType, busNumber, unitNumber)
+	if err != nil {
+		return err
+	}
+
+	if diff.Id() != "" && !diff.HasChange("bus_type") && !diff.HasChange("bus_number") && !diff.HasChange("unit_number") {
+		return nil
+	}
+	if !diff.NewValueKnown("vapp_name") || !diff.NewValueKnown("vm_name") || meta == nil {
+		return nil
+	}
+	// The VM may be created in the same run, in which case VCD checks the placement when the disk is added
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdc(diff.Get("org").(string), diff.Get("vdc").(string))
+	if err != nil {
+		return nil
+	}
+	vapp, err := vdc.GetVAppByName(diff.Get("vapp_name").(string), false)
+	if err != nil {
+		return nil
+	}
+	vm, err := vapp.GetVMByName(diff.Get("vm_name").(string), false)
+	if err != nil {
+		return nil
+	}
+
+	controllers := getVmDiskControllers(vm.VM.VmSpecSection, nil)
+	controller, ok := controllers[diskControllerKey(busType, busNumber)]
+	if ok && controller["bus_type"].(string) != busType {
+		return fmt.Errorf("VM %s has a %s disk controller with bus number %d, which can't hold a %s disk",
+			vm.VM.Name, controller["bus_type"].(string), busNumber, busType)
+	}
+	return nil
+}
@@ -0,0 +1,228 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func testDiskController(busType string, busNumber int, sharing string) map[string]interface{} {
+	return map[string]interface{}{
+		"bus_type":   busType,
+		"bus_number": busNumber,
+		"sharing":    sharing,
+	}
+}
+
+func TestValidateDiskPlacement(t *testing.T) {
+	tests := []struct {
+		busType    string
+		busNumber  int
+		unitNumber int
+		wantError  bool
+	}{
+		{"paravirtual", 0, 0, false},
+		{"paravirtual", 3, 15, false},
+		{"paravirtual", 0, 7, true},
+		{"parallel", 4, 0, true},
+		{"sas", 0, 16, true},
+		{"ide", 1, 1, false},
+		{"ide", 2, 0, true},
+		{"ide", 0, 2, true},
+		{"sata", 3, 29, false},
+		{"sata", 0, 7, false},
+		{"sata", 0, 30, true},
+	}
+	for _, test := range tests {
+		err := validateDiskPlacement(test.busType, test.busNumber, test.unitNumber)
+		if test.wantError && err == nil {
+			t.Errorf("%s %d:%d: expected error, got none", test.busType, test.busNumber, test.unitNumber)
+		}
+		if !test.wantError && err != nil {
+			t.Errorf("%s %d:%d: unexpected error: %s", test.busType, test.busNumber, test.unitNumber, err)
+		}
+	}
+}
+
+func TestGetDiskControllersByKey(t *testing.T) {
+	tests := map[string]struct {
+		controllers  []interface{}
+		expectedKeys []string
+		wantError    bool
+	}{
+		"valid": {
+			controllers: []interface{}{
+				testDiskController("paravirtual", 0, "none"),
+				testDiskController("sas", 1, "virtual"),
+				testDiskController("sata", 0, "none"),
+				testDiskController("ide", 0, "none"),
+			},
+			expectedKeys: []string{"scsi:0", "scsi:1", "sata:0", "ide:0"},
+		},
+		// Different SCSI types share the same buses
+		"duplicate-scsi-bus": {
+			controllers: []interface{}{
+				testDiskController("paravirtual", 1, "none"),
+				testDiskController("parallel", 1, "none"),
+			},
+			wantError: true,
+		},
+		"invalid-ide-bus": {
+			controllers: []interface{}{testDiskController("ide", 2, "none")},
+			wantError:   true,
+		},
+		"shared-sata": {
+			controllers: []interface{}{testDiskController("sata", 0, "physical")},
+			wantError:   true,
+		},
+	}
+	for name, test := range tests {
+		controllersByKey, err := getDiskControllersByKey(test.controllers)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("%s: expected error, got none", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+			continue
+		}
+		if len(controllersByKey) != len(test.expectedKeys) {
+			t.Errorf("%s: expected %d controllers, got %d", name, len(test.expectedKeys), len(controllersByKey))
+		}
+		for _, key := range test.expectedKeys {
+			if _, ok := controllersByKey[key]; !ok {
+				t.Errorf("%s: controller %s not found", name, key)
+			}
+		}
+	}
+}
+
+func TestValidateDiskOnControllers(t *testing.T) {
+	controllersByKey, err := getDiskControllersByKey([]interface{}{
+		testDiskController("paravirtual", 0, "none"),
+		testDiskController("sata", 1, "none"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tests := map[string]struct {
+		busType          string
+		busNumber        int
+		unitNumber       int
+		controllersByKey map[string]map[string]interface{}
+		wantError        bool
+	}{
+		"matching-controller":   {"paravirtual", 0, 1, controllersByKey, false},
+		"no-controllers":        {"parallel", 2, 1, nil, false},
+		"missing-controller":    {"paravirtual", 1, 0, controllersByKey, true},
+		"different-scsi-type":   {"sas", 0, 1, controllersByKey, true},
+		"reserved-unit":         {"paravirtual", 0, 7, controllersByKey, true},
+		"matching-sata":         {"sata", 1, 10, controllersByKey, false},
+		"sata-on-missing-bus":   {"sata", 0, 0, controllersByKey, true},
+		"invalid-without-block": {"ide", 3, 0, nil, true},
+	}
+	for name, test := range tests {
+		err := validateDiskOnControllers(test.busType, test.busNumber, test.unitNumber, test.controllersByKey)
+		if test.wantError && err == nil {
+			t.Errorf("%s: expected error, got none", name)
+		}
+		if !test.wantError && err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
+func TestGetVmDiskControllers(t *testing.T) {
+	vmSpecSection := &types.VmSpecSection{
+		DiskSection: &types.DiskSection{
+			DiskSettings: []*types.DiskSettings{
+				{AdapterType: "5", BusNumber: 0, UnitNumber: 0},
+				{AdapterType: "5", BusNumber: 0, UnitNumber: 1},
+				{AdapterType: "4", BusNumber: 1, UnitNumber: 0},
+				{AdapterType: "6", BusNumber: 0, UnitNumber: 3},
+			},
+		},
+		MediaSection: &types.MediaSection{
+			MediaSettings: []*types.MediaSettings{
+				{AdapterType: "1", BusNumber: 1, UnitNumber: 0},
+			},
+		},
+	}
+	extraConfig := map[string]string{
+		"scsi1.sharedBus": "physical",
+		"scsi3.sharedBus": "virtual",
+	}
+	expected := map[string]map[string]interface{}{
+		"scsi:0": testDiskController("paravirtual", 0, "none"),
+		"scsi:1": testDiskController("sas", 1, "physical"),
+		"sata:0": testDiskController("sata", 0, "none"),
+	}
+
+	controllers := getVmDiskControllers(vmSpecSection, extraConfig)
+	if !reflect.DeepEqual(controllers, expected) {
+		t.Errorf("expected %v, got %v", expected, controllers)
+	}
+
+	controllers = getVmDiskControllers(nil, extraConfig)
+	if len(controllers) != 0 {
+		t.Errorf("expected no controllers without VM spec section, got %v", controllers)
+	}
+}
+
+func TestSetVmDiskControllers(t *testing.T) {
+	vmSpecSection := &types.VmSpecSection{
+		DiskSection: &types.DiskSection{
+			DiskSettings: []*types.DiskSettings{
+				{AdapterType: "4", BusNumber: 0, UnitNumber: 0},
+				{AdapterType: "6", BusNumber: 0, UnitNumber: 0},
+			},
+		},
+		MediaSection: &types.MediaSection{
+			MediaSettings: []*types.MediaSettings{
+				{AdapterType: "1", BusNumber: 1, UnitNumber: 0},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		declared []interface{}
+		expected []string
+	}{
+		// Without declared controllers, the disk controllers of the VM are reported
+		"not-declared": {
+			expected: []string{"sata:0", "scsi:0"},
+		},
+		// The declared controllers are reported, even without disk, while the SATA controller is left out
+		"declared": {
+			declared: []interface{}{
+				testDiskController("paravirtual", 0, "none"),
+				testDiskController("paravirtual", 1, "none"),
+			},
+			expected: []string{"scsi:0", "scsi:1"},
+		},
+	}
+	for name, test := range tests {
+		d := schema.TestResourceDataRaw(t, vappVmSchema, map[string]interface{}{"disk_controller": test.declared})
+		err := setVmDiskControllers(d, vmSpecSection, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		var keys []string
+		for _, rawController := range d.Get("disk_controller").(*schema.Set).List() {
+			controller := rawController.(map[string]interface{})
+			keys = append(keys, diskControllerKey(controller["bus_type"].(string), controller["bus_number"].(int)))
+		}
+		sort.Strings(keys)
+		if !reflect.DeepEqual(keys, test.expected) {
+			t.Errorf("%s: expected controllers %v, got %v", name, test.expected, keys)
+		}
+	}
+}
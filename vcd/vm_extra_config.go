@@ -0,0 +1,210 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// extra_config manages the advanced settings (VMX parameters) of a VM. They are returned by VCD as
+// vmw:ExtraConfig elements of the VM virtual hardware section, which govcd doesn't parse.
+
+// vmExtraConfigReserved lists the keys that VCD and vSphere set themselves. They can't be managed with extra_config.
+// A key ending with "*" matches all the keys with that prefix
+var vmExtraConfigReserved = []string{
+	"guestinfo.ovfEnv",
+	"guestinfo.vmware.*",
+	"guestinfo.gc.*",
+	"vmware.tools.*",
+	"tools.guest.*",
+	"nvram",
+	"uuid.*",
+	"vc.uuid",
+	"vmotion.*",
+	"migrate.*",
+	"numa.autosize.*",
+	"sched.swap.derivedName",
+	"virtualHW.*",
+	"pciBridge*",
+	"ethernet*",
+	"scsi*",
+	"sata*",
+	"ide*",
+	"nvme*",
+}
+
+// vmExtraConfigRead is used to read the extra configuration from the VM
+type vmExtraConfigRead struct {
+	XMLName                xml.Name `xml:"Vm"`
+	VirtualHardwareSection struct {
+		ExtraConfig []struct {
+			Key   string `xml:"http://www.vmware.com/schema/ovf key,attr"`
+			Value string `xml:"http://www.vmware.com/schema/ovf value,attr"`
+		} `xml:"http://www.vmware.com/schema/ovf ExtraConfig"`
+	} `xml:"http://schemas.dmtf.org/ovf/envelope/1 VirtualHardwareSection"`
+}
+
+// vmExtraConfigUpdate is the payload of reconfigureVm that changes the extra configuration.
+// Only the keys included in the payload are changed. A key with an empty value is removed. The description is sent
+// as it is, as reconfigureVm clears it otherwise
+type vmExtraConfigUpdate struct {
+	XMLName                xml.Name                      `xml:"Vm"`
+	Xmlns                  string                        `xml:"xmlns,attr"`
+	Ovf                    string                        `xml:"xmlns:ovf,attr"`
+	Vmw                    string                        `xml:"xmlns:vmw,attr"`
+	Name                   string                        `xml:"name,attr"`
+	Description            string                        `xml:"Description,omitempty"`
+	VirtualHardwareSection *vmExtraConfigHardwareSection `xml:"ovf:VirtualHardwareSection"`
+}
+
+type vmExtraConfigHardwareSection struct {
+	Info        string               `xml:"ovf:Info"`
+	ExtraConfig []*vmExtraConfigItem `xml:"vmw:ExtraConfig"`
+}
+
+type vmExtraConfigItem struct {
+	Required bool   `xml:"ovf:required,attr"`
+	Key      string `xml:"vmw:key,attr"`
+	Value    string `xml:"vmw:value,attr"`
+}
+
+// matchesExtraConfigKey returns true if the key matches one of the patterns.
+// A pattern ending with "*" matches all the keys with that prefix
+func matchesExtraConfigKey(key string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.HasSuffix(pattern, "*") {
+			if strings.HasPrefix(key, strings.TrimSuffix(pattern, "*")) {
+				return true
+			}
+			continue
+		}
+		if key == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// validateExtraConfig checks that "extra_config" doesn't contain empty or reserved keys
+func validateExtraConfig(value interface{}, key string) ([]string, []error) {
+	extraConfig, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, []error{fmt.Errorf("expected %s to be a map", key)}
+	}
+	var errors []error
+	for configKey := range extraConfig {
+		if strings.TrimSpace(configKey) == "" {
+			errors = append(errors, fmt.Errorf("%s contains an empty key", key))
+			continue
+		}
+		if matchesExtraConfigKey(configKey, vmExtraConfigReserved) {
+			errors = append(errors, fmt.Errorf("%s key '%s' is set by VCD and can't be managed", key, configKey))
+		}
+	}
+	return nil, errors
+}
+
+// getVmExtraConfig retrieves the extra configuration of a VM
+func getVmExtraConfig(vcdClient *VCDClient, vm *govcd.VM) (map[string]string, error) {
+	vmExtraConfig := &vmExtraConfigRead{}
+	_, err := vcdClient.Client.ExecuteRequest(vm.VM.HREF, http.MethodGet, types.MimeVM,
+		"error retrieving VM extra configuration: %s", nil, vmExtraConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	extraConfig := make(map[string]string)
+	for _, item := range vmExtraConfig.VirtualHardwareSection.ExtraConfig {
+		extraConfig[item.Key] = item.Value
+	}
+	return extraConfig, nil
+}
+
+// updateVmExtraConfig sets the given keys in the extra configuration of a VM. Keys with an empty value are removed
+func updateVmExtraConfig(vcdClient *VCDClient, vm *govcd.VM, changes map[string]string) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Sorting the keys keeps the payload stable, which helps when reading logs
+	keys := make([]string, 0, len(changes))
+	for key := range changes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	hardwareSection := &vmExtraConfigHardwareSection{Info: "Virtual hardware requirements"}
+	for _, key := range keys {
+		hardwareSection.ExtraConfig = append(hardwareSection.ExtraConfig, &vmExtraConfigItem{Key: key, Value: changes[key]})
+	}
+
+	log.Printf("[TRACE] updating extra configuration keys %v of VM %s", keys, vm.VM.Name)
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/reconfigureVm", http.MethodPost,
+		types.MimeVM, "error updating VM extra configuration: %s", &vmExtraConfigUpdate{
+			Xmlns:                  types.XMLNamespaceVCloud,
+			Ovf:                    types.XMLNamespaceOVF,
+			Vmw:                    types.XMLNamespaceVMW,
+			Name:                   vm.VM.Name,
+			Description:            vm.VM.Description,
+			VirtualHardwareSection: hardwareSection,
+		})
+	if err != nil {
+		return err
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf("error waiting for the extra configuration update of VM %s: %s", vm.VM.Name, err)
+	}
+	return vm.Refresh()
+}
+
+// getVmExtraConfigChanges returns the keys of "extra_config" that were added or changed, and the removed keys
+// with an empty value
+func getVmExtraConfigChanges(d *schema.ResourceData) map[string]string {
+	oldValue, newValue := d.GetChange("extra_config")
+	oldConfig := oldValue.(map[string]interface{})
+	newConfig := newValue.(map[string]interface{})
+
+	changes := make(map[string]string)
+	for key, value := range newConfig {
+		if previous, ok := oldConfig[key]; !ok || previous.(string) != value.(string) {
+			changes[key] = value.(string)
+		}
+	}
+	for key := range oldConfig {
+		if _, ok := newConfig[key]; !ok {
+			changes[key] = ""
+		}
+	}
+	return changes
+}
+
+// setVmExtraConfig stores the extra configuration of a VM into "extra_config".
+// A data source gets all the keys. A resource only tracks the keys it manages, and keeps the configured value of
+// the keys listed in "extra_config_ignore_changes", so that the values adjusted by VCD are not reported as drift
+func setVmExtraConfig(d *schema.ResourceData, extraConfig map[string]string, origin string) error {
+	if origin == "datasource" {
+		return d.Set("extra_config", extraConfig)
+	}
+
+	ignoreChanges := convertSchemaSetToSliceOfStrings(d.Get("extra_config_ignore_changes").(*schema.Set))
+	managed := d.Get("extra_config").(map[string]interface{})
+	newExtraConfig := make(map[string]string)
+	for key, value := range managed {
+		if matchesExtraConfigKey(key, ignoreChanges) {
+			newExtraConfig[key] = value.(string)
+			continue
+		}
+		if actual, ok := extraConfig[key]; ok {
+			newExtraConfig[key] = actual
+		}
+	}
+	return d.Set("extra_config", newExtraConfig)
+}
@@ -0,0 +1,136 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"encoding/xml"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestMatchesExtraConfigKey(t *testing.T) {
+	patterns := []string{"disk.EnableUUID", "sched.cpu.*"}
+	tests := map[string]bool{
+		"disk.EnableUUID":              true,
+		"disk.EnableUUID.extra":        false,
+		"sched.cpu.latencySensitivity": true,
+		"sched.mem.min":                false,
+		"":                             false,
+	}
+	for key, expected := range tests {
+		if matchesExtraConfigKey(key, patterns) != expected {
+			t.Errorf("key %q: expected match %t", key, expected)
+		}
+	}
+}
+
+func TestValidateExtraConfig(t *testing.T) {
+	_, errs := validateExtraConfig(map[string]interface{}{"disk.EnableUUID": "TRUE", "guestinfo.app": "1"}, "extra_config")
+	if len(errs) > 0 {
+		t.Errorf("unexpected errors: %v", errs)
+	}
+	_, errs = validateExtraConfig(map[string]interface{}{"vmware.tools.internalversion": "1", "ethernet0.present": "TRUE"}, "extra_config")
+	if len(errs) != 2 {
+		t.Errorf("expected 2 errors for reserved keys, got %v", errs)
+	}
+	_, errs = validateExtraConfig(map[string]interface{}{" ": "x"}, "extra_config")
+	if len(errs) != 1 {
+		t.Errorf("expected 1 error for empty key, got %v", errs)
+	}
+}
+
+func TestVmExtraConfigXml(t *testing.T) {
+	vmXml := `<Vm xmlns="` + types.XMLNamespaceVCloud + `" xmlns:ovf="` + types.XMLNamespaceOVF +
+		`" xmlns:vmw="` + types.XMLNamespaceVMW + `" name="vm1">
+  <ovf:VirtualHardwareSection>
+    <ovf:Info>Virtual hardware requirements</ovf:Info>
+    <vmw:ExtraConfig ovf:required="false" vmw:key="disk.EnableUUID" vmw:value="TRUE"/>
+    <vmw:ExtraConfig ovf:required="false" vmw:key="nvram" vmw:value="vm1.nvram"/>
+  </ovf:VirtualHardwareSection>
+</Vm>`
+	read := &vmExtraConfigRead{}
+	err := xml.Unmarshal([]byte(vmXml), read)
+	if err != nil {
+		t.Fatalf("error unmarshalling: %s", err)
+	}
+	items := read.VirtualHardwareSection.ExtraConfig
+	if len(items) != 2 || items[0].Key != "disk.EnableUUID" || items[0].Value != "TRUE" || items[1].Key != "nvram" {
+		t.Errorf("unexpected extra configuration: %+v", items)
+	}
+
+	payload, err := xml.Marshal(&vmExtraConfigUpdate{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Ovf:         types.XMLNamespaceOVF,
+		Vmw:         types.XMLNamespaceVMW,
+		Name:        "vm1",
+		Description: "my VM",
+		VirtualHardwareSection: &vmExtraConfigHardwareSection{
+			Info:        "Virtual hardware requirements",
+			ExtraConfig: []*vmExtraConfigItem{{Key: "disk.EnableUUID", Value: "TRUE"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("error marshalling: %s", err)
+	}
+	// The description precedes the hardware section, as in the VM type of VCD
+	for _, expected := range []string{
+		`<Description>my VM</Description><ovf:VirtualHardwareSection>`,
+		`<vmw:ExtraConfig ovf:required="false" vmw:key="disk.EnableUUID" vmw:value="TRUE"></vmw:ExtraConfig>`,
+	} {
+		if !strings.Contains(string(payload), expected) {
+			t.Errorf("payload %s doesn't contain %s", payload, expected)
+		}
+	}
+}
+
+func TestGetVmExtraConfigChanges(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, vappVmSchema, map[string]interface{}{
+		"extra_config": map[string]interface{}{"disk.EnableUUID": "TRUE", "guestinfo.role": "worker"},
+	})
+	changes := getVmExtraConfigChanges(d)
+	expected := map[string]string{"disk.EnableUUID": "TRUE", "guestinfo.role": "worker"}
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("expected %v, got %v", expected, changes)
+	}
+}
+
+func TestSetVmExtraConfig(t *testing.T) {
+	extraConfig := map[string]string{
+		"disk.EnableUUID":              "TRUE",
+		"sched.cpu.latencySensitivity": "high",
+		"nvram":                        "vm1.nvram",
+	}
+
+	d := schema.TestResourceDataRaw(t, vappVmSchema, map[string]interface{}{
+		"extra_config": map[string]interface{}{
+			"disk.EnableUUID":              "TRUE",
+			"sched.cpu.latencySensitivity": "normal",
+			"guestinfo.removed":            "1",
+		},
+	})
+	err := setVmExtraConfig(d, extraConfig, "resource")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected := map[string]interface{}{"disk.EnableUUID": "TRUE", "sched.cpu.latencySensitivity": "high"}
+	if got := d.Get("extra_config"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+
+	d = schema.TestResourceDataRaw(t, vappVmSchema, map[string]interface{}{
+		"extra_config":                map[string]interface{}{"sched.cpu.latencySensitivity": "normal"},
+		"extra_config_ignore_changes": []interface{}{"sched.cpu.*"},
+	})
+	err = setVmExtraConfig(d, extraConfig, "resource")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expected = map[string]interface{}{"sched.cpu.latencySensitivity": "normal"}
+	if got := d.Get("extra_config"); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,171 @@
+package vcd
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The guest operating systems that VCD supports are returned by /api/supportedSystemsInfo, which govcd doesn't
+// expose. Each of them needs a minimum virtual hardware version. The "os_type" of a VM is the internal name of
+// its guest operating system, such as "centos64Guest".
+
+const mimeSupportedSystemsInfo = "application/vnd.vmware.vcloud.supportedSystemsInfo+xml"
+
+// vmSupportedSystemsInfo is used to read the guest operating systems supported by VCD
+type vmSupportedSystemsInfo struct {
+	XMLName  xml.Name `xml:"SupportedOperatingSystemsInfo"`
+	Families []struct {
+		Name             string `xml:"Name"`
+		OperatingSystems []struct {
+			Name                   string `xml:"Name"`
+			InternalName           string `xml:"InternalName"`
+			Supported              bool   `xml:"Supported"`
+			X64                    bool   `xml:"x64"`
+			MinimumHardwareVersion int    `xml:"MinimumHardwareVersion"`
+		} `xml:"OperatingSystem"`
+	} `xml:"OperatingSystemFamilyInfo"`
+}
+
+// vmGuestOs is a guest operating system supported by VCD
+type vmGuestOs struct {
+	family             string
+	name               string
+	internalName       string
+	is64Bit            bool
+	minHardwareVersion int
+}
+
+// getVmGuestOsList retrieves the guest operating systems supported by VCD
+func getVmGuestOsList(vcdClient *VCDClient) ([]vmGuestOs, error) {
+	supportedSystems := &vmSupportedSystemsInfo{}
+	_, err := vcdClient.Client.ExecuteRequest(vcdClient.Client.VCDHREF.String()+"/supportedSystemsInfo/", http.MethodGet,
+		mimeSupportedSystemsInfo, "error retrieving supported guest operating systems: %s", nil, supportedSystems)
+	if err != nil {
+		return nil, err
+	}
+	return getVmGuestOsFromSystemsInfo(supportedSystems), nil
+}
+
+// getVmGuestOsFromSystemsInfo returns the supported guest operating systems of a supportedSystemsInfo response
+func getVmGuestOsFromSystemsInfo(supportedSystems *vmSupportedSystemsInfo) []vmGuestOs {
+	var guestOsList []vmGuestOs
+	for _, family := range supportedSystems.Families {
+		for _, os := range family.OperatingSystems {
+			if !os.Supported || os.InternalName == "" {
+				continue
+			}
+			guestOsList = append(guestOsList, vmGuestOs{
+				family:             family.Name,
+				name:               os.Name,
+				internalName:       os.InternalName,
+				is64Bit:            os.X64,
+				minHardwareVersion: os.MinimumHardwareVersion,
+			})
+		}
+	}
+	return guestOsList
+}
+
+// filterVmGuestOs returns the guest operating systems available with a hardware version. When family is not
+// empty, only the ones of that family are returned
+func filterVmGuestOs(guestOsList []vmGuestOs, hardwareVersion int, family string) []vmGuestOs {
+	var filtered []vmGuestOs
+	for _, guestOs := range guestOsList {
+		if guestOs.minHardwareVersion > hardwareVersion || (family != "" && guestOs.family != family) {
+			continue
+		}
+		filtered = append(filtered, guestOs)
+	}
+	return filtered
+}
+
+// findVmGuestOs returns the guest operating system with the given internal name, or nil
+func findVmGuestOs(guestOsList []vmGuestOs, internalName string) *vmGuestOs {
+	for i := range guestOsList {
+		if guestOsList[i].internalName == internalName {
+			return &guestOsList[i]
+		}
+	}
+	return nil
+}
+
+// getHardwareVersionNumber returns the number of a virtual hardware version such as "vmx-14"
+func getHardwareVersionNumber(hardwareVersion string) (int, error) {
+	number, err := strconv.Atoi(strings.TrimPrefix(hardwareVersion, "vmx-"))
+	if err != nil || !strings.HasPrefix(hardwareVersion, "vmx-") {
+		return 0, fmt.Errorf("'%s' is not a valid hardware version. Expected a value like 'vmx-14'", hardwareVersion)
+	}
+	return number, nil
+}
+
+// validateHardwareVersionChange checks that a hardware version change is an upgrade, as VCD can't downgrade the
+// virtual hardware of a VM
+func validateHardwareVersionChange(oldVersion, newVersion string) error {
+	newNumber, err := getHardwareVersionNumber(newVersion)
+	if err != nil {
+		return err
+	}
+	if oldVersion == "" {
+		return nil
+	}
+	oldNumber, err := getHardwareVersionNumber(oldVersion)
+	if err != nil {
+		return nil
+	}
+	if newNumber < oldNumber {
+		return fmt.Errorf("'hardware_version' can't be downgraded from '%s' to '%s'", oldVersion, newVersion)
+	}
+	return nil
+}
+
+// validateVmGuestOs checks at plan time that a hardware version change is an upgrade, and that "os_type" is a
+// guest operating system supported by VCD with the hardware version of the VM
+func validateVmGuestOs(diff *schema.ResourceDiff, meta interface{}) error {
+	hardwareVersionKnown := diff.NewValueKnown("hardware_version")
+	if hardwareVersionKnown && diff.HasChange("hardware_version") && diff.Get("hardware_version").(string) != "" {
+		oldValue, newValue := diff.GetChange("hardware_version")
+		oldVersion := oldValue.(string)
+		if diff.Id() == "" {
+			oldVersion = ""
+		}
+		err := validateHardwareVersionChange(oldVersion, newValue.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	if meta == nil || !diff.NewValueKnown("os_type") || diff.Get("os_type").(string) == "" {
+		return nil
+	}
+	if !diff.HasChange("os_type") && !diff.HasChange("hardware_version") {
+		return nil
+	}
+	osType := diff.Get("os_type").(string)
+	guestOsList, err := getVmGuestOsList(meta.(*VCDClient))
+	if err != nil {
+		return err
+	}
+	guestOs := findVmGuestOs(guestOsList, osType)
+	if guestOs == nil {
+		return fmt.Errorf("'os_type' %s is not a guest operating system supported by VCD. "+
+			"The data source vcd_vm_guest_os lists the supported ones", osType)
+	}
+	if !hardwareVersionKnown || diff.Get("hardware_version").(string) == "" {
+		return nil
+	}
+	hardwareVersion := diff.Get("hardware_version").(string)
+	number, err := getHardwareVersionNumber(hardwareVersion)
+	if err != nil {
+		return err
+	}
+	if guestOs.minHardwareVersion > number {
+		return fmt.Errorf("'os_type' %s requires hardware version vmx-%d or later, got '%s'",
+			osType, guestOs.minHardwareVersion, hardwareVersion)
+	}
+	return nil
+}
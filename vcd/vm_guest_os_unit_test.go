@@ -0,0 +1,144 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+const testSupportedSystemsInfo = `<?xml version="1.0" encoding="UTF-8"?>
+<SupportedOperatingSystemsInfo xmlns="http://www.vmware.com/vcloud/v1.5">
+  <OperatingSystemFamilyInfo>
+    <Name>Linux</Name>
+    <OperatingSystemFamilyId>2</OperatingSystemFamilyId>
+    <OperatingSystem>
+      <OperatingSystemId>107</OperatingSystemId>
+      <Name>CentOS 7 (64-bit)</Name>
+      <InternalName>centos7_64Guest</InternalName>
+      <Supported>true</Supported>
+      <x64>true</x64>
+      <MinimumHardwareVersion>10</MinimumHardwareVersion>
+    </OperatingSystem>
+    <OperatingSystem>
+      <OperatingSystemId>113</OperatingSystemId>
+      <Name>CentOS 8 (64-bit)</Name>
+      <InternalName>centos8_64Guest</InternalName>
+      <Supported>true</Supported>
+      <x64>true</x64>
+      <MinimumHardwareVersion>15</MinimumHardwareVersion>
+    </OperatingSystem>
+    <OperatingSystem>
+      <OperatingSystemId>60</OperatingSystemId>
+      <Name>Other 2.4.x Linux (32-bit)</Name>
+      <InternalName>other24xLinuxGuest</InternalName>
+      <Supported>false</Supported>
+      <x64>false</x64>
+      <MinimumHardwareVersion>4</MinimumHardwareVersion>
+    </OperatingSystem>
+  </OperatingSystemFamilyInfo>
+  <OperatingSystemFamilyInfo>
+    <Name>Microsoft Windows</Name>
+    <OperatingSystemFamilyId>1</OperatingSystemFamilyId>
+    <OperatingSystem>
+      <OperatingSystemId>102</OperatingSystemId>
+      <Name>Microsoft Windows Server 2016 (64-bit)</Name>
+      <InternalName>windows9Server64Guest</InternalName>
+      <Supported>true</Supported>
+      <x64>true</x64>
+      <MinimumHardwareVersion>10</MinimumHardwareVersion>
+    </OperatingSystem>
+  </OperatingSystemFamilyInfo>
+</SupportedOperatingSystemsInfo>`
+
+func TestGetVmGuestOsFromSystemsInfo(t *testing.T) {
+	supportedSystems := &vmSupportedSystemsInfo{}
+	err := xml.Unmarshal([]byte(testSupportedSystemsInfo), supportedSystems)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	guestOsList := getVmGuestOsFromSystemsInfo(supportedSystems)
+	if len(guestOsList) != 3 {
+		t.Fatalf("expected 3 supported guest operating systems, got %d", len(guestOsList))
+	}
+
+	expected := vmGuestOs{
+		family:             "Linux",
+		name:               "CentOS 8 (64-bit)",
+		internalName:       "centos8_64Guest",
+		is64Bit:            true,
+		minHardwareVersion: 15,
+	}
+	guestOs := findVmGuestOs(guestOsList, "centos8_64Guest")
+	if guestOs == nil || *guestOs != expected {
+		t.Errorf("expected %+v, got %+v", expected, guestOs)
+	}
+	if findVmGuestOs(guestOsList, "other24xLinuxGuest") != nil {
+		t.Errorf("unsupported guest operating system should not be listed")
+	}
+
+	tests := []struct {
+		hardwareVersion int
+		family          string
+		expected        []string
+	}{
+		{14, "", []string{"centos7_64Guest", "windows9Server64Guest"}},
+		{15, "", []string{"centos7_64Guest", "centos8_64Guest", "windows9Server64Guest"}},
+		{15, "Linux", []string{"centos7_64Guest", "centos8_64Guest"}},
+		{9, "", nil},
+	}
+	for _, test := range tests {
+		filtered := filterVmGuestOs(guestOsList, test.hardwareVersion, test.family)
+		var names []string
+		for _, guestOs := range filtered {
+			names = append(names, guestOs.internalName)
+		}
+		if len(names) != len(test.expected) {
+			t.Errorf("vmx-%d %s: expected %v, got %v", test.hardwareVersion, test.family, test.expected, names)
+			continue
+		}
+		for i := range names {
+			if names[i] != test.expected[i] {
+				t.Errorf("vmx-%d %s: expected %v, got %v", test.hardwareVersion, test.family, test.expected, names)
+				break
+			}
+		}
+	}
+}
+
+func TestValidateHardwareVersionChange(t *testing.T) {
+	tests := []struct {
+		oldVersion string
+		newVersion string
+		wantError  bool
+	}{
+		{"vmx-13", "vmx-14", false},
+		{"vmx-14", "vmx-14", false},
+		{"", "vmx-11", false},
+		{"vmx-14", "vmx-13", true},
+		{"vmx-14", "14", true},
+		{"vmx-14", "vmx-", true},
+	}
+	for _, test := range tests {
+		err := validateHardwareVersionChange(test.oldVersion, test.newVersion)
+		if test.wantError && err == nil {
+			t.Errorf("'%s' to '%s': expected error, got none", test.oldVersion, test.newVersion)
+		}
+		if !test.wantError && err != nil {
+			t.Errorf("'%s' to '%s': unexpected error: %s", test.oldVersion, test.newVersion, err)
+		}
+	}
+}
+
+func TestGetHardwareVersionNumber(t *testing.T) {
+	number, err := getHardwareVersionNumber("vmx-14")
+	if err != nil || number != 14 {
+		t.Errorf("expected 14, got %d (%v)", number, err)
+	}
+	for _, invalid := range []string{"", "14", "vmx-", "vmx-abc"} {
+		_, err = getHardwareVersionNumber(invalid)
+		if err == nil {
+			t.Errorf("expected error for '%s'", invalid)
+		}
+	}
+}
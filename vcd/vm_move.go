@@ -0,0 +1,205 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// A VM of vcd_vapp_vm moves to another vApp, in the same VDC or in another VDC of the Org, by recomposing the target
+// vApp with the VM as sourced item, deleted from its source. VCD keeps the VM with its disks, but it must be powered
+// off. The move happens before the other changes of the update, which find the VM in its new vApp. The source and
+// target vApps stay locked for the whole update.
+
+// getVmMoveVdcNames returns the VDCs of the VM in the state and in the configuration. An empty VDC is the one of the
+// provider
+func getVmMoveVdcNames(d vmChangeGetter, defaultVdc string) (string, string) {
+	oldVdc, newVdc := d.GetChange("vdc")
+	sourceVdcName, targetVdcName := oldVdc.(string), newVdc.(string)
+	if sourceVdcName == "" {
+		sourceVdcName = defaultVdc
+	}
+	if targetVdcName == "" {
+		targetVdcName = defaultVdc
+	}
+	return sourceVdcName, targetVdcName
+}
+
+// getVmMoveLockKeys returns the lock keys of the source and target vApps of a move, sorted so that two moves in
+// opposite directions lock them in the same order
+func getVmMoveLockKeys(orgName, sourceVdcName, sourceVappName, targetVdcName, targetVappName string) []string {
+	keys := []string{
+		getVappLockKey(orgName, sourceVdcName, sourceVappName),
+		getVappLockKey(orgName, targetVdcName, targetVappName),
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// lockVmMoveVApps locks the source and target vApps of the VM move and returns the function that unlocks them
+func lockVmMoveVApps(d *schema.ResourceData, vcdClient *VCDClient) func() {
+	sourceVdcName, targetVdcName := getVmMoveVdcNames(d, vcdClient.Vdc)
+	oldVapp, newVapp := d.GetChange("vapp_name")
+	keys := getVmMoveLockKeys(vcdClient.getOrgName(d), sourceVdcName, oldVapp.(string), targetVdcName, newVapp.(string))
+	for _, key := range keys {
+		vcdMutexKV.kvLock(key)
+	}
+	return func() {
+		for i := len(keys) - 1; i >= 0; i-- {
+			vcdMutexKV.kvUnlock(keys[i])
+		}
+	}
+}
+
+// buildVmMoveParams returns the payload that recomposes the target vApp with the VM moved from its source vApp.
+// The compute policies are sent again, as the recompose removes the ones that are missing
+func buildVmMoveParams(targetVapp *types.VApp, vm *types.VM, network *types.NetworkConnectionSection,
+	storageProfile, sizingPolicy, placementPolicy *types.Reference) *types.ReComposeVAppParams {
+
+	params := &types.ReComposeVAppParams{
+		Ovf:         types.XMLNamespaceOVF,
+		Xsi:         types.XMLNamespaceXSI,
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        targetVapp.Name,
+		Description: targetVapp.Description,
+		SourcedItem: &types.SourcedCompositionItemParam{
+			SourceDelete: true,
+			Source:       &types.Reference{HREF: vm.HREF, Name: vm.Name},
+			VMGeneralParams: &types.VMGeneralParams{
+				Name:        vm.Name,
+				Description: vm.Description,
+			},
+			InstantiationParams: &types.InstantiationParams{NetworkConnectionSection: network},
+			StorageProfile:      storageProfile,
+		},
+		AllEULAsAccepted: true,
+	}
+	if sizingPolicy != nil || placementPolicy != nil {
+		params.SourcedItem.ComputePolicy = &types.ComputePolicy{
+			VmSizingPolicy:    sizingPolicy,
+			VmPlacementPolicy: placementPolicy,
+		}
+	}
+	return params
+}
+
+// moveVmToVApp moves the VM of the resource from the vApp and VDC of the state to the ones of the configuration.
+// The caller locks the source and target vApps with lockVmMoveVApps
+func moveVmToVApp(d *schema.ResourceData, vcdClient *VCDClient) error {
+	orgName := vcdClient.getOrgName(d)
+	sourceVdcName, targetVdcName := getVmMoveVdcNames(d, vcdClient.Vdc)
+	oldVapp, newVapp := d.GetChange("vapp_name")
+	sourceVappName, targetVappName := oldVapp.(string), newVapp.(string)
+
+	org, targetVdc, err := vcdClient.GetOrgAndVdc(orgName, targetVdcName)
+	if err != nil {
+		return fmt.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	targetVapp, err := targetVdc.GetVAppByName(targetVappName, false)
+	if err != nil {
+		return fmt.Errorf("[VM move] error finding target vApp %s in VDC %s: %s", targetVappName, targetVdcName, err)
+	}
+
+	// A move that was interrupted after VCD completed it doesn't need to be repeated
+	if _, err = targetVapp.GetVMById(d.Id(), false); err == nil {
+		log.Printf("[DEBUG] [VM move] VM %s is already in vApp %s", d.Id(), targetVappName)
+		return nil
+	}
+
+	sourceVdc, err := org.GetVDCByName(sourceVdcName, false)
+	if err != nil {
+		return fmt.Errorf("[VM move] error finding source VDC %s: %s", sourceVdcName, err)
+	}
+	sourceVapp, err := sourceVdc.GetVAppByName(sourceVappName, false)
+	if err != nil {
+		return fmt.Errorf("[VM move] error finding source vApp %s: %s", sourceVappName, err)
+	}
+	vm, err := sourceVapp.GetVMById(d.Id(), false)
+	if err != nil {
+		return fmt.Errorf("[VM move] error getting VM %s: %s", d.Id(), err)
+	}
+	// The changes that a snapshot blocks fail before the VM moves
+	err = checkVmSnapshotConstraints(d, vm)
+	if err != nil {
+		return err
+	}
+
+	vmStatus, err := vm.GetStatus()
+	if err != nil {
+		return fmt.Errorf("[VM move] error getting VM %s status: %s", vm.VM.Name, err)
+	}
+	if vmStatus != "POWERED_OFF" {
+		if d.Get("prevent_update_power_off").(bool) {
+			return fmt.Errorf("update stopped: VM needs to power off to move to another vApp, but `prevent_update_power_off` is `true`")
+		}
+		log.Printf("[DEBUG] [VM move] un-deploying VM %s before moving it. Previous state %s", vm.VM.Name, vmStatus)
+		err = powerOffVm(d, vcdClient, vm)
+		if err != nil {
+			return err
+		}
+	}
+
+	networkConnectionSection := types.NetworkConnectionSection{}
+	if len(d.Get("network").([]interface{})) > 0 {
+		networkConnectionSection, err = networksToConfig(d, targetVdc, *targetVapp, vcdClient)
+		if err != nil {
+			return fmt.Errorf("[VM move] unable to process network configuration: %s", err)
+		}
+	}
+
+	// Without storage profile in the configuration, the VM keeps its own when it stays in the same VDC, and gets the
+	// default one of the target VDC otherwise
+	var storageProfile *types.Reference
+	if storageProfileName := d.Get("storage_profile").(string); storageProfileName != "" {
+		reference, err := targetVdc.FindStorageProfileReference(storageProfileName)
+		if err != nil {
+			return fmt.Errorf("[VM move] error retrieving storage profile %s: %s", storageProfileName, err)
+		}
+		storageProfile = &reference
+	} else if sourceVdc.Vdc.ID == targetVdc.Vdc.ID {
+		storageProfile = vm.VM.StorageProfile
+	}
+
+	var sizingPolicy *types.Reference
+	if sizingPolicyId, ok := d.GetOk("sizing_policy_id"); ok {
+		sizingPolicy, err = getVdcComputePolicyReference(vcdClient, sizingPolicyId.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	var placementPolicy *types.Reference
+	if placementPolicyId, ok := d.GetOk("placement_policy_id"); ok {
+		placementPolicy, err = getVdcComputePolicyReference(vcdClient, placementPolicyId.(string))
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[TRACE] [VM move] moving VM %s from vApp %s (VDC %s) to vApp %s (VDC %s)", vm.VM.Name,
+		sourceVappName, sourceVdcName, targetVappName, targetVdcName)
+	params := buildVmMoveParams(targetVapp.VApp, vm.VM, &networkConnectionSection, storageProfile, sizingPolicy,
+		placementPolicy)
+	task, err := vcdClient.Client.ExecuteTaskRequestWithApiVersion(targetVapp.VApp.HREF+"/action/recomposeVApp",
+		http.MethodPost, types.MimeRecomposeVappParams, "error moving VM: %s", params,
+		vcdClient.Client.GetSpecificApiVersionOnCondition(">= 33.0", "33.0"))
+	if err != nil {
+		return fmt.Errorf("[VM move] error moving VM %s: %s", vm.VM.Name, err)
+	}
+	err = task.WaitTaskCompletion()
+	if err != nil {
+		return fmt.Errorf(errorCompletingTask, err)
+	}
+
+	// VCD keeps the ID of the VM within the VDC, but it is read again in case the VM changed VDC
+	movedVm, err := targetVapp.GetVMByName(vm.VM.Name, true)
+	if err != nil {
+		return fmt.Errorf("[VM move] error getting VM %s in vApp %s: %s", vm.VM.Name, targetVappName, err)
+	}
+	d.SetId(movedVm.VM.ID)
+	return nil
+}
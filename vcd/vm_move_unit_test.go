@@ -0,0 +1,81 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestGetVmMoveLockKeys(t *testing.T) {
+	keys := getVmMoveLockKeys("org", "vdc1", "vapp2", "vdc1", "vapp1")
+	reverseKeys := getVmMoveLockKeys("org", "vdc1", "vapp1", "vdc1", "vapp2")
+	expected := []string{"org:org|vdc:vdc1|vapp:vapp1", "org:org|vdc:vdc1|vapp:vapp2"}
+	if !reflect.DeepEqual(keys, expected) || !reflect.DeepEqual(reverseKeys, expected) {
+		t.Errorf("expected keys %v in both directions, got %v and %v", expected, keys, reverseKeys)
+	}
+}
+
+func TestGetVmMoveVdcNames(t *testing.T) {
+	tests := map[string]struct {
+		oldVdc, newVdc                 string
+		expectedSource, expectedTarget string
+	}{
+		"same-vdc":        {"vdc1", "vdc1", "vdc1", "vdc1"},
+		"default-vdc-set": {"", "vdc1", "vdc1", "vdc1"},
+		"new-vdc":         {"", "vdc2", "vdc1", "vdc2"},
+		"default-vdc":     {"vdc2", "", "vdc2", "vdc1"},
+	}
+	for name, test := range tests {
+		changes := testVmChanges{
+			oldValues: map[string]interface{}{"vdc": test.oldVdc},
+			newValues: map[string]interface{}{"vdc": test.newVdc},
+		}
+		sourceVdcName, targetVdcName := getVmMoveVdcNames(changes, "vdc1")
+		if sourceVdcName != test.expectedSource || targetVdcName != test.expectedTarget {
+			t.Errorf("%s: expected VDCs %s and %s, got %s and %s", name, test.expectedSource, test.expectedTarget,
+				sourceVdcName, targetVdcName)
+		}
+	}
+}
+
+func TestBuildVmMoveParams(t *testing.T) {
+	targetVapp := &types.VApp{Name: "target", Description: "target vApp"}
+	vm := &types.VM{Name: "vm1", Description: "moved VM", HREF: "https://vcd/api/vApp/vm-1"}
+	network := &types.NetworkConnectionSection{PrimaryNetworkConnectionIndex: 0}
+	storageProfile := &types.Reference{HREF: "https://vcd/api/vdcStorageProfile/1"}
+
+	params := buildVmMoveParams(targetVapp, vm, network, storageProfile, nil, nil)
+	if params.Name != "target" || params.Description != "target vApp" {
+		t.Errorf("expected the name and description of the target vApp, got %s and %s", params.Name, params.Description)
+	}
+	item := params.SourcedItem
+	if !item.SourceDelete {
+		t.Errorf("expected the source VM to be deleted")
+	}
+	if item.Source.HREF != vm.HREF || item.VMGeneralParams.Name != "vm1" || item.VMGeneralParams.Description != "moved VM" {
+		t.Errorf("expected the VM to keep its name and description, got %+v", item.VMGeneralParams)
+	}
+	if item.InstantiationParams.NetworkConnectionSection != network || item.StorageProfile != storageProfile {
+		t.Errorf("expected the given network and storage profile")
+	}
+	if item.ComputePolicy != nil {
+		t.Errorf("expected no compute policy, got %+v", item.ComputePolicy)
+	}
+
+	sizingPolicy := &types.Reference{HREF: "https://vcd/cloudapi/1.0.0/vdcComputePolicies/1"}
+	params = buildVmMoveParams(targetVapp, vm, network, nil, sizingPolicy, nil)
+	if params.SourcedItem.ComputePolicy == nil || params.SourcedItem.ComputePolicy.VmSizingPolicy != sizingPolicy ||
+		params.SourcedItem.ComputePolicy.VmPlacementPolicy != nil {
+		t.Errorf("expected sizing policy %s only", sizingPolicy.HREF)
+	}
+
+	placementPolicy := &types.Reference{HREF: "https://vcd/cloudapi/1.0.0/vdcComputePolicies/2"}
+	params = buildVmMoveParams(targetVapp, vm, network, nil, sizingPolicy, placementPolicy)
+	if params.SourcedItem.ComputePolicy == nil || params.SourcedItem.ComputePolicy.VmSizingPolicy != sizingPolicy ||
+		params.SourcedItem.ComputePolicy.VmPlacementPolicy != placementPolicy {
+		t.Errorf("expected sizing policy %s and placement policy %s", sizingPolicy.HREF, placementPolicy.HREF)
+	}
+}
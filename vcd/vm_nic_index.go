@@ -0,0 +1,277 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// The NICs of a VM are identified by their network connection index in VCD, which is stored in "nic_index".
+// The index of a NIC can be set in the configuration. When it is not, a NIC keeps the index saved in the state for
+// its position in the list, unless it is on another network than the NIC saved in that position: it is then matched
+// with a NIC of the state on its network, as the NICs after a removed one move up in the list. A new NIC gets the
+// first free index. Setting "nic_index" allows adding or removing a NIC in the middle of the list without changing
+// the following ones.
+
+// getNicIndexes returns the network connection index of each element of the 'network' list. oldNetworks is the
+// list saved in the state, where the NICs added to the list are missing. nicIndexSet tells, for each element, whether
+// its index is set: a new NIC without an index reports 0
+func getNicIndexes(networks, oldNetworks []interface{}, nicIndexSet []bool) ([]int, error) {
+	nicIndexes := make([]int, len(networks))
+	usedIndexes := make(map[int]bool)
+	var newNics, movedNics []int
+	for position, rawNic := range networks {
+		nic := rawNic.(map[string]interface{})
+		nicIndex := nic["nic_index"].(int)
+		if position >= len(oldNetworks) && !nicIndexSet[position] {
+			newNics = append(newNics, position)
+			continue
+		}
+		if position < len(oldNetworks) {
+			oldNic := oldNetworks[position].(map[string]interface{})
+			if oldNic["nic_index"].(int) == nicIndex && !isSameNicNetwork(nic, oldNic) {
+				movedNics = append(movedNics, position)
+				continue
+			}
+		}
+		if usedIndexes[nicIndex] {
+			return nil, fmt.Errorf("NIC index %d is used by more than one 'network' block", nicIndex)
+		}
+		usedIndexes[nicIndex] = true
+		nicIndexes[position] = nicIndex
+	}
+
+	// A NIC that moved in the list takes the index of a NIC of the state on the same network
+	var changedNics []int
+	for _, position := range movedNics {
+		nic := networks[position].(map[string]interface{})
+		matched := false
+		for _, rawOldNic := range oldNetworks {
+			oldNic := rawOldNic.(map[string]interface{})
+			oldIndex := oldNic["nic_index"].(int)
+			if !usedIndexes[oldIndex] && isSameNicNetwork(nic, oldNic) {
+				nicIndexes[position] = oldIndex
+				usedIndexes[oldIndex] = true
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			changedNics = append(changedNics, position)
+		}
+	}
+	// Otherwise, the NIC in its position is connected to another network, when its index is still free
+	for _, position := range changedNics {
+		nicIndex := networks[position].(map[string]interface{})["nic_index"].(int)
+		if usedIndexes[nicIndex] {
+			newNics = append(newNics, position)
+			continue
+		}
+		nicIndexes[position] = nicIndex
+		usedIndexes[nicIndex] = true
+	}
+	sort.Ints(newNics)
+
+	freeIndex := 0
+	for _, position := range newNics {
+		for usedIndexes[freeIndex] {
+			freeIndex++
+		}
+		nicIndexes[position] = freeIndex
+		usedIndexes[freeIndex] = true
+	}
+	return nicIndexes, nil
+}
+
+// isSameNicNetwork returns true if two elements of the 'network' list are connected to the same network
+func isSameNicNetwork(nic, otherNic map[string]interface{}) bool {
+	return nic["type"] == otherNic["type"] && nic["name"] == otherNic["name"]
+}
+
+// getNicIndexSet returns, for each element of the 'network' list, whether its NIC index is set in the configuration
+// or in the state. An index which is not set is unknown until apply
+func getNicIndexSet(d *schema.ResourceData, nicCount int) []bool {
+	nicIndexSet := make([]bool, nicCount)
+	for position := range nicIndexSet {
+		_, nicIndexSet[position] = d.GetOkExists(fmt.Sprintf("network.%d.nic_index", position))
+	}
+	return nicIndexSet
+}
+
+// sortNetworkConnections sorts the network connections of a VM in the order of their index in the 'network' list,
+// so that a list where the indexes are not in ascending order is read in the same order. The connections which are
+// not in the list follow, sorted by index
+func sortNetworkConnections(connections []*types.NetworkConnection, networks []interface{}) {
+	positions := make(map[int]int)
+	for position, rawNic := range networks {
+		nic, ok := rawNic.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if nicIndex, ok := nic["nic_index"].(int); ok {
+			positions[nicIndex] = position
+		}
+	}
+	sort.SliceStable(connections, func(i, j int) bool {
+		iPosition, iFound := positions[connections[i].NetworkConnectionIndex]
+		jPosition, jFound := positions[connections[j].NetworkConnectionIndex]
+		if iFound && jFound {
+			return iPosition < jPosition
+		}
+		if iFound != jFound {
+			return iFound
+		}
+		return connections[i].NetworkConnectionIndex < connections[j].NetworkConnectionIndex
+	})
+}
+
+// getNicComputedValue returns a field of a NIC that VCD computes when it is not set, such as the MAC address.
+// When a NIC moves to another position of the list, the plan carries the value of the NIC that was before in that
+// position, which is replaced with the value of the NIC with the same index, or with an empty one for a new NIC
+func getNicComputedValue(nic map[string]interface{}, key string, position, nicIndex int, oldNetworks []interface{}) string {
+	value := nic[key].(string)
+	if position >= len(oldNetworks) {
+		return value
+	}
+	oldNic := oldNetworks[position].(map[string]interface{})
+	if oldNic["nic_index"].(int) == nicIndex || oldNic[key].(string) != value {
+		return value
+	}
+	for _, rawOldNic := range oldNetworks {
+		oldNic := rawOldNic.(map[string]interface{})
+		if oldNic["nic_index"].(int) == nicIndex {
+			return oldNic[key].(string)
+		}
+	}
+	return ""
+}
+
+// vmSchemaV0 is the schema of vcd_vapp_vm at version 0, as released before "nic_index" was added to the 'network'
+// blocks. It is a frozen copy, as the state of version 0 must be read with the fields of that version. Only the types
+// matter
+var vmSchemaV0 = map[string]*schema.Schema{
+	"accept_all_eulas":    {Type: schema.TypeBool, Optional: true},
+	"boot_image":          {Type: schema.TypeString, Optional: true},
+	"catalog_name":        {Type: schema.TypeString, Optional: true},
+	"computer_name":       {Type: schema.TypeString, Optional: true, Computed: true},
+	"cpu_cores":           {Type: schema.TypeInt, Optional: true, Computed: true},
+	"cpu_hot_add_enabled": {Type: schema.TypeBool, Optional: true},
+	"cpus":                {Type: schema.TypeInt, Optional: true, Computed: true},
+	"customization": {Type: schema.TypeList, Optional: true, Computed: true, MaxItems: 1,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"admin_password":                      {Type: schema.TypeString, Optional: true, Computed: true},
+			"allow_local_admin_password":          {Type: schema.TypeBool, Optional: true, Computed: true},
+			"auto_generate_password":              {Type: schema.TypeBool, Optional: true, Computed: true},
+			"change_sid":                          {Type: schema.TypeBool, Optional: true, Computed: true},
+			"enabled":                             {Type: schema.TypeBool, Optional: true, Computed: true},
+			"force":                               {Type: schema.TypeBool, Optional: true},
+			"initscript":                          {Type: schema.TypeString, Optional: true, Computed: true},
+			"join_domain":                         {Type: schema.TypeBool, Optional: true, Computed: true},
+			"join_domain_account_ou":              {Type: schema.TypeString, Optional: true, Computed: true},
+			"join_domain_name":                    {Type: schema.TypeString, Optional: true, Computed: true},
+			"join_domain_password":                {Type: schema.TypeString, Optional: true, Computed: true},
+			"join_domain_user":                    {Type: schema.TypeString, Optional: true, Computed: true},
+			"join_org_domain":                     {Type: schema.TypeBool, Optional: true, Computed: true},
+			"must_change_password_on_first_login": {Type: schema.TypeBool, Optional: true, Computed: true},
+			"number_of_auto_logons":               {Type: schema.TypeInt, Optional: true, Computed: true},
+		}},
+	},
+	"description": {Type: schema.TypeString, Optional: true, Computed: true},
+	"disk": {Type: schema.TypeSet, Optional: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"bus_number":  {Type: schema.TypeString, Required: true},
+			"name":        {Type: schema.TypeString, Required: true},
+			"size_in_mb":  {Type: schema.TypeInt, Computed: true},
+			"unit_number": {Type: schema.TypeString, Required: true},
+		}},
+	},
+	"expose_hardware_virtualization": {Type: schema.TypeBool, Optional: true},
+	"guest_properties":               {Type: schema.TypeMap, Optional: true},
+	"hardware_version":               {Type: schema.TypeString, Optional: true, Computed: true},
+	"href":                           {Type: schema.TypeString, Optional: true, Computed: true},
+	"internal_disk": {Type: schema.TypeList, Computed: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"bus_number":       {Type: schema.TypeInt, Computed: true},
+			"bus_type":         {Type: schema.TypeString, Computed: true},
+			"disk_id":          {Type: schema.TypeString, Computed: true},
+			"iops":             {Type: schema.TypeInt, Computed: true},
+			"size_in_mb":       {Type: schema.TypeInt, Computed: true},
+			"storage_profile":  {Type: schema.TypeString, Computed: true},
+			"thin_provisioned": {Type: schema.TypeBool, Computed: true},
+			"unit_number":      {Type: schema.TypeInt, Computed: true},
+		}},
+	},
+	"memory":                 {Type: schema.TypeInt, Optional: true, Computed: true},
+	"memory_hot_add_enabled": {Type: schema.TypeBool, Optional: true},
+	"metadata":               {Type: schema.TypeMap, Optional: true},
+	"name":                   {Type: schema.TypeString, Required: true},
+	"network": {Type: schema.TypeList, Optional: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"adapter_type":       {Type: schema.TypeString, Optional: true, Computed: true},
+			"connected":          {Type: schema.TypeBool, Optional: true},
+			"ip":                 {Type: schema.TypeString, Optional: true, Computed: true},
+			"ip_allocation_mode": {Type: schema.TypeString, Optional: true},
+			"is_primary":         {Type: schema.TypeBool, Optional: true, Computed: true},
+			"mac":                {Type: schema.TypeString, Optional: true, Computed: true},
+			"name":               {Type: schema.TypeString, Optional: true},
+			"type":               {Type: schema.TypeString, Required: true},
+		}},
+	},
+	"network_dhcp_wait_seconds": {Type: schema.TypeInt, Optional: true},
+	"org":                       {Type: schema.TypeString, Optional: true},
+	"os_type":                   {Type: schema.TypeString, Optional: true, Computed: true},
+	"override_template_disk": {Type: schema.TypeSet, Optional: true,
+		Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+			"bus_number":      {Type: schema.TypeInt, Required: true},
+			"bus_type":        {Type: schema.TypeString, Required: true},
+			"iops":            {Type: schema.TypeInt, Optional: true},
+			"size_in_mb":      {Type: schema.TypeInt, Required: true},
+			"storage_profile": {Type: schema.TypeString, Optional: true},
+			"unit_number":     {Type: schema.TypeInt, Required: true},
+		}},
+	},
+	"power_on":                 {Type: schema.TypeBool, Optional: true},
+	"prevent_update_power_off": {Type: schema.TypeBool, Optional: true},
+	"sizing_policy_id":         {Type: schema.TypeString, Optional: true, Computed: true},
+	"storage_profile":          {Type: schema.TypeString, Optional: true, Computed: true},
+	"template_name":            {Type: schema.TypeString, Optional: true},
+	"vapp_name":                {Type: schema.TypeString, Required: true},
+	"vdc":                      {Type: schema.TypeString, Optional: true},
+	"vm_name_in_template":      {Type: schema.TypeString, Optional: true},
+}
+
+// standaloneVmSchemaV0 returns the schema of vcd_vm at version 0. vcd_vm was added with the same fields as
+// vcd_vapp_vm, except for "vapp_name", which is only reported
+func standaloneVmSchemaV0() map[string]*schema.Schema {
+	vmSchema := make(map[string]*schema.Schema, len(vmSchemaV0))
+	for key, value := range vmSchemaV0 {
+		vmSchema[key] = value
+	}
+	vmSchema["vapp_name"] = &schema.Schema{Type: schema.TypeString, Computed: true}
+	return vmSchema
+}
+
+// resourceVcdVAppVmV0 is the version of vcd_vapp_vm before "nic_index" was added
+func resourceVcdVAppVmV0() *schema.Resource {
+	return &schema.Resource{Schema: vmSchemaV0}
+}
+
+// resourceVcdStandaloneVmV0 is the version of vcd_vm before "nic_index" was added
+func resourceVcdStandaloneVmV0() *schema.Resource {
+	return &schema.Resource{Schema: standaloneVmSchemaV0()}
+}
+
+// upgradeVmStateV0 sets the NIC index of the 'network' blocks saved before it was in the schema. Up to that version,
+// the NIC index was the position in the list. The next refresh reads the actual index from VCD
+func upgradeVmStateV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	position := 0
+	err := forEachStateBlock(rawState, "network", func(block map[string]interface{}) error {
+		setStateAttributeDefault(block, "nic_index", position)
+		position++
+		return nil
+	})
+	return rawState, err
+}
@@ -0,0 +1,192 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func testNic(nicIndex int, network, mac string) map[string]interface{} {
+	return map[string]interface{}{
+		"nic_index": nicIndex,
+		"type":      "org",
+		"name":      network,
+		"mac":       mac,
+	}
+}
+
+func TestGetNicIndexes(t *testing.T) {
+	oldNetworks := []interface{}{testNic(0, "net-a", "a"), testNic(1, "net-b", "b"), testNic(2, "net-c", "c"), testNic(3, "net-d", "d")}
+
+	tests := map[string]struct {
+		networks    []interface{}
+		oldNetworks []interface{}
+		// nicIndexSet lists the positions of the new NICs with an index in the configuration
+		nicIndexSet []int
+		expected    []int
+		wantError   bool
+	}{
+		"unchanged": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(1, "net-b", "b"), testNic(2, "net-c", "c"), testNic(3, "net-d", "d")},
+			oldNetworks: oldNetworks,
+			expected:    []int{0, 1, 2, 3},
+		},
+		// Without "nic_index" in the configuration, the NICs after the removed one get the index of the NIC that was
+		// in their position, but keep their own as they are on its network
+		"removed-second-nic": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(1, "net-c", "b"), testNic(2, "net-d", "c")},
+			oldNetworks: oldNetworks,
+			expected:    []int{0, 2, 3},
+		},
+		"removed-second-nic-with-index": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(2, "net-c", "b"), testNic(3, "net-d", "c")},
+			oldNetworks: oldNetworks,
+			expected:    []int{0, 2, 3},
+		},
+		"changed-network": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(1, "net-e", "b"), testNic(2, "net-c", "c"), testNic(3, "net-d", "d")},
+			oldNetworks: oldNetworks,
+			expected:    []int{0, 1, 2, 3},
+		},
+		// The NIC on net-c moves to index 2, and the new network of the third position takes the index left by net-b
+		"removed-second-nic-changed-last": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(1, "net-c", "b"), testNic(2, "net-e", "c")},
+			oldNetworks: oldNetworks[:3],
+			expected:    []int{0, 2, 1},
+		},
+		"swapped-nics": {
+			networks:    []interface{}{testNic(0, "net-b", "a"), testNic(1, "net-a", "b")},
+			oldNetworks: oldNetworks[:2],
+			expected:    []int{1, 0},
+		},
+		"added-nics": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(2, "net-c", "b"), testNic(3, "net-d", "c"), testNic(0, "net-e", ""), testNic(0, "net-f", "")},
+			oldNetworks: oldNetworks[:3],
+			expected:    []int{0, 2, 3, 1, 4},
+		},
+		"new-vm": {
+			networks:    []interface{}{testNic(0, "net-a", ""), testNic(0, "net-b", ""), testNic(5, "net-c", "")},
+			nicIndexSet: []int{2},
+			expected:    []int{0, 1, 5},
+		},
+		"new-vm-explicit-zero": {
+			networks:    []interface{}{testNic(0, "net-a", ""), testNic(0, "net-b", "")},
+			nicIndexSet: []int{1},
+			expected:    []int{1, 0},
+		},
+		"new-nic-explicit-zero-clash": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(0, "net-e", "")},
+			oldNetworks: oldNetworks[:1],
+			nicIndexSet: []int{1},
+			wantError:   true,
+		},
+		"duplicate-index": {
+			networks:    []interface{}{testNic(0, "net-a", "a"), testNic(0, "net-b", "b")},
+			oldNetworks: oldNetworks,
+			wantError:   true,
+		},
+	}
+	for name, test := range tests {
+		nicIndexSet := make([]bool, len(test.networks))
+		for position := range nicIndexSet {
+			nicIndexSet[position] = position < len(test.oldNetworks)
+		}
+		for _, position := range test.nicIndexSet {
+			nicIndexSet[position] = true
+		}
+		nicIndexes, err := getNicIndexes(test.networks, test.oldNetworks, nicIndexSet)
+		if test.wantError {
+			if err == nil {
+				t.Errorf("%s: expected error, got none", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(nicIndexes, test.expected) {
+			t.Errorf("%s: expected %v, got %v", name, test.expected, nicIndexes)
+		}
+	}
+}
+
+func TestSortNetworkConnections(t *testing.T) {
+	connections := []*types.NetworkConnection{
+		{NetworkConnectionIndex: 0}, {NetworkConnectionIndex: 1}, {NetworkConnectionIndex: 2}, {NetworkConnectionIndex: 3},
+	}
+	networks := []interface{}{testNic(2, "net-a", ""), testNic(0, "net-b", "")}
+	sortNetworkConnections(connections, networks)
+	var nicIndexes []int
+	for _, connection := range connections {
+		nicIndexes = append(nicIndexes, connection.NetworkConnectionIndex)
+	}
+	// The NICs of the list come first, in its order, followed by the others
+	expected := []int{2, 0, 1, 3}
+	if !reflect.DeepEqual(nicIndexes, expected) {
+		t.Errorf("expected %v, got %v", expected, nicIndexes)
+	}
+}
+
+func TestGetNicComputedValue(t *testing.T) {
+	oldNetworks := []interface{}{testNic(0, "net-a", "a"), testNic(1, "net-b", "b"), testNic(2, "net-c", "c")}
+
+	tests := map[string]struct {
+		nic      map[string]interface{}
+		position int
+		nicIndex int
+		expected string
+	}{
+		// The NIC in the same position keeps its value
+		"same-nic": {testNic(1, "net", "b"), 1, 1, "b"},
+		// The third NIC moved to the second position, with the MAC of the second one in the plan
+		"moved-nic": {testNic(2, "net", "b"), 1, 2, "c"},
+		// A value set in the configuration is kept
+		"changed-value": {testNic(2, "net", "e"), 1, 2, "e"},
+		// A NIC that takes a free index has no value to inherit
+		"new-index": {testNic(5, "net", "b"), 1, 5, ""},
+		// A NIC added at the end of the list
+		"added-nic": {testNic(3, "net", ""), 3, 3, ""},
+	}
+	for name, test := range tests {
+		value := getNicComputedValue(test.nic, "mac", test.position, test.nicIndex, oldNetworks)
+		if value != test.expected {
+			t.Errorf("%s: expected '%s', got '%s'", name, test.expected, value)
+		}
+	}
+}
+
+func TestUpgradeVmStateV0(t *testing.T) {
+	for name, resource := range map[string]*schema.Resource{"vcd_vapp_vm": resourceVcdVAppVm(), "vcd_vm": resourceVcdStandaloneVm()} {
+		state := map[string]interface{}{
+			"name": "vm",
+			"network": []interface{}{
+				map[string]interface{}{"type": "org", "name": "net1"},
+				map[string]interface{}{"type": "none"},
+			},
+		}
+		expected := map[string]interface{}{
+			"name": "vm",
+			"network": []interface{}{
+				map[string]interface{}{"type": "org", "name": "net1", "nic_index": 0},
+				map[string]interface{}{"type": "none", "nic_index": 1},
+			},
+		}
+		upgraded, err := upgradeResourceState(context.Background(), resource, state, 0, nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if !reflect.DeepEqual(upgraded, expected) {
+			t.Errorf("%s: expected %v, got %v", name, expected, upgraded)
+		}
+		previousNetwork := resource.StateUpgraders[0].Type.AttributeType("network").ElementType()
+		if previousNetwork.HasAttribute("nic_index") {
+			t.Errorf("%s: previous schema must not have 'nic_index'", name)
+		}
+	}
+}
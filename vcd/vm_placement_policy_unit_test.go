@@ -0,0 +1,87 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestIsVmPlacementPolicy(t *testing.T) {
+	cpuCount := 2
+	tests := map[string]struct {
+		policy   *types.VdcComputePolicy
+		expected bool
+	}{
+		"sizing": {
+			policy:   &types.VdcComputePolicy{Name: "sizing", CPUCount: &cpuCount},
+			expected: false,
+		},
+		"vm-groups": {
+			policy: &types.VdcComputePolicy{
+				Name:          "placement",
+				PvdcID:        "urn:vcloud:providervdc:1",
+				NamedVMGroups: [][]computePolicyGroupReference{{{ID: "urn:vcloud:vmGroup:1"}}},
+			},
+			expected: true,
+		},
+		"logical-vm-groups": {
+			policy: &types.VdcComputePolicy{
+				Name:                     "placement",
+				LogicalVMGroupReferences: []computePolicyGroupReference{{ID: "urn:vcloud:logicalVmGroup:1"}},
+			},
+			expected: true,
+		},
+	}
+	for name, test := range tests {
+		if isVmPlacementPolicy(test.policy) != test.expected {
+			t.Errorf("%s: expected placement policy %t", name, test.expected)
+		}
+	}
+}
+
+func TestSetVmPlacementPolicyGroups(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceVcdVmPlacementPolicy().Schema, map[string]interface{}{
+		"name":                 "placement",
+		"provider_vdc_id":      "urn:vcloud:providervdc:1",
+		"vm_group_ids":         []interface{}{"urn:vcloud:vmGroup:1", "urn:vcloud:vmGroup:2"},
+		"logical_vm_group_ids": []interface{}{"urn:vcloud:logicalVmGroup:1"},
+	})
+
+	policy := &types.VdcComputePolicy{}
+	setVmPlacementPolicyGroups(d, policy)
+	if len(policy.NamedVMGroups) != 1 || len(policy.NamedVMGroups[0]) != 2 {
+		t.Fatalf("expected one list of 2 VM groups, got %v", policy.NamedVMGroups)
+	}
+	if len(policy.LogicalVMGroupReferences) != 1 || policy.LogicalVMGroupReferences[0].ID != "urn:vcloud:logicalVmGroup:1" {
+		t.Fatalf("expected one logical VM group, got %v", policy.LogicalVMGroupReferences)
+	}
+
+	err := setVmPlacementPolicyData(d, policy)
+	if err != nil {
+		t.Fatalf("error setting data: %s", err)
+	}
+	if d.Get("vm_group_ids").(*schema.Set).Len() != 2 {
+		t.Errorf("expected 2 VM group IDs, got %v", d.Get("vm_group_ids"))
+	}
+}
+
+func TestValidateVmPlacementPolicyChange(t *testing.T) {
+	tests := []struct {
+		oldId, newId string
+		expectError  bool
+	}{
+		{"", "urn:vcloud:vdcComputePolicy:1", false},
+		{"urn:vcloud:vdcComputePolicy:1", "urn:vcloud:vdcComputePolicy:2", false},
+		{"urn:vcloud:vdcComputePolicy:1", "", true},
+		{"", "", false},
+	}
+	for _, test := range tests {
+		err := validateVmPlacementPolicyChange(test.oldId, test.newId)
+		if test.expectError != (err != nil) {
+			t.Errorf("'%s' to '%s': expected error %t, got %v", test.oldId, test.newId, test.expectError, err)
+		}
+	}
+}
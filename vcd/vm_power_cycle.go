@@ -0,0 +1,136 @@
+package vcd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// An update of a VM powers it off when a change can't be applied while the VM is running (a cold update). The same
+// rules are evaluated at plan time, using only the plan and the state, so that a plan logs which changes will power
+// off the VM, and fails when "prevent_update_power_off" doesn't allow it.
+
+// vmColdUpdateFields are the fields that can only be changed while the VM is powered off
+var vmColdUpdateFields = []string{"cpu_cores", "power_on", "disk", "expose_hardware_virtualization", "boot_image",
+	"hardware_version", "os_type", "description", "cpu_hot_add_enabled", "memory_hot_add_enabled", "extra_config",
+	"firmware", "secure_boot", "latency_sensitivity", "disk_controller"}
+
+// vmChangeGetter is the part of schema.ResourceData and schema.ResourceDiff used to classify the changes of a VM
+type vmChangeGetter interface {
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// getVmPowerCycleReasons returns the changes of an update that need the VM to be powered off.
+// networkRemovalIsCold is true when the VCD version can't remove a NIC from a running VM, and defaultVdc is the VDC
+// of the provider
+func getVmPowerCycleReasons(d vmChangeGetter, vmType typeOfVm, networkRemovalIsCold bool, defaultVdc string) []string {
+	var reasons []string
+	// A VM is powered off to move to another vApp or VDC
+	if isVmMoved(d, vmType, defaultVdc) {
+		if d.HasChange("vapp_name") {
+			reasons = append(reasons, "vapp_name")
+		}
+		if sourceVdcName, targetVdcName := getVmMoveVdcNames(d, defaultVdc); sourceVdcName != targetVdcName {
+			reasons = append(reasons, "vdc")
+		}
+	}
+	for _, field := range vmColdUpdateFields {
+		if d.HasChange(field) {
+			reasons = append(reasons, field)
+		}
+	}
+	if d.HasChange("memory") && !d.Get("memory_hot_add_enabled").(bool) {
+		reasons = append(reasons, "memory")
+	}
+	if d.HasChange("cpus") && !d.Get("cpu_hot_add_enabled").(bool) {
+		reasons = append(reasons, "cpus")
+	}
+	if d.HasChange("network") && networkRemovalIsCold && isNetworkRemoved(d) {
+		reasons = append(reasons, "network")
+	}
+	return reasons
+}
+
+// isVmRunningBeforeUpdate returns true if the state expects the VM to be running: the power state read by the last
+// refresh when "power_state" is managed, "power_on" otherwise
+func isVmRunningBeforeUpdate(d vmChangeGetter) bool {
+	oldPowerState, _ := d.GetChange("power_state")
+	if oldPowerState.(string) != "" {
+		return oldPowerState.(string) == vmPowerStateOn
+	}
+	oldPowerOn, _ := d.GetChange("power_on")
+	return oldPowerOn.(bool)
+}
+
+// isVmMoved returns true if the update moves a VM of vcd_vapp_vm to another vApp or VDC. A standalone VM can't move
+func isVmMoved(d vmChangeGetter, vmType typeOfVm, defaultVdc string) bool {
+	if vmType != vappVmType {
+		return false
+	}
+	sourceVdcName, targetVdcName := getVmMoveVdcNames(d, defaultVdc)
+	return d.HasChange("vapp_name") || sourceVdcName != targetVdcName
+}
+
+// isVmReplaced returns true if the plan replaces the VM. The changes of a replaced VM don't power it off.
+// Besides the fields of the schema, "override_template_disk" replaces the VM for changes other than storage profiles
+func isVmReplaced(d vmChangeGetter, vmType typeOfVm) bool {
+	vmSchema := vappVmSchema
+	if vmType == standaloneVmType {
+		vmSchema = standaloneVmSchema()
+	}
+	for field, fieldSchema := range vmSchema {
+		if fieldSchema.ForceNew && d.HasChange(field) {
+			return true
+		}
+	}
+	return isOverrideTemplateDiskReplaced(d)
+}
+
+// isNetworkRemoved returns true if the update removes NICs from the VM
+func isNetworkRemoved(d vmChangeGetter) bool {
+	oldNetworks, newNetworks := d.GetChange("network")
+	return len(oldNetworks.([]interface{})) > len(newNetworks.([]interface{}))
+}
+
+// customizeVmPowerCycleDiff finds the changes of a plan that will power off a running VM. The plan fails if
+// "prevent_update_power_off" is true, otherwise it shows "power_cycle_required" as true. A VM that the state doesn't
+// expect to be running is left to the checks of the update
+func customizeVmPowerCycleDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}, vmType typeOfVm) error {
+	if diff.Id() == "" || meta == nil || isVmReplaced(diff, vmType) {
+		return nil
+	}
+	vcdClient := meta.(*VCDClient)
+	vmName := diff.Get("name").(string)
+
+	powerCycleRequired := false
+	if isVmRunningBeforeUpdate(diff) {
+		// A forced customization powers the VM off and on again on every update, also when
+		// "prevent_update_power_off" is true
+		if isForcedCustomization(diff.Get("customization")) && getVmDesiredPowerState(diff) == vmPowerStateOn {
+			log.Printf("[WARN] the forced customization will power off and on again VM %s", vmName)
+			powerCycleRequired = true
+		}
+		// Due to a bug in VCD 10.1, NICs can only be removed from a VM that is powered off
+		reasons := getVmPowerCycleReasons(diff, vmType, vcdClient.Client.APIVCDMaxVersionIs("= 34.0"), vcdClient.Vdc)
+		if len(reasons) > 0 {
+			if diff.Get("prevent_update_power_off").(bool) {
+				return fmt.Errorf("update stopped: VM needs to power off to change properties (%s), but `prevent_update_power_off` is `true`",
+					strings.Join(reasons, ", "))
+			}
+			log.Printf("[WARN] the changes to %s will power off VM %s", strings.Join(reasons, ", "), vmName)
+			powerCycleRequired = true
+		}
+	}
+
+	// The value is only planned together with other changes, so that it never makes a plan on its own. The update
+	// keeps the planned value in the state
+	if len(diff.GetChangedKeysPrefix("")) == 0 || diff.Get("power_cycle_required").(bool) == powerCycleRequired {
+		return nil
+	}
+	return diff.SetNew("power_cycle_required", powerCycleRequired)
+}
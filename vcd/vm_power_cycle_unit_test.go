@@ -0,0 +1,170 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"reflect"
+	"testing"
+)
+
+// testVmChanges implements vmChangeGetter with the values before and after an update
+type testVmChanges struct {
+	oldValues map[string]interface{}
+	newValues map[string]interface{}
+}
+
+func (c testVmChanges) Get(key string) interface{} {
+	if value, ok := c.newValues[key]; ok {
+		return value
+	}
+	return c.oldValues[key]
+}
+
+func (c testVmChanges) GetChange(key string) (interface{}, interface{}) {
+	return c.oldValues[key], c.Get(key)
+}
+
+func (c testVmChanges) HasChange(key string) bool {
+	newValue, ok := c.newValues[key]
+	return ok && !reflect.DeepEqual(c.oldValues[key], newValue)
+}
+
+func TestGetVmPowerCycleReasons(t *testing.T) {
+	oldValues := map[string]interface{}{
+		"memory":                 1024,
+		"cpus":                   2,
+		"cpu_cores":              1,
+		"memory_hot_add_enabled": true,
+		"cpu_hot_add_enabled":    false,
+		"power_on":               true,
+		"power_state":            "",
+		"network":                []interface{}{map[string]interface{}{"nic_index": 0}, map[string]interface{}{"nic_index": 1}},
+		"customization":          []interface{}{},
+		"metadata":               map[string]interface{}{},
+		"vapp_name":              "vapp1",
+		"vdc":                    "",
+	}
+
+	forcedCustomization := []interface{}{map[string]interface{}{"force": true}}
+	tests := map[string]struct {
+		newValues            map[string]interface{}
+		vmType               typeOfVm
+		networkRemovalIsCold bool
+		expected             []string
+	}{
+		"hot-changes": {
+			newValues: map[string]interface{}{
+				"memory":   2048,
+				"metadata": map[string]interface{}{"key": "value"},
+			},
+		},
+		"cpus-without-hot-add": {
+			newValues: map[string]interface{}{"cpus": 4, "memory": 2048},
+			expected:  []string{"cpus"},
+		},
+		"memory-hot-add-disabled": {
+			newValues: map[string]interface{}{"memory": 2048, "memory_hot_add_enabled": false},
+			expected:  []string{"memory_hot_add_enabled", "memory"},
+		},
+		"cold-fields": {
+			newValues: map[string]interface{}{"cpu_cores": 2, "os_type": "centos8_64Guest"},
+			expected:  []string{"cpu_cores", "os_type"},
+		},
+		"nic-removed": {
+			newValues: map[string]interface{}{"network": []interface{}{map[string]interface{}{"nic_index": 0}}},
+		},
+		"nic-removed-vcd-10.1": {
+			newValues:            map[string]interface{}{"network": []interface{}{map[string]interface{}{"nic_index": 0}}},
+			networkRemovalIsCold: true,
+			expected:             []string{"network"},
+		},
+		"forced-customization": {
+			newValues: map[string]interface{}{"customization": forcedCustomization},
+		},
+		"moved-to-vapp": {
+			newValues: map[string]interface{}{"vapp_name": "vapp2", "memory": 2048},
+			expected:  []string{"vapp_name"},
+		},
+		"moved-to-vdc": {
+			newValues: map[string]interface{}{"vdc": "vdc2"},
+			expected:  []string{"vdc"},
+		},
+		"moved-to-vapp-of-vdc": {
+			newValues: map[string]interface{}{"vapp_name": "vapp2", "vdc": "vdc2"},
+			expected:  []string{"vapp_name", "vdc"},
+		},
+		"standalone-vm-vapp-changed": {
+			newValues: map[string]interface{}{"vapp_name": "vapp2"},
+			vmType:    standaloneVmType,
+		},
+		"default-vdc-set": {
+			newValues: map[string]interface{}{"vdc": "vdc1"},
+		},
+	}
+	for name, test := range tests {
+		vmType := test.vmType
+		if vmType == "" {
+			vmType = vappVmType
+		}
+		changes := testVmChanges{oldValues: oldValues, newValues: test.newValues}
+		reasons := getVmPowerCycleReasons(changes, vmType, test.networkRemovalIsCold, "vdc1")
+		if !reflect.DeepEqual(reasons, test.expected) {
+			t.Errorf("%s: expected %v, got %v", name, test.expected, reasons)
+		}
+	}
+}
+
+func TestIsVmRunningBeforeUpdate(t *testing.T) {
+	tests := map[string]struct {
+		oldValues map[string]interface{}
+		newValues map[string]interface{}
+		expected  bool
+	}{
+		"powered-on":                {map[string]interface{}{"power_on": true, "power_state": ""}, nil, true},
+		"not-powered-on":            {map[string]interface{}{"power_on": false, "power_state": ""}, nil, false},
+		"power-state-on":            {map[string]interface{}{"power_on": false, "power_state": vmPowerStateOn}, nil, true},
+		"power-state-off":           {map[string]interface{}{"power_on": true, "power_state": vmPowerStateOff}, nil, false},
+		"power-state-suspended":     {map[string]interface{}{"power_on": true, "power_state": vmPowerStateSuspended}, nil, false},
+		"powered-on-by-update":      {map[string]interface{}{"power_on": false, "power_state": ""}, map[string]interface{}{"power_on": true}, false},
+		"power-state-off-to-on":     {map[string]interface{}{"power_on": true, "power_state": vmPowerStateOff}, map[string]interface{}{"power_state": vmPowerStateOn}, false},
+		"power-state-newly-managed": {map[string]interface{}{"power_on": true, "power_state": ""}, map[string]interface{}{"power_state": vmPowerStateOff}, true},
+	}
+	for name, test := range tests {
+		changes := testVmChanges{oldValues: test.oldValues, newValues: test.newValues}
+		if running := isVmRunningBeforeUpdate(changes); running != test.expected {
+			t.Errorf("%s: expected %t, got %t", name, test.expected, running)
+		}
+	}
+}
+
+func TestIsVmReplaced(t *testing.T) {
+	oldValues := map[string]interface{}{
+		"name":                   "vm1",
+		"vapp_name":              "vapp1",
+		"vdc":                    "vdc1",
+		"override_template_disk": overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "")),
+	}
+
+	tests := map[string]struct {
+		vmType    typeOfVm
+		newValues map[string]interface{}
+		expected  bool
+	}{
+		"vapp-vm-renamed":       {vappVmType, map[string]interface{}{"name": "vm2"}, true},
+		"vapp-vm-moved":         {vappVmType, map[string]interface{}{"vapp_name": "vapp2", "vdc": "vdc2"}, false},
+		"standalone-vm-new-vdc": {standaloneVmType, map[string]interface{}{"vdc": "vdc2"}, true},
+		"standalone-vm-updated": {standaloneVmType, map[string]interface{}{"memory": 2048}, false},
+		"template-disk-resized": {vappVmType, map[string]interface{}{
+			"override_template_disk": overrideTemplateDiskSet(overrideTemplateDisk(0, 2048, "")),
+		}, true},
+		"template-disk-relocated": {vappVmType, map[string]interface{}{
+			"override_template_disk": overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "*")),
+		}, false},
+	}
+	for name, test := range tests {
+		changes := testVmChanges{oldValues: oldValues, newValues: test.newValues}
+		if replaced := isVmReplaced(changes, test.vmType); replaced != test.expected {
+			t.Errorf("%s: expected %t, got %t", name, test.expected, replaced)
+		}
+	}
+}
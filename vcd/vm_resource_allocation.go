@@ -0,0 +1,228 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// The reservation, limit and shares of CPU and memory are part of the VM spec section and can be changed while the
+// VM is running. The latency sensitivity is a vSphere advanced setting, which is changed as an extra configuration
+// key and needs the VM to be powered off.
+
+const (
+	// vmLatencySensitivityKey is the extra configuration key of the latency sensitivity
+	vmLatencySensitivityKey = "sched.cpu.latencySensitivity"
+
+	vmLatencySensitivityNormal = "normal"
+	vmLatencySensitivityHigh   = "high"
+
+	// vmSharesLevelCustom is the shares level which uses the number of shares set on the VM
+	vmSharesLevelCustom = "CUSTOM"
+)
+
+// vmResourceAllocationFields are the fields of the CPU and memory resource allocation
+var vmResourceAllocationFields = []string{"cpu_reservation", "cpu_limit", "cpu_shares",
+	"memory_reservation", "memory_limit", "memory_shares"}
+
+// hasVmResourceAllocation returns true if any of the resource allocation fields needs to be sent to VCD
+func hasVmResourceAllocation(d *schema.ResourceData) bool {
+	for _, field := range vmResourceAllocationFields {
+		if isVmFieldSet(d, field) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateVmResourceAllocation sends to VCD the CPU and memory reservation, limit and shares that are set
+func updateVmResourceAllocation(d *schema.ResourceData, vm *govcd.VM) error {
+	vmSpecSection := vm.VM.VmSpecSection
+	if vmSpecSection == nil || vmSpecSection.CpuResourceMhz == nil || vmSpecSection.MemoryResourceMb == nil {
+		return fmt.Errorf("[VM update] VM %s has no CPU and memory resources section", vm.VM.Name)
+	}
+
+	cpu := vmSpecSection.CpuResourceMhz
+	if isVmFieldSet(d, "cpu_reservation") {
+		reservation := int64(d.Get("cpu_reservation").(int))
+		cpu.Reservation = &reservation
+	}
+	if isVmFieldSet(d, "cpu_limit") {
+		limit := int64(d.Get("cpu_limit").(int))
+		cpu.Limit = &limit
+	}
+	if isVmFieldSet(d, "cpu_shares") {
+		cpu.SharesLevel = vmSharesLevelCustom
+		cpu.Shares = takeIntPointer(d.Get("cpu_shares").(int))
+	}
+
+	memory := vmSpecSection.MemoryResourceMb
+	if isVmFieldSet(d, "memory_reservation") {
+		reservation := int64(d.Get("memory_reservation").(int))
+		memory.Reservation = &reservation
+	}
+	if isVmFieldSet(d, "memory_limit") {
+		limit := int64(d.Get("memory_limit").(int))
+		memory.Limit = &limit
+	}
+	if isVmFieldSet(d, "memory_shares") {
+		memory.SharesLevel = vmSharesLevelCustom
+		memory.Shares = takeIntPointer(d.Get("memory_shares").(int))
+	}
+
+	log.Printf("[TRACE] updating CPU and memory resource allocation of VM %s", vm.VM.Name)
+	_, err := vm.UpdateVmSpecSection(vmSpecSection, vm.VM.Description)
+	if err != nil {
+		return fmt.Errorf("error updating resource allocation of VM %s: %s", vm.VM.Name, err)
+	}
+	return nil
+}
+
+// setVmResourceAllocation stores the CPU and memory reservation, limit and shares of a VM into the state
+func setVmResourceAllocation(d *schema.ResourceData, vmSpecSection *types.VmSpecSection) {
+	if vmSpecSection == nil {
+		return
+	}
+	if cpu := vmSpecSection.CpuResourceMhz; cpu != nil {
+		if cpu.Reservation != nil {
+			_ = d.Set("cpu_reservation", int(*cpu.Reservation))
+		}
+		if cpu.Limit != nil {
+			_ = d.Set("cpu_limit", int(*cpu.Limit))
+		}
+		if cpu.Shares != nil {
+			_ = d.Set("cpu_shares", *cpu.Shares)
+		}
+	}
+	if memory := vmSpecSection.MemoryResourceMb; memory != nil {
+		if memory.Reservation != nil {
+			_ = d.Set("memory_reservation", int(*memory.Reservation))
+		}
+		if memory.Limit != nil {
+			_ = d.Set("memory_limit", int(*memory.Limit))
+		}
+		if memory.Shares != nil {
+			_ = d.Set("memory_shares", *memory.Shares)
+		}
+	}
+}
+
+// setVmLatencySensitivity stores the latency sensitivity found in the extra configuration of a VM
+func setVmLatencySensitivity(d *schema.ResourceData, extraConfig map[string]string) {
+	latencySensitivity := extraConfig[vmLatencySensitivityKey]
+	if latencySensitivity == "" {
+		latencySensitivity = vmLatencySensitivityNormal
+	}
+	_ = d.Set("latency_sensitivity", latencySensitivity)
+}
+
+// validateVmResourceAllocation checks the resource allocation fields against each other and against the latency
+// sensitivity. Values that are not known at plan time are not checked
+func validateVmResourceAllocation(diff vmDiffGetter) error {
+	for _, resource := range []string{"cpu", "memory"} {
+		reservationField, limitField := resource+"_reservation", resource+"_limit"
+		if !diff.NewValueKnown(reservationField) || !diff.NewValueKnown(limitField) {
+			continue
+		}
+		reservation, limit := diff.Get(reservationField).(int), diff.Get(limitField).(int)
+		// A limit of -1 means unlimited
+		if limit >= 0 && reservation > limit {
+			return fmt.Errorf("'%s' (%d) can't be greater than '%s' (%d)", reservationField, reservation, limitField, limit)
+		}
+	}
+
+	if !diff.NewValueKnown("latency_sensitivity") || diff.Get("latency_sensitivity").(string) != vmLatencySensitivityHigh {
+		return nil
+	}
+	if diff.NewValueKnown("extra_config") {
+		if _, ok := diff.Get("extra_config").(map[string]interface{})[vmLatencySensitivityKey]; ok {
+			return fmt.Errorf("'%s' is managed by 'latency_sensitivity' and can't be set in 'extra_config'", vmLatencySensitivityKey)
+		}
+	}
+	// vSphere only powers on a VM with high latency sensitivity when all its memory is reserved
+	if diff.NewValueKnown("memory") && diff.NewValueKnown("memory_reservation") {
+		memory, reservation := diff.Get("memory").(int), diff.Get("memory_reservation").(int)
+		if memory > 0 && reservation < memory {
+			return fmt.Errorf("'latency_sensitivity' = \"%s\" requires 'memory_reservation' to be the whole 'memory' (%d MB), got %d MB",
+				vmLatencySensitivityHigh, memory, reservation)
+		}
+	}
+	return nil
+}
+
+// vmSizingPolicyAllocations returns the resource allocation fields that a VM sizing policy defines, with their value.
+// When a VM uses a sizing policy, VCD applies these values instead of the ones of the VM
+func vmSizingPolicyAllocations(policy *types.VdcComputePolicy) map[string]string {
+	allocations := make(map[string]string)
+	if policy.CPUReservationGuarantee != nil {
+		allocations["cpu_reservation"] = fmt.Sprintf("%g CPU reservation guarantee", *policy.CPUReservationGuarantee)
+	}
+	if policy.CPULimit != nil {
+		allocations["cpu_limit"] = fmt.Sprintf("%d MHz", *policy.CPULimit)
+	}
+	if policy.CPUShares != nil {
+		allocations["cpu_shares"] = fmt.Sprintf("%d shares", *policy.CPUShares)
+	}
+	if policy.MemoryReservationGuarantee != nil {
+		allocations["memory_reservation"] = fmt.Sprintf("%g memory reservation guarantee", *policy.MemoryReservationGuarantee)
+	}
+	if policy.MemoryLimit != nil {
+		allocations["memory_limit"] = fmt.Sprintf("%d MB", *policy.MemoryLimit)
+	}
+	if policy.MemoryShares != nil {
+		allocations["memory_shares"] = fmt.Sprintf("%d shares", *policy.MemoryShares)
+	}
+	return allocations
+}
+
+// validateVmResourceAllocationWithSizingPolicy explains at plan time that the resource allocation fields defined by
+// the VM sizing policy can't be set on the VM. A field is checked when it changes, or when it is set in the
+// configuration of a new VM
+func validateVmResourceAllocationWithSizingPolicy(diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("sizing_policy_id") || meta == nil {
+		return nil
+	}
+	policyId := diff.Get("sizing_policy_id").(string)
+	if policyId == "" {
+		return nil
+	}
+	var setFields []string
+	for _, field := range vmResourceAllocationFields {
+		if !diff.NewValueKnown(field) {
+			continue
+		}
+		_, isSet := diff.GetOkExists(field)
+		if diff.HasChange(field) || (diff.Id() == "" && isSet) {
+			setFields = append(setFields, field)
+		}
+	}
+	if len(setFields) == 0 {
+		return nil
+	}
+
+	vcdClient := meta.(*VCDClient)
+	orgName := diff.Get("org").(string)
+	if orgName == "" {
+		orgName = vcdClient.Org
+	}
+	org, err := vcdClient.VCDClient.GetOrgByName(orgName)
+	if err != nil {
+		return fmt.Errorf("error retrieving Org %s: %s", orgName, err)
+	}
+	policy, err := org.GetVdcComputePolicyById(policyId)
+	if err != nil {
+		return fmt.Errorf("error getting sizing policy %s: %s", policyId, err)
+	}
+
+	allocations := vmSizingPolicyAllocations(policy.VdcComputePolicy)
+	for _, field := range setFields {
+		if value, ok := allocations[field]; ok {
+			return fmt.Errorf("'%s' can't be set, as the VM sizing policy %s defines it (%s) and takes precedence. "+
+				"Remove '%s' or use a sizing policy without it", field, policy.VdcComputePolicy.Name, value, field)
+		}
+	}
+	return nil
+}
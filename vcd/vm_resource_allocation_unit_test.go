@@ -0,0 +1,64 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+func TestValidateVmResourceAllocation(t *testing.T) {
+	tests := []struct {
+		name        string
+		diff        testVmDiff
+		expectError bool
+	}{
+		{"nothing-known", testVmDiff{}, false},
+		{"reservation-below-limit", testVmDiff{"cpu_reservation": 500, "cpu_limit": 1000}, false},
+		{"reservation-above-limit", testVmDiff{"cpu_reservation": 1500, "cpu_limit": 1000}, true},
+		{"reservation-unlimited", testVmDiff{"memory_reservation": 4096, "memory_limit": -1}, false},
+		{"memory-reservation-above-limit", testVmDiff{"memory_reservation": 4096, "memory_limit": 2048}, true},
+		{"reservation-unknown-limit", testVmDiff{"cpu_reservation": 1500}, false},
+		{"high-latency-full-reservation", testVmDiff{"latency_sensitivity": "high", "memory": 2048, "memory_reservation": 2048}, false},
+		{"high-latency-partial-reservation", testVmDiff{"latency_sensitivity": "high", "memory": 2048, "memory_reservation": 1024}, true},
+		{"high-latency-unknown-memory", testVmDiff{"latency_sensitivity": "high", "memory_reservation": 1024}, false},
+		{"normal-latency-partial-reservation", testVmDiff{"latency_sensitivity": "normal", "memory": 2048, "memory_reservation": 1024}, false},
+		{"high-latency-in-extra-config", testVmDiff{"latency_sensitivity": "high",
+			"extra_config": map[string]interface{}{vmLatencySensitivityKey: "high"}}, true},
+		{"other-extra-config", testVmDiff{"latency_sensitivity": "high",
+			"extra_config": map[string]interface{}{"ethernet0.coalescingScheme": "disabled"}}, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := validateVmResourceAllocation(test.diff)
+			if test.expectError && err == nil {
+				t.Errorf("expected error, got none")
+			}
+			if !test.expectError && err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+		})
+	}
+}
+
+func TestVmSizingPolicyAllocations(t *testing.T) {
+	guarantee := 0.5
+	limit := 2000
+	policy := &types.VdcComputePolicy{
+		CPUReservationGuarantee: &guarantee,
+		MemoryLimit:             &limit,
+	}
+	allocations := vmSizingPolicyAllocations(policy)
+	if len(allocations) != 2 {
+		t.Fatalf("expected 2 allocations, got %d: %v", len(allocations), allocations)
+	}
+	for _, field := range []string{"cpu_reservation", "memory_limit"} {
+		if _, ok := allocations[field]; !ok {
+			t.Errorf("expected '%s' to be defined by the policy", field)
+		}
+	}
+	if len(vmSizingPolicyAllocations(&types.VdcComputePolicy{})) != 0 {
+		t.Errorf("expected no allocations for an empty policy")
+	}
+}
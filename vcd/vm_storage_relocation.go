@@ -0,0 +1,150 @@
+package vcd
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/lmicke/go-vcloud-director/v2/govcd"
+	"github.com/lmicke/go-vcloud-director/v2/types/v56"
+)
+
+// A change of storage profile moves the VM files, or the files of a disk, to the datastores of the new storage
+// profile. VCD does it while the VM is running, but it can take a long time, so the progress of the task is logged.
+
+// vmRelocationProgressDelay is the interval between two checks of a relocation task
+const vmRelocationProgressDelay = 10 * time.Second
+
+// waitForVmRelocation waits for a relocation task, logging its progress
+func waitForVmRelocation(task govcd.Task, description string) error {
+	startTime := time.Now()
+	err := task.WaitInspectTaskCompletion(func(task *types.Task, howManyTimes int, elapsed time.Duration, first, last bool) {
+		if last {
+			log.Printf("[INFO] %s: %s after %s", description, task.Status, time.Since(startTime).Round(time.Second))
+			return
+		}
+		log.Printf("[INFO] %s: %d%% done after %s", description, task.Progress, time.Since(startTime).Round(time.Second))
+	}, vmRelocationProgressDelay)
+	if err != nil {
+		return fmt.Errorf("error waiting for %s: %s", description, err)
+	}
+	return nil
+}
+
+// relocateVm moves a VM, and the disks that use the VM storage profile, to another storage profile
+func relocateVm(vdc *govcd.Vdc, vm *govcd.VM, storageProfileName string) error {
+	storageProfile, err := vdc.FindStorageProfileReference(storageProfileName)
+	if err != nil {
+		return fmt.Errorf("[vm update] error retrieving storage profile %s : %s", storageProfileName, err)
+	}
+	task, err := vm.UpdateStorageProfileAsync(storageProfile.HREF)
+	if err != nil {
+		return fmt.Errorf("error updating changing storage profile to %s: %s", storageProfileName, err)
+	}
+	err = waitForVmRelocation(task, fmt.Sprintf("relocation of VM %s to storage profile %s", vm.VM.Name, storageProfileName))
+	if err != nil {
+		return err
+	}
+	return vm.Refresh()
+}
+
+// updateVmDisks applies the disk settings of the VM spec section, logging the progress, as they may relocate disks
+func updateVmDisks(vm *govcd.VM, vmSpecSection *types.VmSpecSection) error {
+	task, err := vm.UpdateInternalDisksAsync(vmSpecSection)
+	if err != nil {
+		return err
+	}
+	err = waitForVmRelocation(task, fmt.Sprintf("internal disks update of VM %s", vm.VM.Name))
+	if err != nil {
+		return err
+	}
+	return vm.Refresh()
+}
+
+// overrideTemplateDiskKey identifies a disk of "override_template_disk" by its position
+func overrideTemplateDiskKey(disk map[string]interface{}) string {
+	return fmt.Sprintf("%s:%d:%d", disk["bus_type"].(string), disk["bus_number"].(int), disk["unit_number"].(int))
+}
+
+// getOverrideTemplateDiskRelocations compares two values of "override_template_disk". It returns the disks which only
+// changed storage profile, and whether any other change happened, which requires recreating the VM
+func getOverrideTemplateDiskRelocations(oldDisks, newDisks *schema.Set) ([]map[string]interface{}, bool) {
+	oldByKey := make(map[string]map[string]interface{})
+	for _, disk := range oldDisks.List() {
+		oldDisk := disk.(map[string]interface{})
+		oldByKey[overrideTemplateDiskKey(oldDisk)] = oldDisk
+	}
+	if len(oldByKey) != newDisks.Len() {
+		return nil, true
+	}
+
+	var relocations []map[string]interface{}
+	for _, disk := range newDisks.List() {
+		newDisk := disk.(map[string]interface{})
+		oldDisk, ok := oldByKey[overrideTemplateDiskKey(newDisk)]
+		if !ok || oldDisk["size_in_mb"].(int) != newDisk["size_in_mb"].(int) || oldDisk["iops"].(int) != newDisk["iops"].(int) {
+			return nil, true
+		}
+		if oldDisk["storage_profile"].(string) != newDisk["storage_profile"].(string) {
+			relocations = append(relocations, newDisk)
+		}
+	}
+	return relocations, false
+}
+
+// isOverrideTemplateDiskReplaced returns true when "override_template_disk" has changes other than storage profiles,
+// which recreate the VM
+func isOverrideTemplateDiskReplaced(d vmChangeGetter) bool {
+	if !d.HasChange("override_template_disk") {
+		return false
+	}
+	oldDisks, newDisks := d.GetChange("override_template_disk")
+	_, forceNew := getOverrideTemplateDiskRelocations(oldDisks.(*schema.Set), newDisks.(*schema.Set))
+	return forceNew
+}
+
+// customizeOverrideTemplateDiskDiff recreates the VM when "override_template_disk" has changes other than
+// storage profiles, which are applied in place
+func customizeOverrideTemplateDiskDiff(diff *schema.ResourceDiff) error {
+	if diff.Id() == "" || !isOverrideTemplateDiskReplaced(diff) {
+		return nil
+	}
+	return diff.ForceNew("override_template_disk")
+}
+
+// relocateOverrideTemplateDisks moves the template disks whose storage profile changed in "override_template_disk".
+// A disk without storage profile goes back to the VM one
+func relocateOverrideTemplateDisks(d *schema.ResourceData, vdc *govcd.Vdc, vm *govcd.VM) error {
+	oldDisks, newDisks := d.GetChange("override_template_disk")
+	relocations, _ := getOverrideTemplateDiskRelocations(oldDisks.(*schema.Set), newDisks.(*schema.Set))
+	if len(relocations) == 0 {
+		return nil
+	}
+	if vm.VM.VmSpecSection == nil || vm.VM.VmSpecSection.DiskSection == nil {
+		return fmt.Errorf("[vm update] VM %s has no disk section", vm.VM.Name)
+	}
+
+	for _, disk := range relocations {
+		diskSettings := getMatchedDisk(disk, vm.VM.VmSpecSection.DiskSection.DiskSettings)
+		if diskSettings == nil {
+			return fmt.Errorf("[vm update] disk with bus type %s, bus number %d and unit number %d not found",
+				disk["bus_type"].(string), disk["bus_number"].(int), disk["unit_number"].(int))
+		}
+		storageProfileName := disk["storage_profile"].(string)
+		if storageProfileName == "" {
+			diskSettings.StorageProfile = vm.VM.StorageProfile
+			diskSettings.OverrideVmDefault = false
+			continue
+		}
+		storageProfile, err := vdc.FindStorageProfileReference(storageProfileName)
+		if err != nil {
+			return fmt.Errorf("[vm update] error retrieving storage profile %s : %s", storageProfileName, err)
+		}
+		diskSettings.StorageProfile = &storageProfile
+		diskSettings.OverrideVmDefault = true
+	}
+
+	log.Printf("[TRACE] relocating %d template disks of VM %s", len(relocations), vm.VM.Name)
+	return updateVmDisks(vm, vm.VM.VmSpecSection)
+}
@@ -0,0 +1,69 @@
+// +build unit ALL
+
+package vcd
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func overrideTemplateDiskSet(disks ...map[string]interface{}) *schema.Set {
+	elem := vappVmSchema["override_template_disk"].Elem.(*schema.Resource)
+	var list []interface{}
+	for _, disk := range disks {
+		list = append(list, disk)
+	}
+	return schema.NewSet(schema.HashResource(elem), list)
+}
+
+func overrideTemplateDisk(unitNumber, sizeInMb int, storageProfile string) map[string]interface{} {
+	return map[string]interface{}{
+		"bus_type":        "paravirtual",
+		"bus_number":      0,
+		"unit_number":     unitNumber,
+		"size_in_mb":      sizeInMb,
+		"iops":            0,
+		"storage_profile": storageProfile,
+	}
+}
+
+func TestGetOverrideTemplateDiskRelocations(t *testing.T) {
+	oldDisks := overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "*"), overrideTemplateDisk(1, 2048, ""))
+
+	tests := map[string]struct {
+		newDisks            *schema.Set
+		expectedRelocations int
+		expectedForceNew    bool
+	}{
+		"unchanged": {
+			newDisks:            overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "*"), overrideTemplateDisk(1, 2048, "")),
+			expectedRelocations: 0,
+		},
+		"storage-profile": {
+			newDisks:            overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "gold"), overrideTemplateDisk(1, 2048, "silver")),
+			expectedRelocations: 2,
+		},
+		"size": {
+			newDisks:         overrideTemplateDiskSet(overrideTemplateDisk(0, 4096, "gold"), overrideTemplateDisk(1, 2048, "")),
+			expectedForceNew: true,
+		},
+		"removed-disk": {
+			newDisks:         overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "*")),
+			expectedForceNew: true,
+		},
+		"moved-disk": {
+			newDisks:         overrideTemplateDiskSet(overrideTemplateDisk(0, 1024, "*"), overrideTemplateDisk(2, 2048, "")),
+			expectedForceNew: true,
+		},
+	}
+	for name, test := range tests {
+		relocations, forceNew := getOverrideTemplateDiskRelocations(oldDisks, test.newDisks)
+		if forceNew != test.expectedForceNew {
+			t.Errorf("%s: expected force new %t, got %t", name, test.expectedForceNew, forceNew)
+		}
+		if len(relocations) != test.expectedRelocations {
+			t.Errorf("%s: expected %d relocations, got %d", name, test.expectedRelocations, len(relocations))
+		}
+	}
+}